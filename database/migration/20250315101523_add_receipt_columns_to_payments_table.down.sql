@@ -0,0 +1,7 @@
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS invoice_number,
+    DROP COLUMN IF EXISTS discount,
+    DROP COLUMN IF EXISTS badge,
+    DROP COLUMN IF EXISTS has_receipt;
+
+DROP SEQUENCE IF EXISTS payment_invoice_number_seq;
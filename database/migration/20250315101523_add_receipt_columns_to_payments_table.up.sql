@@ -0,0 +1,7 @@
+CREATE SEQUENCE payment_invoice_number_seq;
+
+ALTER TABLE payments
+    ADD COLUMN invoice_number BIGINT UNIQUE,
+    ADD COLUMN discount       INT         NOT NULL DEFAULT 0,
+    ADD COLUMN badge          VARCHAR(50),
+    ADD COLUMN has_receipt    BOOLEAN     NOT NULL DEFAULT FALSE;
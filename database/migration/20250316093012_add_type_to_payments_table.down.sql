@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_mentor_transaction_histories_created_at;
+DROP INDEX IF EXISTS idx_payments_created_at;
+
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS type;
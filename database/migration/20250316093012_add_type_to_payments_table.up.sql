@@ -0,0 +1,15 @@
+ALTER TABLE payments
+    ADD COLUMN type VARCHAR(50);
+
+UPDATE payments
+SET type = CASE title
+               WHEN 'Skill Boost Subscription' THEN 'boost'
+               WHEN 'Skill Challenge Subscription' THEN 'challenge'
+               WHEN 'Skill Guidance Subscription' THEN 'guidance'
+    END;
+
+ALTER TABLE payments
+    ALTER COLUMN type SET NOT NULL;
+
+CREATE INDEX idx_payments_created_at ON payments (created_at);
+CREATE INDEX idx_mentor_transaction_histories_created_at ON mentor_transaction_histories (created_at);
@@ -0,0 +1,5 @@
+ALTER TABLE course_enrollments
+    DROP COLUMN IF EXISTS is_purchased;
+
+ALTER TABLE courses
+    DROP COLUMN IF EXISTS price;
@@ -0,0 +1,5 @@
+ALTER TABLE courses
+    ADD COLUMN price INT NOT NULL DEFAULT 0 CHECK (price >= 0);
+
+ALTER TABLE course_enrollments
+    ADD COLUMN is_purchased BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_payments_user_id_type_status;
+
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS product_id;
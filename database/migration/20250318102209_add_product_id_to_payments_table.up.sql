@@ -0,0 +1,4 @@
+ALTER TABLE payments
+    ADD COLUMN product_id UUID;
+
+CREATE INDEX idx_payments_user_id_type_status ON payments (user_id, type, status);
@@ -0,0 +1,3 @@
+ALTER TABLE mentoring_chats
+    DROP COLUMN IF EXISTS student_last_read_message_id,
+    DROP COLUMN IF EXISTS mentor_last_read_message_id;
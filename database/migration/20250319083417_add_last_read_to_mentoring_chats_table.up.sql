@@ -0,0 +1,3 @@
+ALTER TABLE mentoring_chats
+    ADD COLUMN student_last_read_message_id UUID REFERENCES mentoring_messages (id) ON DELETE SET NULL,
+    ADD COLUMN mentor_last_read_message_id  UUID REFERENCES mentoring_messages (id) ON DELETE SET NULL;
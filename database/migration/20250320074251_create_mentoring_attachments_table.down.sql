@@ -0,0 +1 @@
+DROP TABLE IF EXISTS mentoring_attachments;
@@ -0,0 +1,14 @@
+CREATE TABLE mentoring_attachments
+(
+    id           UUID PRIMARY KEY,
+    chat_id      UUID                     NOT NULL REFERENCES mentoring_chats (id) ON DELETE CASCADE,
+    uploader_id  UUID                     NOT NULL REFERENCES users (id),
+    message_id   UUID REFERENCES mentoring_messages (id) ON DELETE CASCADE,
+    file_name    VARCHAR(255)             NOT NULL,
+    content_type VARCHAR(100)             NOT NULL,
+    size         BIGINT                   NOT NULL,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX mentoring_attachments_message_id_key ON mentoring_attachments (message_id);
+CREATE INDEX mentoring_attachments_chat_id_idx ON mentoring_attachments (chat_id);
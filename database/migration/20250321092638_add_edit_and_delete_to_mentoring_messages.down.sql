@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS mentoring_message_edits;
+
+ALTER TABLE mentoring_messages
+    DROP COLUMN IF EXISTS edited_at,
+    DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,11 @@
+ALTER TABLE mentoring_messages
+    ADD COLUMN edited_at  TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE mentoring_message_edits
+(
+    message_id UUID                     NOT NULL REFERENCES mentoring_messages (id) ON DELETE CASCADE,
+    message    VARCHAR(2000)            NOT NULL,
+    edited_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (message_id, edited_at)
+);
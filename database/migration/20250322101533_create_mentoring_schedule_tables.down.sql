@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS mentoring_sessions;
+DROP TABLE IF EXISTS mentor_availability_exceptions;
+DROP TABLE IF EXISTS mentor_availabilities;
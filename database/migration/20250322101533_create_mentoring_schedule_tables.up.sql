@@ -0,0 +1,51 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+CREATE TABLE mentor_availabilities
+(
+    id           UUID PRIMARY KEY,
+    mentor_id    UUID     NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    weekday      SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    -- minutes since midnight in the platform timezone (WIB)
+    start_minute SMALLINT NOT NULL CHECK (start_minute BETWEEN 0 AND 1439),
+    end_minute   SMALLINT NOT NULL CHECK (end_minute BETWEEN 1 AND 1440),
+    CHECK (start_minute < end_minute)
+);
+
+CREATE INDEX mentor_availabilities_mentor_id_idx ON mentor_availabilities (mentor_id);
+
+CREATE TABLE mentor_availability_exceptions
+(
+    id        UUID PRIMARY KEY,
+    mentor_id UUID                     NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+    reason    VARCHAR(255),
+    CHECK (starts_at < ends_at)
+);
+
+CREATE INDEX mentor_availability_exceptions_mentor_id_idx ON mentor_availability_exceptions (mentor_id);
+
+CREATE TABLE mentoring_sessions
+(
+    id               UUID PRIMARY KEY,
+    mentor_id        UUID                     NOT NULL REFERENCES users (id),
+    student_id       UUID                     NOT NULL REFERENCES students (user_id),
+    starts_at        TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at          TIMESTAMP WITH TIME ZONE NOT NULL,
+    status           VARCHAR(50)              NOT NULL,
+    hold_until       TIMESTAMP WITH TIME ZONE,
+    reminder_sent_at TIMESTAMP WITH TIME ZONE,
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (starts_at < ends_at),
+    -- pending sessions hold their slot until paid or cancelled, so concurrent bookings cannot overlap
+    CONSTRAINT mentoring_sessions_mentor_overlap EXCLUDE USING gist (
+        mentor_id WITH =,
+        tstzrange(starts_at, ends_at) WITH &&
+        ) WHERE (status <> 'cancelled'),
+    CONSTRAINT mentoring_sessions_student_overlap EXCLUDE USING gist (
+        student_id WITH =,
+        tstzrange(starts_at, ends_at) WITH &&
+        ) WHERE (status <> 'cancelled')
+);
+
+CREATE INDEX mentoring_sessions_status_starts_at_idx ON mentoring_sessions (status, starts_at);
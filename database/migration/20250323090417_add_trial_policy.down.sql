@@ -0,0 +1,16 @@
+DROP INDEX IF EXISTS mentoring_trials_student_id_idx;
+DROP INDEX IF EXISTS mentoring_trials_student_id_mentor_id_key;
+
+DELETE
+FROM mentoring_trials a
+    USING mentoring_trials b
+WHERE a.student_id = b.student_id
+  AND a.ctid > b.ctid;
+
+ALTER TABLE mentoring_trials
+    DROP COLUMN IF EXISTS created_at,
+    DROP COLUMN IF EXISTS mentor_id,
+    ADD PRIMARY KEY (student_id);
+
+ALTER TABLE mentors
+    DROP COLUMN IF EXISTS accepts_trial;
@@ -0,0 +1,11 @@
+ALTER TABLE mentors
+    ADD COLUMN accepts_trial BOOLEAN NOT NULL DEFAULT TRUE;
+
+-- trials are now tracked per mentor, rows created before this have no mentor
+ALTER TABLE mentoring_trials
+    DROP CONSTRAINT mentoring_trials_pkey,
+    ADD COLUMN mentor_id  UUID REFERENCES users (id) ON DELETE CASCADE,
+    ADD COLUMN created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+CREATE UNIQUE INDEX mentoring_trials_student_id_mentor_id_key ON mentoring_trials (student_id, mentor_id);
+CREATE INDEX mentoring_trials_student_id_idx ON mentoring_trials (student_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS mentoring_chats_expires_at_idx;
+
+ALTER TABLE mentoring_chats
+    DROP COLUMN IF EXISTS expiry_closed_at,
+    DROP COLUMN IF EXISTS expiry_warning_sent_at;
@@ -0,0 +1,11 @@
+ALTER TABLE mentoring_chats
+    ADD COLUMN expiry_warning_sent_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN expiry_closed_at       TIMESTAMP WITH TIME ZONE;
+
+-- chats that already expired need no notification
+UPDATE mentoring_chats
+SET expiry_warning_sent_at = expires_at,
+    expiry_closed_at       = expires_at
+WHERE expires_at <= CURRENT_TIMESTAMP;
+
+CREATE INDEX mentoring_chats_expires_at_idx ON mentoring_chats (expires_at);
@@ -0,0 +1,12 @@
+CREATE TABLE mentoring_transcripts
+(
+    id           UUID PRIMARY KEY,
+    chat_id      UUID                     NOT NULL REFERENCES mentoring_chats (id) ON DELETE CASCADE,
+    requester_id UUID                     NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    format       VARCHAR(50)              NOT NULL,
+    status       VARCHAR(50)              NOT NULL,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX mentoring_transcripts_chat_id_idx ON mentoring_transcripts (chat_id);
@@ -0,0 +1,6 @@
+ALTER TABLE course_materials
+    DROP COLUMN IF EXISTS section_id;
+ALTER TABLE course_videos
+    DROP COLUMN IF EXISTS section_id;
+
+DROP TABLE IF EXISTS course_sections;
@@ -0,0 +1,21 @@
+CREATE TABLE course_sections
+(
+    id          UUID PRIMARY KEY,
+    course_id   UUID                     NOT NULL REFERENCES courses (id) ON DELETE CASCADE,
+    title       VARCHAR(50)              NOT NULL,
+    description VARCHAR(1000)            NOT NULL DEFAULT '',
+    "order"     INT                      NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX course_sections_course_id_order_index ON course_sections (course_id, "order");
+
+-- Contents without a section are listed after the sections
+ALTER TABLE course_videos
+    ADD COLUMN section_id UUID REFERENCES course_sections (id) ON DELETE SET NULL;
+ALTER TABLE course_materials
+    ADD COLUMN section_id UUID REFERENCES course_sections (id) ON DELETE SET NULL;
+
+CREATE INDEX course_videos_section_id_index ON course_videos (section_id);
+CREATE INDEX course_materials_section_id_index ON course_materials (section_id);
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS course_quiz_progresses;
+DROP TABLE IF EXISTS course_quiz_attempt_selections;
+DROP TABLE IF EXISTS course_quiz_attempt_answers;
+DROP TABLE IF EXISTS course_quiz_attempts;
+DROP TABLE IF EXISTS course_quiz_options;
+DROP TABLE IF EXISTS course_quiz_questions;
+DROP TABLE IF EXISTS course_quizzes;
@@ -0,0 +1,81 @@
+CREATE TABLE course_quizzes
+(
+    id            UUID PRIMARY KEY,
+    course_id     UUID                     NOT NULL REFERENCES courses (id) ON DELETE CASCADE,
+    section_id    UUID REFERENCES course_sections (id) ON DELETE SET NULL,
+    title         VARCHAR(50)              NOT NULL,
+    description   VARCHAR(1000)            NOT NULL DEFAULT '',
+    passing_score INT                      NOT NULL,
+    max_attempts  INT                      NOT NULL DEFAULT 0,
+    is_free       BOOLEAN                  NOT NULL DEFAULT FALSE,
+    "order"       INT                      NOT NULL,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX course_quizzes_course_id_index ON course_quizzes (course_id);
+CREATE INDEX course_quizzes_section_id_index ON course_quizzes (section_id);
+
+CREATE TABLE course_quiz_questions
+(
+    id       UUID PRIMARY KEY,
+    quiz_id  UUID        NOT NULL REFERENCES course_quizzes (id) ON DELETE CASCADE,
+    type     VARCHAR(50) NOT NULL,
+    question TEXT        NOT NULL,
+    points   INT         NOT NULL DEFAULT 1,
+    "order"  INT         NOT NULL
+);
+
+CREATE INDEX course_quiz_questions_quiz_id_index ON course_quiz_questions (quiz_id, "order");
+
+-- For short answer questions, the options are the accepted answers
+CREATE TABLE course_quiz_options
+(
+    id          UUID PRIMARY KEY,
+    question_id UUID         NOT NULL REFERENCES course_quiz_questions (id) ON DELETE CASCADE,
+    text        VARCHAR(500) NOT NULL,
+    is_correct  BOOLEAN      NOT NULL DEFAULT FALSE,
+    "order"     INT          NOT NULL
+);
+
+CREATE INDEX course_quiz_options_question_id_index ON course_quiz_options (question_id, "order");
+
+CREATE TABLE course_quiz_attempts
+(
+    id         UUID PRIMARY KEY,
+    quiz_id    UUID                     NOT NULL REFERENCES course_quizzes (id) ON DELETE CASCADE,
+    student_id UUID                     NOT NULL REFERENCES students (user_id) ON DELETE CASCADE,
+    score      INT                      NOT NULL,
+    max_score  INT                      NOT NULL,
+    is_passed  BOOLEAN                  NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX course_quiz_attempts_quiz_id_student_id_index ON course_quiz_attempts (quiz_id, student_id, created_at);
+
+CREATE TABLE course_quiz_attempt_answers
+(
+    attempt_id  UUID    NOT NULL REFERENCES course_quiz_attempts (id) ON DELETE CASCADE,
+    question_id UUID    NOT NULL REFERENCES course_quiz_questions (id) ON DELETE CASCADE,
+    answer_text TEXT    NOT NULL DEFAULT '',
+    is_correct  BOOLEAN NOT NULL,
+    PRIMARY KEY (attempt_id, question_id)
+);
+
+CREATE TABLE course_quiz_attempt_selections
+(
+    attempt_id  UUID NOT NULL,
+    question_id UUID NOT NULL,
+    option_id   UUID NOT NULL REFERENCES course_quiz_options (id) ON DELETE CASCADE,
+    PRIMARY KEY (attempt_id, option_id),
+    FOREIGN KEY (attempt_id, question_id)
+        REFERENCES course_quiz_attempt_answers (attempt_id, question_id) ON DELETE CASCADE
+);
+
+CREATE TABLE course_quiz_progresses
+(
+    student_id UUID                     NOT NULL REFERENCES students (user_id) ON DELETE CASCADE,
+    quiz_id    UUID                     NOT NULL REFERENCES course_quizzes (id) ON DELETE CASCADE,
+    passed_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (student_id, quiz_id)
+);
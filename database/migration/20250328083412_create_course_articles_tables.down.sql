@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS course_article_progresses;
+DROP TABLE IF EXISTS course_article_revisions;
+DROP TABLE IF EXISTS course_articles;
@@ -0,0 +1,37 @@
+CREATE TABLE course_articles
+(
+    id         UUID PRIMARY KEY,
+    course_id  UUID                     NOT NULL REFERENCES courses (id) ON DELETE CASCADE,
+    section_id UUID REFERENCES course_sections (id) ON DELETE SET NULL,
+    title      VARCHAR(50)              NOT NULL,
+    subtitle   VARCHAR(50)              NOT NULL DEFAULT '',
+    body       TEXT                     NOT NULL,
+    body_html  TEXT                     NOT NULL,
+    revision   INT                      NOT NULL DEFAULT 1,
+    is_free    BOOLEAN                  NOT NULL DEFAULT FALSE,
+    "order"    INT                      NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX course_articles_course_id_index ON course_articles (course_id);
+CREATE INDEX course_articles_section_id_index ON course_articles (section_id);
+
+-- Snapshot of the title and body after every edit of either
+CREATE TABLE course_article_revisions
+(
+    article_id UUID                     NOT NULL REFERENCES course_articles (id) ON DELETE CASCADE,
+    revision   INT                      NOT NULL,
+    title      VARCHAR(50)              NOT NULL,
+    body       TEXT                     NOT NULL,
+    editor_id  UUID REFERENCES users (id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (article_id, revision)
+);
+
+CREATE TABLE course_article_progresses
+(
+    student_id UUID NOT NULL REFERENCES students (user_id) ON DELETE CASCADE,
+    article_id UUID NOT NULL REFERENCES course_articles (id) ON DELETE CASCADE,
+    PRIMARY KEY (student_id, article_id)
+);
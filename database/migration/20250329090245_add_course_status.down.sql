@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS courses_status_publish_at_index;
+
+ALTER TABLE courses
+    DROP COLUMN IF EXISTS publish_at,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,12 @@
+-- Courses created before this migration are already visible to students
+ALTER TABLE courses
+    ADD COLUMN status     VARCHAR(20)              NOT NULL DEFAULT 'published',
+    ADD COLUMN publish_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE courses
+SET publish_at = created_at;
+
+ALTER TABLE courses
+    ALTER COLUMN status SET DEFAULT 'draft';
+
+CREATE INDEX courses_status_publish_at_index ON courses (status, publish_at);
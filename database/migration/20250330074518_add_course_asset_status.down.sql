@@ -0,0 +1,9 @@
+ALTER TABLE course_materials
+    DROP COLUMN IF EXISTS material_status;
+
+ALTER TABLE course_videos
+    DROP COLUMN IF EXISTS thumbnail_status,
+    DROP COLUMN IF EXISTS video_status;
+
+ALTER TABLE courses
+    DROP COLUMN IF EXISTS preview_video_status;
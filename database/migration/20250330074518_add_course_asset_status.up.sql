@@ -0,0 +1,20 @@
+-- Assets uploaded before this migration are assumed to be ready
+ALTER TABLE courses
+    ADD COLUMN preview_video_status VARCHAR(20) NOT NULL DEFAULT 'ready';
+
+ALTER TABLE course_videos
+    ADD COLUMN video_status     VARCHAR(20) NOT NULL DEFAULT 'ready',
+    ADD COLUMN thumbnail_status VARCHAR(20) NOT NULL DEFAULT 'ready';
+
+ALTER TABLE course_materials
+    ADD COLUMN material_status VARCHAR(20) NOT NULL DEFAULT 'ready';
+
+ALTER TABLE courses
+    ALTER COLUMN preview_video_status SET DEFAULT 'pending';
+
+ALTER TABLE course_videos
+    ALTER COLUMN video_status SET DEFAULT 'pending',
+    ALTER COLUMN thumbnail_status SET DEFAULT 'pending';
+
+ALTER TABLE course_materials
+    ALTER COLUMN material_status SET DEFAULT 'pending';
@@ -0,0 +1,14 @@
+CREATE TABLE course_video_subtitles
+(
+    video_id   UUID                     NOT NULL REFERENCES course_videos (id) ON DELETE CASCADE,
+    language   VARCHAR(35)              NOT NULL,
+    label      VARCHAR(50)              NOT NULL,
+    transcript TEXT                     NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (video_id, language)
+);
+
+-- Lets the course search match the spoken content with ILIKE
+CREATE INDEX course_video_subtitles_transcript_trgm_index
+    ON course_video_subtitles USING GIN (transcript gin_trgm_ops);
@@ -0,0 +1,21 @@
+-- Notes of the students on a video at a timestamp, and bookmarks on materials
+CREATE TABLE course_notes
+(
+    id          UUID PRIMARY KEY,
+    student_id  UUID                     NOT NULL REFERENCES students (user_id) ON DELETE CASCADE,
+    course_id   UUID                     NOT NULL REFERENCES courses (id) ON DELETE CASCADE,
+    video_id    UUID REFERENCES course_videos (id) ON DELETE CASCADE,
+    material_id UUID REFERENCES course_materials (id) ON DELETE CASCADE,
+    timestamp   INT,
+    body        TEXT                     NOT NULL DEFAULT '',
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT course_notes_content_check CHECK (
+        (video_id IS NOT NULL AND material_id IS NULL AND timestamp >= 0) OR
+        (video_id IS NULL AND material_id IS NOT NULL AND timestamp IS NULL)
+    ),
+    -- a student bookmarks a material once
+    CONSTRAINT course_notes_student_id_material_id_key UNIQUE (student_id, material_id)
+);
+
+CREATE INDEX course_notes_student_id_course_id_index ON course_notes (student_id, course_id);
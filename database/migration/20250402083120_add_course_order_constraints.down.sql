@@ -0,0 +1,11 @@
+ALTER TABLE course_sections
+    DROP CONSTRAINT IF EXISTS course_sections_course_id_order_key;
+
+ALTER TABLE course_quizzes
+    DROP CONSTRAINT IF EXISTS course_quizzes_course_id_order_key;
+ALTER TABLE course_articles
+    DROP CONSTRAINT IF EXISTS course_articles_course_id_order_key;
+ALTER TABLE course_materials
+    DROP CONSTRAINT IF EXISTS course_materials_course_id_order_key;
+ALTER TABLE course_videos
+    DROP CONSTRAINT IF EXISTS course_videos_course_id_order_key;
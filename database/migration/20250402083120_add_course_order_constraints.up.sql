@@ -0,0 +1,52 @@
+-- Number the contents of each course 1, 2, 3, ... across the content tables, keeping their current order
+CREATE TEMPORARY TABLE course_content_orders ON COMMIT DROP AS
+SELECT id,
+       table_name,
+       ROW_NUMBER() OVER (PARTITION BY course_id ORDER BY "order", created_at, id) AS new_order
+FROM (SELECT id, course_id, "order", created_at, 'course_videos' AS table_name FROM course_videos
+      UNION ALL
+      SELECT id, course_id, "order", created_at, 'course_materials' FROM course_materials
+      UNION ALL
+      SELECT id, course_id, "order", created_at, 'course_articles' FROM course_articles
+      UNION ALL
+      SELECT id, course_id, "order", created_at, 'course_quizzes' FROM course_quizzes) AS contents;
+
+UPDATE course_videos c
+SET "order" = o.new_order
+FROM course_content_orders o
+WHERE o.table_name = 'course_videos' AND o.id = c.id;
+
+UPDATE course_materials c
+SET "order" = o.new_order
+FROM course_content_orders o
+WHERE o.table_name = 'course_materials' AND o.id = c.id;
+
+UPDATE course_articles c
+SET "order" = o.new_order
+FROM course_content_orders o
+WHERE o.table_name = 'course_articles' AND o.id = c.id;
+
+UPDATE course_quizzes c
+SET "order" = o.new_order
+FROM course_content_orders o
+WHERE o.table_name = 'course_quizzes' AND o.id = c.id;
+
+UPDATE course_sections s
+SET "order" = o.new_order
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY course_id ORDER BY "order", created_at, id) AS new_order
+      FROM course_sections) AS o
+WHERE o.id = s.id;
+
+-- Contents are only reordered as a whole, and every order is checked when the reorder commits.
+-- Orders are unique across the content tables as well, which the repository keeps by locking the course.
+ALTER TABLE course_videos
+    ADD CONSTRAINT course_videos_course_id_order_key UNIQUE (course_id, "order") DEFERRABLE INITIALLY DEFERRED;
+ALTER TABLE course_materials
+    ADD CONSTRAINT course_materials_course_id_order_key UNIQUE (course_id, "order") DEFERRABLE INITIALLY DEFERRED;
+ALTER TABLE course_articles
+    ADD CONSTRAINT course_articles_course_id_order_key UNIQUE (course_id, "order") DEFERRABLE INITIALLY DEFERRED;
+ALTER TABLE course_quizzes
+    ADD CONSTRAINT course_quizzes_course_id_order_key UNIQUE (course_id, "order") DEFERRABLE INITIALLY DEFERRED;
+
+ALTER TABLE course_sections
+    ADD CONSTRAINT course_sections_course_id_order_key UNIQUE (course_id, "order") DEFERRABLE;
@@ -0,0 +1,3 @@
+-- A non-null refund_reason means the student paid but did not get what they paid for and is owed a refund
+ALTER TABLE payments
+    ADD COLUMN refund_reason VARCHAR(255);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_payments_paid_at;
+
+ALTER TABLE payments
+    DROP COLUMN IF EXISTS paid_at;
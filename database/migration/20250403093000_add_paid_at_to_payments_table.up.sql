@@ -0,0 +1,9 @@
+ALTER TABLE payments
+    ADD COLUMN paid_at TIMESTAMP WITH TIME ZONE;
+
+-- best guess for payments that succeeded before paid_at was recorded
+UPDATE payments
+SET paid_at = updated_at
+WHERE status = 'success';
+
+CREATE INDEX idx_payments_paid_at ON payments (paid_at) WHERE paid_at IS NOT NULL;
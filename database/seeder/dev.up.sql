@@ -797,61 +797,61 @@ $$
             INSERT INTO course_materials (id, course_id, title, subtitle, is_free, "order", created_at, updated_at)
             VALUES (material1_id, course1_id, 'JS Fundamentals Cheatsheet',
                     'Core JavaScript concepts reference',
-                    TRUE, 1,
+                    TRUE, 11,
                     NOW() - INTERVAL '60 days',
                     NOW() - INTERVAL '60 days'),
 
                    (material2_id, course1_id, 'ES6+ Features Reference',
                     'Guide to modern JavaScript features',
-                    TRUE, 2,
+                    TRUE, 12,
                     NOW() - INTERVAL '59 days',
                     NOW() - INTERVAL '59 days'),
 
                    (material3_id, course1_id, 'Async JavaScript Patterns',
                     'Explanation of async patterns and practices',
-                    FALSE, 3,
+                    FALSE, 13,
                     NOW() - INTERVAL '58 days',
                     NOW() - INTERVAL '58 days'),
 
                    (material4_id, course1_id, 'Functional Programming in JS',
                     'Guide to functional programming concepts',
-                    FALSE, 4,
+                    FALSE, 14,
                     NOW() - INTERVAL '57 days',
                     NOW() - INTERVAL '57 days'),
 
                    (material5_id, course1_id, 'JS Performance Optimization',
                     'Techniques for writing efficient code',
-                    FALSE, 5,
+                    FALSE, 15,
                     NOW() - INTERVAL '56 days',
                     NOW() - INTERVAL '56 days'),
 
                    (material11_id, course1_id, 'Modern JS Coding Standards',
                     'Best practices for clean code',
-                    FALSE, 6,
+                    FALSE, 16,
                     NOW() - INTERVAL '55 days',
                     NOW() - INTERVAL '55 days'),
 
                    (material12_id, course1_id, 'Debugging JS Applications',
                     'Advanced debugging techniques and tools',
-                    FALSE, 7,
+                    FALSE, 17,
                     NOW() - INTERVAL '54 days',
                     NOW() - INTERVAL '54 days'),
 
                    (material13_id, course1_id, 'JavaScript Design Patterns',
                     'Common design patterns in JavaScript',
-                    FALSE, 8,
+                    FALSE, 18,
                     NOW() - INTERVAL '53 days',
                     NOW() - INTERVAL '53 days'),
 
                    (material14_id, course1_id, 'JavaScript Testing Strategies',
                     'Guide to unit, integration, and E2E testing',
-                    FALSE, 9,
+                    FALSE, 19,
                     NOW() - INTERVAL '52 days',
                     NOW() - INTERVAL '52 days'),
 
                    (material15_id, course1_id, 'JavaScript Project Structure',
                     'Best practices for organizing JS applications',
-                    FALSE, 10,
+                    FALSE, 20,
                     NOW() - INTERVAL '51 days',
                     NOW() - INTERVAL '51 days');
 
@@ -900,31 +900,31 @@ $$
             INSERT INTO course_materials (id, course_id, title, subtitle, is_free, "order", created_at, updated_at)
             VALUES (material16_id, course2_id, 'React Component Lifecycle',
                     'Understanding lifecycle methods and hooks',
-                    TRUE, 1,
+                    TRUE, 6,
                     NOW() - INTERVAL '45 days',
                     NOW() - INTERVAL '45 days'),
 
                    (material17_id, course2_id, 'State Management with Redux',
                     'Guide to Redux architecture and patterns',
-                    FALSE, 2,
+                    FALSE, 7,
                     NOW() - INTERVAL '44 days',
                     NOW() - INTERVAL '44 days'),
 
                    (material18_id, course2_id, 'RESTful API Design with Node.js',
                     'Best practices for designing robust APIs',
-                    FALSE, 3,
+                    FALSE, 8,
                     NOW() - INTERVAL '43 days',
                     NOW() - INTERVAL '43 days'),
 
                    (material19_id, course2_id, 'Authentication and Authorization',
                     'Implementing secure auth flows in applications',
-                    FALSE, 4,
+                    FALSE, 9,
                     NOW() - INTERVAL '42 days',
                     NOW() - INTERVAL '42 days'),
 
                    (material20_id, course2_id, 'Deployment for Web Apps',
                     'Guide to deploying full-stack applications',
-                    FALSE, 5,
+                    FALSE, 10,
                     NOW() - INTERVAL '41 days',
                     NOW() - INTERVAL '41 days');
 
@@ -0,0 +1,32 @@
+package contract
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/dto"
+	"github.com/nathakusuma/elevateu-backend/domain/entity"
+)
+
+type ICourseArticleRepository interface {
+	CreateArticle(ctx context.Context, article *entity.CourseArticle, editorID uuid.UUID) error
+	GetArticleByID(ctx context.Context, id uuid.UUID) (*entity.CourseArticle, error)
+	GetArticlesByCourseID(ctx context.Context, courseID uuid.UUID) ([]*entity.CourseArticle, error)
+	UpdateArticle(ctx context.Context, id uuid.UUID, updates dto.CourseArticleUpdate, editorID uuid.UUID) error
+	DeleteArticle(ctx context.Context, id uuid.UUID) error
+
+	GetRevisions(ctx context.Context, articleID uuid.UUID) ([]*entity.CourseArticleRevision, error)
+	GetRevision(ctx context.Context, articleID uuid.UUID, revision int) (*entity.CourseArticleRevision, error)
+}
+
+type ICourseArticleService interface {
+	CreateArticle(ctx context.Context, editorID, courseID uuid.UUID,
+		req dto.CreateCourseArticleRequest) (*dto.CourseArticleResponse, error)
+	GetArticle(ctx context.Context, id uuid.UUID) (*dto.CourseArticleResponse, error)
+	UpdateArticle(ctx context.Context, editorID, id uuid.UUID, req dto.UpdateCourseArticleRequest) error
+	DeleteArticle(ctx context.Context, id uuid.UUID) error
+
+	GetRevisions(ctx context.Context, id uuid.UUID) ([]*dto.CourseArticleRevisionResponse, error)
+	GetRevision(ctx context.Context, id uuid.UUID, revision int) (*dto.CourseArticleRevisionResponse, error)
+}
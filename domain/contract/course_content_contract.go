@@ -7,6 +7,7 @@ import (
 
 	"github.com/nathakusuma/elevateu-backend/domain/dto"
 	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
 )
 
 type ICourseContentRepository interface {
@@ -15,12 +16,23 @@ type ICourseContentRepository interface {
 	DeleteVideo(ctx context.Context, id uuid.UUID) error
 	GetVideoByID(ctx context.Context, id uuid.UUID) (*entity.CourseVideo, error)
 
+	UpsertVideoSubtitle(ctx context.Context, subtitle *entity.CourseVideoSubtitle) error
+	DeleteVideoSubtitle(ctx context.Context, videoID uuid.UUID, language string) error
+	GetVideoSubtitles(ctx context.Context, videoID uuid.UUID) ([]*entity.CourseVideoSubtitle, error)
+
 	CreateMaterial(ctx context.Context, material *entity.CourseMaterial) error
 	UpdateMaterial(ctx context.Context, id uuid.UUID, updates dto.CourseMaterialUpdate) error
 	DeleteMaterial(ctx context.Context, id uuid.UUID) error
 	GetMaterialByID(ctx context.Context, id uuid.UUID) (*entity.CourseMaterial, error)
 
-	GetCourseContents(ctx context.Context, courseID uuid.UUID) ([]*entity.CourseVideo, []*entity.CourseMaterial, error)
+	CreateSection(ctx context.Context, section *entity.CourseSection) error
+	UpdateSection(ctx context.Context, id uuid.UUID, updates dto.CourseSectionUpdate) error
+	DeleteSection(ctx context.Context, id uuid.UUID) error
+	GetSectionByID(ctx context.Context, id uuid.UUID) (*entity.CourseSection, error)
+
+	GetCourseContents(ctx context.Context, courseID uuid.UUID) ([]*entity.CourseSection, []*entity.CourseVideo,
+		[]*entity.CourseMaterial, error)
+	ReorderContents(ctx context.Context, courseID uuid.UUID, contentIDs []uuid.UUID) error
 }
 
 type ICourseContentService interface {
@@ -29,12 +41,24 @@ type ICourseContentService interface {
 	UpdateVideo(ctx context.Context, id uuid.UUID, req dto.UpdateCourseVideoRequest) error
 	DeleteVideo(ctx context.Context, id uuid.UUID) error
 	GetVideoUploadURLs(ctx context.Context, id uuid.UUID) (string, string, error) // videoURL, thumbnailURL, error
+	// videoStatus, thumbnailStatus, error
+	FinalizeVideoUpload(ctx context.Context, id uuid.UUID) (enum.AssetStatus, enum.AssetStatus, error)
+	UploadVideoSubtitle(ctx context.Context, videoID uuid.UUID,
+		req dto.UploadCourseVideoSubtitleRequest) (*dto.CourseVideoSubtitleResponse, error)
+	DeleteVideoSubtitle(ctx context.Context, videoID uuid.UUID, language string) error
 
 	CreateMaterial(ctx context.Context, courseID uuid.UUID,
 		req dto.CreateCourseMaterialRequest) (dto.CreateCourseMaterialResponse, error)
 	UpdateCourseMaterial(ctx context.Context, id uuid.UUID, req dto.UpdateCourseMaterialRequest) error
 	DeleteCourseMaterial(ctx context.Context, id uuid.UUID) error
 	GetMaterialUploadURL(ctx context.Context, id uuid.UUID) (string, error)
+	FinalizeMaterialUpload(ctx context.Context, id uuid.UUID) (enum.AssetStatus, error)
+
+	CreateSection(ctx context.Context, courseID uuid.UUID,
+		req dto.CreateCourseSectionRequest) (*dto.CourseSectionResponse, error)
+	UpdateSection(ctx context.Context, id uuid.UUID, req dto.UpdateCourseSectionRequest) error
+	DeleteSection(ctx context.Context, id uuid.UUID) error
 
-	GetCourseContents(ctx context.Context, courseID uuid.UUID) ([]*dto.CourseContentResponse, error)
+	GetCourseContents(ctx context.Context, courseID uuid.UUID) (*dto.CourseContentsResponse, error)
+	ReorderContents(ctx context.Context, courseID uuid.UUID, req dto.ReorderCourseContentsRequest) error
 }
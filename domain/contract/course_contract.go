@@ -2,11 +2,13 @@ package contract
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/nathakusuma/elevateu-backend/domain/dto"
 	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
 	"github.com/nathakusuma/elevateu-backend/internal/infra/database"
 )
 
@@ -16,6 +18,8 @@ type ICourseRepository interface {
 	GetCourses(ctx context.Context, query dto.GetCoursesQuery,
 		pageReq dto.PaginationRequest) ([]*entity.Course, dto.PaginationResponse, error)
 	UpdateCourse(ctx context.Context, txWrapper database.ITransaction, updates *dto.CourseUpdate) error
+	UpdateCourseStatus(ctx context.Context, id uuid.UUID, status enum.CourseStatus, publishAt *time.Time) error
+	UpdatePreviewVideoStatus(ctx context.Context, id uuid.UUID, status enum.AssetStatus) error
 	DeleteCourse(ctx context.Context, txWrapper database.ITransaction, id uuid.UUID) error
 
 	CreateEnrollment(ctx context.Context, courseID, studentID uuid.UUID) error
@@ -30,9 +34,11 @@ type ICourseService interface {
 	GetCourses(ctx context.Context, query dto.GetCoursesQuery,
 		paginationReq dto.PaginationRequest) ([]*dto.CourseResponse, dto.PaginationResponse, error)
 	UpdateCourse(ctx context.Context, id uuid.UUID, req *dto.UpdateCourseRequest) error
+	UpdateCourseStatus(ctx context.Context, id uuid.UUID, req dto.UpdateCourseStatusRequest) error
 	DeleteCourse(ctx context.Context, id uuid.UUID) error
 
 	GetPreviewVideoUploadURL(ctx context.Context, id uuid.UUID) (string, error)
+	FinalizePreviewVideoUpload(ctx context.Context, id uuid.UUID) (enum.AssetStatus, error)
 
 	CreateEnrollment(ctx context.Context, courseID, studentID uuid.UUID) error
 	GetEnrolledCourses(ctx context.Context, studentID uuid.UUID,
@@ -0,0 +1,31 @@
+package contract
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/dto"
+	"github.com/nathakusuma/elevateu-backend/domain/entity"
+)
+
+type ICourseNoteRepository interface {
+	CreateNote(ctx context.Context, note *entity.CourseNote) error
+	UpsertMaterialBookmark(ctx context.Context, note *entity.CourseNote) error
+	GetNoteByID(ctx context.Context, id uuid.UUID) (*entity.CourseNote, error)
+	GetNotesByCourseID(ctx context.Context, courseID, studentID uuid.UUID) ([]*entity.CourseNote, error)
+	UpdateNote(ctx context.Context, id uuid.UUID, updates dto.CourseNoteUpdate) error
+	DeleteNote(ctx context.Context, id uuid.UUID) error
+}
+
+type ICourseNoteService interface {
+	CreateVideoNote(ctx context.Context, studentID, videoID uuid.UUID,
+		req dto.CreateCourseVideoNoteRequest) (*dto.CourseNoteResponse, error)
+	BookmarkMaterial(ctx context.Context, studentID, materialID uuid.UUID,
+		req dto.BookmarkCourseMaterialRequest) (*dto.CourseNoteResponse, error)
+	UpdateNote(ctx context.Context, studentID, id uuid.UUID, req dto.UpdateCourseNoteRequest) error
+	DeleteNote(ctx context.Context, studentID, id uuid.UUID) error
+
+	GetCourseNotes(ctx context.Context, studentID, courseID uuid.UUID) ([]*dto.CourseNoteResponse, error)
+	ExportCourseNotes(ctx context.Context, studentID, courseID uuid.UUID) ([]byte, error)
+}
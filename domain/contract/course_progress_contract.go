@@ -15,10 +15,15 @@ type ICourseProgressRepository interface {
 		progress entity.CourseVideoProgress) (bool, error)
 	UpdateMaterialProgress(ctx context.Context, txWrapper database.ITransaction,
 		progress entity.CourseMaterialProgress) (bool, error)
+	UpdateArticleProgress(ctx context.Context, txWrapper database.ITransaction,
+		progress entity.CourseArticleProgress) (bool, error)
+	UpdateQuizProgress(ctx context.Context, txWrapper database.ITransaction,
+		progress entity.CourseQuizProgress) (bool, error)
 	IncrementCourseProgress(ctx context.Context, txWrapper database.ITransaction, courseID,
 		studentID uuid.UUID) (bool, error)
 
 	GetContentCourseID(ctx context.Context, contentID uuid.UUID, contentType string) (uuid.UUID, error)
+	GetSectionProgresses(ctx context.Context, courseID, studentID uuid.UUID) ([]*entity.CourseSectionProgress, error)
 
 	BatchDecrementCourseProgress(ctx context.Context, txWrapper database.ITransaction, courseID uuid.UUID,
 		contentID uuid.UUID, contentType string) error
@@ -28,4 +33,5 @@ type ICourseProgressService interface {
 	UpdateVideoProgress(ctx context.Context, studentID, videoID uuid.UUID,
 		req dto.UpdateCourseVideoProgressRequest) error
 	UpdateMaterialProgress(ctx context.Context, studentID uuid.UUID, materialID uuid.UUID) error
+	UpdateArticleProgress(ctx context.Context, studentID uuid.UUID, articleID uuid.UUID) error
 }
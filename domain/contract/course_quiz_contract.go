@@ -0,0 +1,36 @@
+package contract
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/dto"
+	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/internal/infra/database"
+)
+
+type ICourseQuizRepository interface {
+	CreateQuiz(ctx context.Context, quiz *entity.CourseQuiz) error
+	GetQuizByID(ctx context.Context, id uuid.UUID) (*entity.CourseQuiz, error)
+	GetQuizzesByCourseID(ctx context.Context, courseID uuid.UUID) ([]*entity.CourseQuiz, error)
+	UpdateQuiz(ctx context.Context, id uuid.UUID, updates dto.CourseQuizUpdate) error
+	ReplaceQuestions(ctx context.Context, quizID uuid.UUID, questions []*entity.CourseQuizQuestion) error
+	DeleteQuiz(ctx context.Context, id uuid.UUID) error
+
+	CountAttempts(ctx context.Context, txWrapper database.ITransaction, quizID, studentID uuid.UUID) (int, error)
+	CreateAttempt(ctx context.Context, txWrapper database.ITransaction, attempt *entity.CourseQuizAttempt) error
+	GetAttempts(ctx context.Context, quizID, studentID uuid.UUID) ([]*entity.CourseQuizAttempt, error)
+}
+
+type ICourseQuizService interface {
+	CreateQuiz(ctx context.Context, courseID uuid.UUID, req dto.CreateCourseQuizRequest) (*dto.CourseQuizResponse, error)
+	GetQuiz(ctx context.Context, id uuid.UUID) (*dto.CourseQuizResponse, error)
+	UpdateQuiz(ctx context.Context, id uuid.UUID, req dto.UpdateCourseQuizRequest) error
+	ReplaceQuestions(ctx context.Context, id uuid.UUID, req dto.ReplaceCourseQuizQuestionsRequest) error
+	DeleteQuiz(ctx context.Context, id uuid.UUID) error
+
+	SubmitAttempt(ctx context.Context, studentID, quizID uuid.UUID,
+		req dto.SubmitCourseQuizRequest) (*dto.CourseQuizAttemptResponse, error)
+	GetAttempts(ctx context.Context, studentID, quizID uuid.UUID) (*dto.CourseQuizAttemptsResponse, error)
+}
@@ -2,35 +2,72 @@ package contract
 
 import (
 	"context"
+	"time"
 
-	"github.com/gofiber/contrib/websocket"
 	"github.com/google/uuid"
 
 	"github.com/nathakusuma/elevateu-backend/domain/dto"
 	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
+	"github.com/nathakusuma/elevateu-backend/internal/infra/database"
+	"github.com/nathakusuma/elevateu-backend/pkg/wsconn"
 )
 
 type IMentoringRepository interface {
-	CreateChat(ctx context.Context, chat *entity.MentoringChat) error
-	CreateTrialChat(ctx context.Context, chat *entity.MentoringChat) error
+	CreateChat(ctx context.Context, txWrapper database.ITransaction, chat *entity.MentoringChat) error
+	CreateTrialChat(ctx context.Context, chat *entity.MentoringChat, maxTrials int) error
+	CountTrialsByStudent(ctx context.Context, studentID uuid.UUID) (int, error)
 	GetChatByID(ctx context.Context, chatID uuid.UUID) (*entity.MentoringChat, error)
 	GetChatsByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.MentoringChat, error)
 	GetChatByMentorAndStudent(ctx context.Context, mentorID,
 		studentID uuid.UUID) (*entity.MentoringChat, error)
 	SendMessage(ctx context.Context, message *entity.MentoringMessage) error
+	CreateAttachment(ctx context.Context, attachment *entity.MentoringAttachment) error
+	GetAttachmentByID(ctx context.Context, attachmentID uuid.UUID) (*entity.MentoringAttachment, error)
 	GetMessages(ctx context.Context, chatID uuid.UUID,
 		pageReq dto.PaginationRequest) ([]*entity.MentoringMessage, dto.PaginationResponse, error)
+	GetMessageByID(ctx context.Context, messageID uuid.UUID) (*entity.MentoringMessage, error)
+	MarkChatRead(ctx context.Context, chatID, userID, messageID uuid.UUID) (bool, error)
+	UpdateMessage(ctx context.Context, message *entity.MentoringMessage) error
+	DeleteMessage(ctx context.Context, message *entity.MentoringMessage) error
+	CountMessagesBySender(ctx context.Context, chatID, senderID uuid.UUID) (int, error)
+	ClaimChatsForExpiryWarning(ctx context.Context, expiresBefore time.Time) ([]*entity.MentoringChat, error)
+	ClaimExpiredChats(ctx context.Context) ([]*entity.MentoringChat, error)
+
+	CreateTranscript(ctx context.Context, transcript *entity.MentoringTranscript) error
+	GetTranscriptByID(ctx context.Context, transcriptID uuid.UUID) (*entity.MentoringTranscript, error)
+	UpdateTranscriptStatus(ctx context.Context, transcriptID uuid.UUID, status enum.TranscriptStatus) error
 }
 
 type IMentoringService interface {
-	CreateChat(ctx context.Context, mentorID, studentID uuid.UUID, isTrial bool) (*dto.ChatResponse, error)
+	CreateChat(ctx context.Context, txWrapper database.ITransaction, mentorID, studentID uuid.UUID,
+		isTrial bool) (*dto.ChatResponse, error)
+	CreateSessionChat(ctx context.Context, txWrapper database.ITransaction, mentorID, studentID uuid.UUID,
+		expiresAt time.Time) (*dto.ChatResponse, error)
+	GetTrialAllowance(ctx context.Context, studentID uuid.UUID) (*dto.TrialAllowanceResponse, error)
+	ResolveExtensionToken(ctx context.Context, token string) (*dto.ChatExtension, error)
+	StartExpiryWorker()
 	GetChatsByUserID(ctx context.Context, userID uuid.UUID) ([]*dto.ChatResponse, error)
 	SendMessage(ctx context.Context, userID, chatID uuid.UUID,
-		message string) error
+		req dto.SendMessageRequest) (*dto.MessageResponse, error)
+	CreateAttachment(ctx context.Context, userID, chatID uuid.UUID,
+		req dto.CreateAttachmentRequest) (*dto.CreateAttachmentResponse, error)
 	GetMessages(ctx context.Context, userID uuid.UUID, chatID uuid.UUID,
 		pageReq dto.PaginationRequest) ([]*dto.MessageResponse, dto.PaginationResponse, error)
+	EditMessage(ctx context.Context, userID, chatID, messageID uuid.UUID,
+		req dto.EditMessageRequest) (*dto.MessageResponse, error)
+	DeleteMessage(ctx context.Context, userID, chatID, messageID uuid.UUID) error
+	MarkChatRead(ctx context.Context, userID, chatID, messageID uuid.UUID) error
+	SetTyping(ctx context.Context, userID, chatID uuid.UUID, isTyping bool) error
+
+	RegisterClient(userID uuid.UUID, chatID uuid.UUID, conn *wsconn.Conn) error
+	UnregisterClient(userID uuid.UUID, chatID uuid.UUID, conn *wsconn.Conn)
+	KeepAlive(userID uuid.UUID, chatID uuid.UUID)
+	BroadcastEvent(event *dto.ChatEvent, chatID, originConnID, excludedUserID uuid.UUID)
+}
 
-	RegisterClient(userID uuid.UUID, chatID uuid.UUID, conn *websocket.Conn)
-	UnregisterClient(userID uuid.UUID, chatID uuid.UUID)
-	BroadcastMessage(message *dto.MessageResponse, chatID uuid.UUID)
+type IMentoringTranscriptService interface {
+	CreateTranscript(ctx context.Context, userID, chatID uuid.UUID,
+		req dto.CreateTranscriptRequest) (*dto.TranscriptResponse, error)
+	GetTranscript(ctx context.Context, userID, transcriptID uuid.UUID) (*dto.TranscriptResponse, error)
 }
@@ -0,0 +1,56 @@
+package contract
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/dto"
+	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
+	"github.com/nathakusuma/elevateu-backend/internal/infra/database"
+)
+
+type IMentoringScheduleRepository interface {
+	ReplaceAvailabilities(ctx context.Context, mentorID uuid.UUID, availabilities []*entity.MentorAvailability) error
+	GetAvailabilities(ctx context.Context, mentorID uuid.UUID) ([]*entity.MentorAvailability, error)
+	CreateAvailabilityException(ctx context.Context, exception *entity.MentorAvailabilityException) error
+	GetAvailabilityExceptions(ctx context.Context, mentorID uuid.UUID,
+		from, to time.Time) ([]*entity.MentorAvailabilityException, error)
+	DeleteAvailabilityException(ctx context.Context, mentorID, exceptionID uuid.UUID) error
+
+	CreateSession(ctx context.Context, session *entity.MentoringSession) error
+	GetSessionByID(ctx context.Context, sessionID uuid.UUID) (*entity.MentoringSession, error)
+	GetPendingSession(ctx context.Context, studentID, mentorID uuid.UUID,
+		startsAt time.Time) (*entity.MentoringSession, error)
+	GetActiveSessionsByMentor(ctx context.Context, mentorID uuid.UUID,
+		from, to time.Time) ([]*entity.MentoringSession, error)
+	GetBookedSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]*entity.MentoringSession, error)
+	ExtendSessionHold(ctx context.Context, sessionID uuid.UUID, holdUntil time.Time) error
+	UpdateSessionStatus(ctx context.Context, sessionID uuid.UUID, from []enum.MentoringSessionStatus,
+		to enum.MentoringSessionStatus) error
+	BookSession(ctx context.Context, txWrapper database.ITransaction,
+		sessionID uuid.UUID) (*entity.MentoringSession, error)
+	ClaimSessionsForReminder(ctx context.Context, startsBefore time.Time) ([]*entity.MentoringSession, error)
+}
+
+type IMentoringScheduleService interface {
+	SetAvailability(ctx context.Context, mentorID uuid.UUID, req dto.SetAvailabilityRequest) error
+	GetMentorAvailability(ctx context.Context, mentorID uuid.UUID) (*dto.MentorAvailabilityResponse, error)
+	CreateAvailabilityException(ctx context.Context, mentorID uuid.UUID,
+		req dto.CreateAvailabilityExceptionRequest) (*dto.AvailabilityExceptionResponse, error)
+	DeleteAvailabilityException(ctx context.Context, mentorID, exceptionID uuid.UUID) error
+	GetAvailableSlots(ctx context.Context, mentorID uuid.UUID,
+		req dto.AvailableSlotsRequest) ([]*dto.AvailableSlotResponse, error)
+
+	HoldSession(ctx context.Context, studentID, mentorID uuid.UUID, startsAt,
+		holdUntil time.Time) (*dto.MentoringSessionResponse, error)
+	ConfirmSession(ctx context.Context, txWrapper database.ITransaction,
+		sessionID uuid.UUID) (*dto.MentoringSessionResponse, error)
+	ReleaseSession(ctx context.Context, sessionID uuid.UUID) error
+	GetSessionsByUserID(ctx context.Context, userID uuid.UUID) ([]*dto.MentoringSessionResponse, error)
+	ExportCalendar(ctx context.Context, userID uuid.UUID) ([]byte, error)
+
+	StartReminderWorker()
+}
@@ -19,6 +19,11 @@ type IPaymentRepository interface {
 	GetPaymentByID(ctx context.Context, tx database.ITransaction,
 		id uuid.UUID) (*entity.Payment, error)
 	UpdatePayment(ctx context.Context, tx database.ITransaction, payment *entity.Payment) error
+	GetReusablePayment(ctx context.Context, userID uuid.UUID, paymentType enum.PaymentType,
+		productID *uuid.UUID, amount int, validUntil time.Time) (*entity.Payment, error)
+	AssignInvoiceNumber(ctx context.Context, txWrapper database.ITransaction, id uuid.UUID) (int64, error)
+	MarkReceiptGenerated(ctx context.Context, id uuid.UUID) error
+	MarkForRefund(ctx context.Context, txWrapper database.ITransaction, id uuid.UUID, reason string) error
 
 	GetPaymentsByStudent(ctx context.Context, studentID uuid.UUID,
 		pageReq dto.PaginationRequest) ([]*entity.Payment, dto.PaginationResponse, error)
@@ -31,6 +36,9 @@ type IPaymentRepository interface {
 		studentID uuid.UUID, subscribedUntil time.Time) error
 	AddMentorBalance(ctx context.Context, txWrapper database.ITransaction,
 		mentorID uuid.UUID, amount int) error
+	IsCoursePurchased(ctx context.Context, courseID, studentID uuid.UUID) (bool, error)
+	AddCoursePurchase(ctx context.Context, txWrapper database.ITransaction,
+		courseID, studentID uuid.UUID) error
 }
 
 type IPaymentService interface {
@@ -41,8 +49,13 @@ type IPaymentService interface {
 		pageReq dto.PaginationRequest) ([]*dto.PaymentResponse, dto.PaginationResponse, error)
 	GetTransactionHistoriesByMentor(ctx context.Context, mentorID uuid.UUID,
 		pageReq dto.PaginationRequest) ([]*entity.MentorTransactionHistory, dto.PaginationResponse, error)
+	GetReceiptURL(ctx context.Context, studentID, paymentID uuid.UUID) (string, error)
+	SubscribePaymentStatus(ctx context.Context, studentID, paymentID uuid.UUID) (<-chan *dto.PaymentStatusEvent,
+		func(), error)
 
 	PaySkillBoost(ctx context.Context, studentID uuid.UUID) (string, error)
 	PaySkillChallenge(ctx context.Context, studentID uuid.UUID) (string, error)
-	PaySkillGuidance(ctx context.Context, studentID, mentorID uuid.UUID) (string, error)
+	PaySkillGuidance(ctx context.Context, studentID, mentorID uuid.UUID, startsAt *time.Time) (string, error)
+	ExtendSkillGuidance(ctx context.Context, token string) (string, error)
+	PayCourse(ctx context.Context, studentID, courseID uuid.UUID) (string, error)
 }
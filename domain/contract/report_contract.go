@@ -0,0 +1,23 @@
+package contract
+
+import (
+	"context"
+
+	"github.com/nathakusuma/elevateu-backend/domain/dto"
+)
+
+type IReportRepository interface {
+	GetRevenueByPeriod(ctx context.Context, filter dto.ReportFilter) ([]*dto.RevenuePeriodReport, error)
+	GetRevenueByType(ctx context.Context, filter dto.ReportFilter) ([]*dto.RevenueByTypeReport, error)
+	GetRevenueByMethod(ctx context.Context, filter dto.ReportFilter) ([]*dto.RevenueByMethodReport, error)
+	GetConversion(ctx context.Context, filter dto.ReportFilter) (*dto.ConversionReport, error)
+	GetMentorPayouts(ctx context.Context, filter dto.ReportFilter) ([]*dto.MentorPayoutReport, error)
+}
+
+type IReportService interface {
+	GetRevenueByPeriod(ctx context.Context, req dto.ReportRequest) ([]*dto.RevenuePeriodReport, error)
+	GetRevenueByType(ctx context.Context, req dto.ReportRequest) ([]*dto.RevenueByTypeReport, error)
+	GetRevenueByMethod(ctx context.Context, req dto.ReportRequest) ([]*dto.RevenueByMethodReport, error)
+	GetConversion(ctx context.Context, req dto.ReportRequest) (*dto.ConversionReport, error)
+	GetMentorPayouts(ctx context.Context, req dto.ReportRequest) ([]*dto.MentorPayoutReport, error)
+}
@@ -7,6 +7,7 @@ const (
 	UserRole              contextKey = "user.role"
 	IsSubscribedBoost     contextKey = "user.is_subscribed_boost"
 	IsSubscribedChallenge contextKey = "user.is_subscribed_challenge"
+	ConnectionID          contextKey = "websocket.connection_id"
 )
 
 func (c contextKey) String() string {
@@ -0,0 +1,86 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/entity"
+)
+
+type CreateCourseArticleRequest struct {
+	SectionID *uuid.UUID `json:"section_id"`
+	Title     string     `json:"title" validate:"required,min=3,max=50"`
+	Subtitle  string     `json:"subtitle" validate:"omitempty,max=50"`
+	Body      string     `json:"body" validate:"required,max=100000"`
+	IsFree    bool       `json:"is_free"`
+}
+
+// UpdateCourseArticleRequest leaves the section as is when SectionID is nil.
+// RemoveSection takes the article out of its section.
+type UpdateCourseArticleRequest struct {
+	SectionID     *uuid.UUID `json:"section_id"`
+	RemoveSection bool       `json:"remove_section" validate:"excluded_with=SectionID"`
+	Title         *string    `json:"title" validate:"omitempty,min=3,max=50"`
+	Subtitle      *string    `json:"subtitle" validate:"omitempty,max=50"`
+	Body          *string    `json:"body" validate:"omitempty,min=1,max=100000"`
+	IsFree        *bool      `json:"is_free"`
+}
+
+type CourseArticleUpdate struct {
+	SectionID *uuid.NullUUID `db:"section_id"`
+	Title     *string        `db:"title"`
+	Subtitle  *string        `db:"subtitle"`
+	Body      *string        `db:"body"`
+	BodyHTML  *string        `db:"body_html"`
+	Revision  *int           `db:"revision"`
+	IsFree    *bool          `db:"is_free"`
+}
+
+// CourseArticleResponse holds the rendered article. The Markdown source is only included for admins.
+type CourseArticleResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	CourseID  uuid.UUID  `json:"course_id"`
+	SectionID *uuid.UUID `json:"section_id,omitempty"`
+	Title     string     `json:"title"`
+	Subtitle  string     `json:"subtitle,omitempty"`
+	Body      string     `json:"body,omitempty"`
+	BodyHTML  string     `json:"body_html"`
+	Revision  int        `json:"revision"`
+	IsFree    bool       `json:"is_free"`
+	Order     int        `json:"order"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func (c *CourseArticleResponse) PopulateFromEntity(article *entity.CourseArticle, withSource bool) {
+	c.ID = article.ID
+	c.CourseID = article.CourseID
+	c.SectionID = article.SectionID
+	c.Title = article.Title
+	c.Subtitle = article.Subtitle
+	c.BodyHTML = article.BodyHTML
+	c.Revision = article.Revision
+	c.IsFree = article.IsFree
+	c.Order = article.Order
+	c.UpdatedAt = article.UpdatedAt
+
+	if withSource {
+		c.Body = article.Body
+	}
+}
+
+type CourseArticleRevisionResponse struct {
+	Revision  int        `json:"revision"`
+	Title     string     `json:"title"`
+	Body      string     `json:"body,omitempty"`
+	EditorID  *uuid.UUID `json:"editor_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func (c *CourseArticleRevisionResponse) PopulateFromEntity(revision *entity.CourseArticleRevision) {
+	c.Revision = revision.Revision
+	c.Title = revision.Title
+	c.Body = revision.Body
+	c.EditorID = revision.EditorID
+	c.CreatedAt = revision.CreatedAt
+}
@@ -2,58 +2,136 @@ package dto
 
 import (
 	"fmt"
+	"mime/multipart"
 
 	"github.com/google/uuid"
 
 	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
 )
 
 type CourseContentResponse struct {
-	Type         string    `json:"type"`
-	ID           uuid.UUID `json:"id"`
-	URL          string    `json:"url,omitempty"`
-	ThumbnailURL string    `json:"thumbnail_url,omitempty"`
-	Title        string    `json:"title,omitempty"`
-	Description  string    `json:"description,omitempty"`
-	Subtitle     string    `json:"subtitle,omitempty"`
-	Duration     int       `json:"duration,omitempty"`
-	IsFree       *bool     `json:"is_free,omitempty"`
+	Type         string     `json:"type"`
+	ID           uuid.UUID  `json:"id"`
+	SectionID    *uuid.UUID `json:"section_id,omitempty"`
+	URL          string     `json:"url,omitempty"`
+	ThumbnailURL string     `json:"thumbnail_url,omitempty"`
+	Title        string     `json:"title,omitempty"`
+	Description  string     `json:"description,omitempty"`
+	Subtitle     string     `json:"subtitle,omitempty"`
+	Duration     int        `json:"duration,omitempty"`
+	PassingScore int        `json:"passing_score,omitempty"`
+	MaxAttempts  int        `json:"max_attempts,omitempty"`
+	IsFree       *bool      `json:"is_free,omitempty"`
+	IsPlayable   *bool      `json:"is_playable,omitempty"`
+
+	Subtitles []CourseVideoSubtitleResponse `json:"subtitles,omitempty"`
+}
+
+// CourseVideoSubtitleResponse is a subtitle track of a video. URL is only set when the video is accessible.
+type CourseVideoSubtitleResponse struct {
+	Language string `json:"language"`
+	Label    string `json:"label"`
+	URL      string `json:"url,omitempty"`
+}
+
+func (c *CourseVideoSubtitleResponse) PopulateFromEntity(subtitle *entity.CourseVideoSubtitle) {
+	c.Language = subtitle.Language
+	c.Label = subtitle.Label
+}
+
+// CourseVideoAssetPaths returns the paths PopulateFromCourseVideo signs, so list responses can sign them
+// in one batch. Only verified uploads are included, and the video only when it is accessible.
+func CourseVideoAssetPaths(video *entity.CourseVideo, isRestricted bool) []string {
+	var paths []string
+	if video.VideoStatus == enum.AssetStatusReady && (video.IsFree || !isRestricted) {
+		paths = append(paths, fmt.Sprintf("course_videos/video/%s", video.ID.String()))
+	}
+
+	if video.ThumbnailStatus == enum.AssetStatusReady {
+		paths = append(paths, fmt.Sprintf("course_videos/thumbnail/%s", video.ID.String()))
+	}
+
+	if video.IsFree || !isRestricted {
+		for _, subtitle := range video.Subtitles {
+			paths = append(paths, fmt.Sprintf("course_videos/subtitle/%s/%s", video.ID.String(), subtitle.Language))
+		}
+	}
+
+	return paths
 }
 
 func (c *CourseContentResponse) PopulateFromCourseVideo(video *entity.CourseVideo, isRestricted bool,
 	urlSigner func(string) (string, error)) error {
 	c.Type = "video"
 	c.ID = video.ID
+	c.SectionID = video.SectionID
 	c.Title = video.Title
 	c.Description = video.Description
 	c.Duration = video.Duration
 	c.IsFree = &video.IsFree
 
+	// only verified uploads are signed
+	isPlayable := video.VideoStatus == enum.AssetStatusReady
+	c.IsPlayable = &isPlayable
+
 	var err error
-	if video.IsFree || !isRestricted {
+	if isPlayable && (video.IsFree || !isRestricted) {
 		c.URL, err = urlSigner(fmt.Sprintf("course_videos/video/%s", video.ID.String()))
 		if err != nil {
 			return err
 		}
 	}
 
-	c.ThumbnailURL, err = urlSigner(fmt.Sprintf("course_videos/thumbnail/%s", video.ID.String()))
-	if err != nil {
-		return err
+	if video.ThumbnailStatus == enum.AssetStatusReady {
+		c.ThumbnailURL, err = urlSigner(fmt.Sprintf("course_videos/thumbnail/%s", video.ID.String()))
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, subtitle := range video.Subtitles {
+		var subtitleResp CourseVideoSubtitleResponse
+		subtitleResp.PopulateFromEntity(subtitle)
+
+		if video.IsFree || !isRestricted {
+			subtitleResp.URL, err = urlSigner(fmt.Sprintf("course_videos/subtitle/%s/%s", video.ID.String(),
+				subtitle.Language))
+			if err != nil {
+				return err
+			}
+		}
+
+		c.Subtitles = append(c.Subtitles, subtitleResp)
 	}
 
 	return nil
 }
 
+// CourseMaterialAssetPaths returns the paths PopulateFromCourseMaterial signs, so list responses can sign them
+// in one batch
+func CourseMaterialAssetPaths(material *entity.CourseMaterial, isRestricted bool) []string {
+	if material.MaterialStatus != enum.AssetStatusReady || (!material.IsFree && isRestricted) {
+		return nil
+	}
+
+	return []string{fmt.Sprintf("course_materials/material/%s", material.ID.String())}
+}
+
 func (c *CourseContentResponse) PopulateFromCourseMaterial(material *entity.CourseMaterial, isRestricted bool,
 	urlSigner func(string) (string, error)) error {
 	c.Type = "material"
 	c.ID = material.ID
+	c.SectionID = material.SectionID
 	c.Title = material.Title
 	c.Subtitle = material.Subtitle
 	c.IsFree = &material.IsFree
 
-	if material.IsFree || !isRestricted {
+	// only verified uploads are signed
+	isPlayable := material.MaterialStatus == enum.AssetStatusReady
+	c.IsPlayable = &isPlayable
+
+	if isPlayable && (material.IsFree || !isRestricted) {
 		var err error
 		c.URL, err = urlSigner(fmt.Sprintf("course_materials/material/%s", material.ID.String()))
 		if err != nil {
@@ -64,27 +142,83 @@ func (c *CourseContentResponse) PopulateFromCourseMaterial(material *entity.Cour
 	return nil
 }
 
+func (c *CourseContentResponse) PopulateFromCourseArticle(article *entity.CourseArticle) {
+	c.Type = "article"
+	c.ID = article.ID
+	c.SectionID = article.SectionID
+	c.Title = article.Title
+	c.Subtitle = article.Subtitle
+	c.IsFree = &article.IsFree
+}
+
+func (c *CourseContentResponse) PopulateFromCourseQuiz(quiz *entity.CourseQuiz) {
+	c.Type = "quiz"
+	c.ID = quiz.ID
+	c.SectionID = quiz.SectionID
+	c.Title = quiz.Title
+	c.Description = quiz.Description
+	c.PassingScore = quiz.PassingScore
+	c.MaxAttempts = quiz.MaxAttempts
+	c.IsFree = &quiz.IsFree
+}
+
+// CourseSectionResponse is a section with its contents, ordered by their order.
+// ContentCompleted is only set for enrolled students.
+type CourseSectionResponse struct {
+	ID               uuid.UUID                `json:"id"`
+	Title            string                   `json:"title"`
+	Description      string                   `json:"description,omitempty"`
+	Order            int                      `json:"order"`
+	ContentCount     int                      `json:"content_count"`
+	ContentCompleted *int                     `json:"content_completed,omitempty"`
+	Contents         []*CourseContentResponse `json:"contents"`
+}
+
+func (c *CourseSectionResponse) PopulateFromEntity(section *entity.CourseSection) {
+	c.ID = section.ID
+	c.Title = section.Title
+	c.Description = section.Description
+	c.Order = section.Order
+	c.Contents = []*CourseContentResponse{}
+}
+
+// CourseContentsResponse is the content tree of a course. Contents holds every content of the course,
+// including the ones listed under Sections.
+type CourseContentsResponse struct {
+	Sections []*CourseSectionResponse `json:"sections"`
+	Contents []*CourseContentResponse `json:"course_contents"`
+}
+
 type CourseVideoUpdate struct {
-	Title       *string `db:"title"`
-	Description *string `db:"description"`
-	Duration    *int    `db:"duration"`
-	IsFree      *bool   `db:"is_free"`
-	Order       *int    `db:"order"`
+	SectionID       *uuid.NullUUID    `db:"section_id"`
+	Title           *string           `db:"title"`
+	Description     *string           `db:"description"`
+	Duration        *int              `db:"duration"`
+	VideoStatus     *enum.AssetStatus `db:"video_status"`
+	ThumbnailStatus *enum.AssetStatus `db:"thumbnail_status"`
+	IsFree          *bool             `db:"is_free"`
 }
 
 type CourseMaterialUpdate struct {
-	Title    *string `db:"title"`
-	Subtitle *string `db:"subtitle"`
-	IsFree   *bool   `db:"is_free"`
-	Order    *int    `db:"order"`
+	SectionID      *uuid.NullUUID    `db:"section_id"`
+	Title          *string           `db:"title"`
+	Subtitle       *string           `db:"subtitle"`
+	MaterialStatus *enum.AssetStatus `db:"material_status"`
+	IsFree         *bool             `db:"is_free"`
+}
+
+type CourseSectionUpdate struct {
+	Title       *string `db:"title"`
+	Description *string `db:"description"`
+	Order       *int    `db:"order"`
 }
 
 type CreateCourseVideoRequest struct {
-	Title       string `json:"title" validate:"required,min=3,max=50"`
-	Description string `json:"description" validate:"required,min=3,max=1000"`
-	Duration    int    `json:"duration" validate:"required,min=1"`
-	IsFree      bool   `json:"is_free"`
-	Order       int    `json:"order" validate:"required"`
+	SectionID   *uuid.UUID `json:"section_id"`
+	Title       string     `json:"title" validate:"required,min=3,max=50"`
+	Description string     `json:"description" validate:"required,min=3,max=1000"`
+	Duration    int        `json:"duration" validate:"required,min=1"`
+	IsFree      bool       `json:"is_free"`
 }
 
 type CreateCourseVideoResponse struct {
@@ -93,29 +227,58 @@ type CreateCourseVideoResponse struct {
 	ThumbnailUploadURL string                 `json:"thumbnail_upload_url"`
 }
 
+// UploadCourseVideoSubtitleRequest uploads a WebVTT subtitle track, replacing the track of the same language
+type UploadCourseVideoSubtitleRequest struct {
+	Language string                `json:"-" param:"language" validate:"required,bcp47_language_tag"`
+	Label    string                `form:"label" validate:"required,min=2,max=50"`
+	Subtitle *multipart.FileHeader `form:"-" validate:"required"`
+}
+
 type CreateCourseMaterialResponse struct {
 	CourseContent     *CourseContentResponse `json:"course_content"`
 	MaterialUploadURL string                 `json:"material_upload_url"`
 }
 
+// UpdateCourseVideoRequest leaves the section as is when SectionID is nil.
+// RemoveSection takes the video out of its section.
 type UpdateCourseVideoRequest struct {
-	Title       *string `json:"title" validate:"omitempty,min=3,max=50"`
-	Description *string `json:"description" validate:"omitempty,min=3,max=1000"`
-	Duration    *int    `json:"duration" validate:"omitempty,min=1"`
-	IsFree      *bool   `json:"is_free"`
-	Order       *int    `json:"order"`
+	SectionID     *uuid.UUID `json:"section_id"`
+	RemoveSection bool       `json:"remove_section" validate:"excluded_with=SectionID"`
+	Title         *string    `json:"title" validate:"omitempty,min=3,max=50"`
+	Description   *string    `json:"description" validate:"omitempty,min=3,max=1000"`
+	Duration      *int       `json:"duration" validate:"omitempty,min=1"`
+	IsFree        *bool      `json:"is_free"`
 }
 
 type CreateCourseMaterialRequest struct {
-	Title    string `json:"title" validate:"required,min=3,max=50"`
-	Subtitle string `json:"subtitle" validate:"required,min=3,max=50"`
-	IsFree   bool   `json:"is_free"`
-	Order    int    `json:"order" validate:"required"`
+	SectionID *uuid.UUID `json:"section_id"`
+	Title     string     `json:"title" validate:"required,min=3,max=50"`
+	Subtitle  string     `json:"subtitle" validate:"required,min=3,max=50"`
+	IsFree    bool       `json:"is_free"`
 }
 
+// UpdateCourseMaterialRequest leaves the section as is when SectionID is nil.
+// RemoveSection takes the material out of its section.
 type UpdateCourseMaterialRequest struct {
-	Title    *string `form:"title" validate:"omitempty,min=3,max=50"`
-	Subtitle *string `form:"subtitle" validate:"omitempty,min=3,max=50"`
-	IsFree   *bool   `form:"is_free"`
-	Order    *int    `form:"order"`
+	SectionID     *uuid.UUID `json:"section_id" form:"section_id"`
+	RemoveSection bool       `json:"remove_section" form:"remove_section" validate:"excluded_with=SectionID"`
+	Title         *string    `form:"title" validate:"omitempty,min=3,max=50"`
+	Subtitle      *string    `form:"subtitle" validate:"omitempty,min=3,max=50"`
+	IsFree        *bool      `form:"is_free"`
+}
+
+type CreateCourseSectionRequest struct {
+	Title       string `json:"title" validate:"required,min=3,max=50"`
+	Description string `json:"description" validate:"omitempty,max=1000"`
+	Order       int    `json:"order" validate:"required"`
+}
+
+type UpdateCourseSectionRequest struct {
+	Title       *string `json:"title" validate:"omitempty,min=3,max=50"`
+	Description *string `json:"description" validate:"omitempty,max=1000"`
+	Order       *int    `json:"order"`
+}
+
+type ReorderCourseContentsRequest struct {
+	ContentIDs []uuid.UUID `json:"content_ids" validate:"required,min=1,unique"`
 }
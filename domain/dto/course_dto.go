@@ -3,29 +3,51 @@ package dto
 import (
 	"fmt"
 	"mime/multipart"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
 )
 
 type CourseResponse struct {
-	ID               uuid.UUID `json:"id,omitempty"`
-	Category         string    `json:"category,omitempty"`
-	Title            string    `json:"title,omitempty"`
-	Description      string    `json:"description,omitempty"`
-	TeacherName      string    `json:"teacher_name,omitempty"`
-	TeacherAvatarURL string    `json:"teacher_avatar_url,omitempty"`
-	ThumbnailURL     string    `json:"thumbnail_url,omitempty"`
-	PreviewVideoURL  string    `json:"preview_video_url,omitempty"`
-	Rating           *float64  `json:"rating,omitempty"`
-	RatingCount      *int64    `json:"rating_count,omitempty"`
-	EnrollmentCount  *int64    `json:"enrollment_count,omitempty"`
-	ContentCount     *int      `json:"content_count,omitempty"`
-	TotalDuration    *int      `json:"total_duration,omitempty"`
+	ID                 uuid.UUID  `json:"id,omitempty"`
+	Category           string     `json:"category,omitempty"`
+	Title              string     `json:"title,omitempty"`
+	Description        string     `json:"description,omitempty"`
+	TeacherName        string     `json:"teacher_name,omitempty"`
+	TeacherAvatarURL   string     `json:"teacher_avatar_url,omitempty"`
+	ThumbnailURL       string     `json:"thumbnail_url,omitempty"`
+	PreviewVideoURL    string     `json:"preview_video_url,omitempty"`
+	Rating             *float64   `json:"rating,omitempty"`
+	RatingCount        *int64     `json:"rating_count,omitempty"`
+	EnrollmentCount    *int64     `json:"enrollment_count,omitempty"`
+	ContentCount       *int       `json:"content_count,omitempty"`
+	TotalDuration      *int       `json:"total_duration,omitempty"`
+	Price              *int       `json:"price,omitempty"`
+	Status             string     `json:"status,omitempty"`
+	PublishAt          *time.Time `json:"publish_at,omitempty"`
+	PreviewVideoStatus string     `json:"preview_video_status,omitempty"`
 
 	ContentCompleted *int  `json:"content_completed,omitempty"`
 	IsCompleted      *bool `json:"is_completed,omitempty"`
+	IsPurchased      *bool `json:"is_purchased,omitempty"`
+}
+
+// CourseAssetPaths returns the paths PopulateFromEntity signs, so list responses can sign them in one batch.
+// The preview video is only included once its upload is verified.
+func CourseAssetPaths(course *entity.Course) []string {
+	paths := []string{
+		fmt.Sprintf("courses/teacher_avatar/%s", course.ID),
+		fmt.Sprintf("courses/thumbnail/%s", course.ID),
+	}
+
+	if course.PreviewVideoStatus == enum.AssetStatusReady {
+		paths = append(paths, fmt.Sprintf("courses/preview_video/%s", course.ID))
+	}
+
+	return paths
 }
 
 func (c *CourseResponse) PopulateFromEntity(course *entity.Course,
@@ -41,9 +63,12 @@ func (c *CourseResponse) PopulateFromEntity(course *entity.Course,
 		return fmt.Errorf("failed to sign thumbnail URL: %w", err)
 	}
 
-	c.PreviewVideoURL, err = urlSigner(fmt.Sprintf("courses/preview_video/%s", course.ID))
-	if err != nil {
-		return fmt.Errorf("failed to sign preview video URL: %w", err)
+	// the preview video is uploaded directly to the storage, so it is only signed once verified
+	if course.PreviewVideoStatus == enum.AssetStatusReady {
+		c.PreviewVideoURL, err = urlSigner(fmt.Sprintf("courses/preview_video/%s", course.ID))
+		if err != nil {
+			return fmt.Errorf("failed to sign preview video URL: %w", err)
+		}
 	}
 
 	if course.Category != nil {
@@ -59,6 +84,14 @@ func (c *CourseResponse) PopulateFromEntity(course *entity.Course,
 	c.EnrollmentCount = &course.EnrollmentCount
 	c.ContentCount = &course.ContentCount
 	c.TotalDuration = &course.TotalDuration
+	c.Status = string(course.Status)
+	c.PublishAt = course.PublishAt
+	c.PreviewVideoStatus = string(course.PreviewVideoStatus)
+
+	// courses without a price can only be accessed through Skill Boost
+	if course.Price > 0 {
+		c.Price = &course.Price
+	}
 
 	return nil
 }
@@ -66,6 +99,7 @@ func (c *CourseResponse) PopulateFromEntity(course *entity.Course,
 func (c *CourseResponse) PopulateFromCourseEnrollment(enrollment *entity.CourseEnrollment) {
 	c.ContentCompleted = &enrollment.ContentCompleted
 	c.IsCompleted = &enrollment.IsCompleted
+	c.IsPurchased = &enrollment.IsPurchased
 }
 
 type CreateCourseRequest struct {
@@ -73,6 +107,7 @@ type CreateCourseRequest struct {
 	Title         string    `form:"title" validate:"required,min=3,max=50"`
 	Description   string    `form:"description" validate:"required,min=3,max=1000"`
 	TeacherName   string    `form:"teacher_name" validate:"required,min=3,max=50"`
+	Price         int       `form:"price" validate:"omitempty,min=1000"`
 	TeacherAvatar *multipart.FileHeader
 	Thumbnail     *multipart.FileHeader
 }
@@ -82,9 +117,13 @@ type CreateCourseResponse struct {
 	PreviewVideoUploadURL string          `json:"preview_video_upload_url"`
 }
 
+// GetCoursesQuery filters the courses. Status is only honored for admins;
+// VisibleOnly is set by the service to hide unpublished courses from everyone else.
 type GetCoursesQuery struct {
-	CategoryID uuid.UUID `query:"category_id" validate:"omitempty,uuid"`
-	Title      string    `query:"title" validate:"omitempty"`
+	CategoryID  uuid.UUID         `query:"category_id" validate:"omitempty,uuid"`
+	Title       string            `query:"title" validate:"omitempty"`
+	Status      enum.CourseStatus `query:"status" validate:"omitempty,oneof=draft scheduled published archived"`
+	VisibleOnly bool              `query:"-"`
 }
 
 type CourseUpdate struct {
@@ -93,6 +132,7 @@ type CourseUpdate struct {
 	Title       *string    `db:"title"`
 	Description *string    `db:"description"`
 	TeacherName *string    `db:"teacher_name"`
+	Price       *int       `db:"price"`
 }
 
 type UpdateCourseRequest struct {
@@ -100,6 +140,14 @@ type UpdateCourseRequest struct {
 	Title         *string    `form:"title" validate:"omitempty,min=3,max=50"`
 	Description   *string    `form:"description" validate:"omitempty,min=3,max=1000"`
 	TeacherName   *string    `form:"teacher_name" validate:"omitempty,min=3,max=60"`
+	Price         *int       `form:"price" validate:"omitempty,min=0"`
 	TeacherAvatar *multipart.FileHeader
 	Thumbnail     *multipart.FileHeader
 }
+
+// UpdateCourseStatusRequest moves a course through its publishing workflow.
+// PublishAt is required when scheduling and ignored otherwise.
+type UpdateCourseStatusRequest struct {
+	Status    enum.CourseStatus `json:"status" validate:"required,oneof=draft scheduled published archived"`
+	PublishAt *time.Time        `json:"publish_at"`
+}
@@ -0,0 +1,56 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/entity"
+)
+
+type CourseNoteResponse struct {
+	ID           uuid.UUID `json:"id"`
+	Type         string    `json:"type"`
+	ContentID    uuid.UUID `json:"content_id"`
+	ContentTitle string    `json:"content_title,omitempty"`
+	Timestamp    *int      `json:"timestamp,omitempty"`
+	Body         string    `json:"body"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (c *CourseNoteResponse) PopulateFromEntity(note *entity.CourseNote) {
+	c.ID = note.ID
+	if note.VideoID != nil {
+		c.Type = "video"
+		c.ContentID = *note.VideoID
+	} else if note.MaterialID != nil {
+		c.Type = "material"
+		c.ContentID = *note.MaterialID
+	}
+	c.ContentTitle = note.ContentTitle
+	c.Timestamp = note.Timestamp
+	c.Body = note.Body
+	c.CreatedAt = note.CreatedAt
+	c.UpdatedAt = note.UpdatedAt
+}
+
+type CourseNoteUpdate struct {
+	Timestamp *int    `db:"timestamp"`
+	Body      *string `db:"body"`
+}
+
+type CreateCourseVideoNoteRequest struct {
+	Timestamp *int   `json:"timestamp" validate:"required,gte=0"`
+	Body      string `json:"body" validate:"required,max=5000"`
+}
+
+// BookmarkCourseMaterialRequest bookmarks a material, replacing the note of the existing bookmark
+type BookmarkCourseMaterialRequest struct {
+	Body string `json:"body" validate:"max=5000"`
+}
+
+type UpdateCourseNoteRequest struct {
+	Timestamp *int    `json:"timestamp" validate:"omitempty,gte=0"`
+	Body      *string `json:"body" validate:"omitempty,max=5000"`
+}
@@ -0,0 +1,182 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
+)
+
+type CourseQuizOptionRequest struct {
+	Text      string `json:"text" validate:"required,max=500"`
+	IsCorrect bool   `json:"is_correct"`
+}
+
+// CourseQuizQuestionRequest is a question of a quiz. For short answer questions, every option is an accepted answer.
+type CourseQuizQuestionRequest struct {
+	Type     enum.QuizQuestionType     `json:"type" validate:"required,oneof=multiple_choice multi_select short_answer"`
+	Question string                    `json:"question" validate:"required,max=2000"`
+	Points   int                       `json:"points" validate:"omitempty,min=1,max=100"`
+	Options  []CourseQuizOptionRequest `json:"options" validate:"required,min=1,max=10,dive"`
+}
+
+type CreateCourseQuizRequest struct {
+	SectionID    *uuid.UUID                  `json:"section_id"`
+	Title        string                      `json:"title" validate:"required,min=3,max=50"`
+	Description  string                      `json:"description" validate:"omitempty,max=1000"`
+	PassingScore int                         `json:"passing_score" validate:"required,min=1,max=100"`
+	MaxAttempts  int                         `json:"max_attempts" validate:"min=0,max=100"`
+	IsFree       bool                        `json:"is_free"`
+	Questions    []CourseQuizQuestionRequest `json:"questions" validate:"required,min=1,max=100,dive"`
+}
+
+// UpdateCourseQuizRequest leaves the section as is when SectionID is nil.
+// RemoveSection takes the quiz out of its section.
+type UpdateCourseQuizRequest struct {
+	SectionID     *uuid.UUID `json:"section_id"`
+	RemoveSection bool       `json:"remove_section" validate:"excluded_with=SectionID"`
+	Title         *string    `json:"title" validate:"omitempty,min=3,max=50"`
+	Description   *string    `json:"description" validate:"omitempty,max=1000"`
+	PassingScore  *int       `json:"passing_score" validate:"omitempty,min=1,max=100"`
+	MaxAttempts   *int       `json:"max_attempts" validate:"omitempty,min=0,max=100"`
+	IsFree        *bool      `json:"is_free"`
+}
+
+type CourseQuizUpdate struct {
+	SectionID    *uuid.NullUUID `db:"section_id"`
+	Title        *string        `db:"title"`
+	Description  *string        `db:"description"`
+	PassingScore *int           `db:"passing_score"`
+	MaxAttempts  *int           `db:"max_attempts"`
+	IsFree       *bool          `db:"is_free"`
+}
+
+type ReplaceCourseQuizQuestionsRequest struct {
+	Questions []CourseQuizQuestionRequest `json:"questions" validate:"required,min=1,max=100,dive"`
+}
+
+type CourseQuizAnswerRequest struct {
+	QuestionID uuid.UUID   `json:"question_id" validate:"required"`
+	OptionIDs  []uuid.UUID `json:"option_ids" validate:"omitempty,unique"`
+	Text       string      `json:"text" validate:"max=500"`
+}
+
+// SubmitCourseQuizRequest holds the answers of an attempt. Unanswered questions are graded as incorrect.
+type SubmitCourseQuizRequest struct {
+	Answers []CourseQuizAnswerRequest `json:"answers" validate:"required,dive"`
+}
+
+type CourseQuizResponse struct {
+	ID           uuid.UUID                     `json:"id"`
+	CourseID     uuid.UUID                     `json:"course_id"`
+	SectionID    *uuid.UUID                    `json:"section_id,omitempty"`
+	Title        string                        `json:"title"`
+	Description  string                        `json:"description,omitempty"`
+	PassingScore int                           `json:"passing_score"`
+	MaxAttempts  int                           `json:"max_attempts"`
+	IsFree       bool                          `json:"is_free"`
+	Order        int                           `json:"order"`
+	Questions    []*CourseQuizQuestionResponse `json:"questions,omitempty"`
+}
+
+type CourseQuizQuestionResponse struct {
+	ID       uuid.UUID                   `json:"id"`
+	Type     enum.QuizQuestionType       `json:"type"`
+	Question string                      `json:"question"`
+	Points   int                         `json:"points"`
+	Options  []*CourseQuizOptionResponse `json:"options,omitempty"`
+}
+
+type CourseQuizOptionResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Text      string    `json:"text"`
+	IsCorrect *bool     `json:"is_correct,omitempty"`
+}
+
+// PopulateFromEntity fills the response from the quiz. Unless withAnswers, the correct options
+// and the accepted answers of short answer questions are left out.
+func (c *CourseQuizResponse) PopulateFromEntity(quiz *entity.CourseQuiz, withAnswers bool) {
+	c.ID = quiz.ID
+	c.CourseID = quiz.CourseID
+	c.SectionID = quiz.SectionID
+	c.Title = quiz.Title
+	c.Description = quiz.Description
+	c.PassingScore = quiz.PassingScore
+	c.MaxAttempts = quiz.MaxAttempts
+	c.IsFree = quiz.IsFree
+	c.Order = quiz.Order
+
+	c.Questions = make([]*CourseQuizQuestionResponse, len(quiz.Questions))
+	for i, question := range quiz.Questions {
+		c.Questions[i] = &CourseQuizQuestionResponse{
+			ID:       question.ID,
+			Type:     question.Type,
+			Question: question.Question,
+			Points:   question.Points,
+		}
+
+		if question.Type == enum.QuizQuestionTypeShortAnswer && !withAnswers {
+			continue
+		}
+
+		c.Questions[i].Options = make([]*CourseQuizOptionResponse, len(question.Options))
+		for j, option := range question.Options {
+			c.Questions[i].Options[j] = &CourseQuizOptionResponse{
+				ID:   option.ID,
+				Text: option.Text,
+			}
+			if withAnswers {
+				isCorrect := option.IsCorrect
+				c.Questions[i].Options[j].IsCorrect = &isCorrect
+			}
+		}
+	}
+}
+
+type CourseQuizAttemptResponse struct {
+	ID         uuid.UUID                   `json:"id"`
+	QuizID     uuid.UUID                   `json:"quiz_id"`
+	Score      int                         `json:"score"`
+	MaxScore   int                         `json:"max_score"`
+	Percentage int                         `json:"percentage"`
+	IsPassed   bool                        `json:"is_passed"`
+	CreatedAt  time.Time                   `json:"created_at"`
+	Results    []*CourseQuizResultResponse `json:"results,omitempty"`
+}
+
+type CourseQuizResultResponse struct {
+	QuestionID uuid.UUID `json:"question_id"`
+	IsCorrect  bool      `json:"is_correct"`
+}
+
+func (c *CourseQuizAttemptResponse) PopulateFromEntity(attempt *entity.CourseQuizAttempt) {
+	c.ID = attempt.ID
+	c.QuizID = attempt.QuizID
+	c.Score = attempt.Score
+	c.MaxScore = attempt.MaxScore
+	if attempt.MaxScore > 0 {
+		c.Percentage = attempt.Score * 100 / attempt.MaxScore
+	}
+	c.IsPassed = attempt.IsPassed
+	c.CreatedAt = attempt.CreatedAt
+
+	if len(attempt.Answers) > 0 {
+		c.Results = make([]*CourseQuizResultResponse, len(attempt.Answers))
+		for i, answer := range attempt.Answers {
+			c.Results[i] = &CourseQuizResultResponse{
+				QuestionID: answer.QuestionID,
+				IsCorrect:  answer.IsCorrect,
+			}
+		}
+	}
+}
+
+// CourseQuizAttemptsResponse is the attempt history of a student, newest first.
+// AttemptsRemaining is nil when the quiz allows unlimited attempts.
+type CourseQuizAttemptsResponse struct {
+	Attempts          []*CourseQuizAttemptResponse `json:"attempts"`
+	AttemptsRemaining *int                         `json:"attempts_remaining"`
+	IsPassed          bool                         `json:"is_passed"`
+}
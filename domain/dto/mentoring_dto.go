@@ -1,23 +1,29 @@
 package dto
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
 )
 
 type ChatResponse struct {
-	ID                 uuid.UUID  `json:"id"`
-	MentorID           uuid.UUID  `json:"mentor_id"`
-	StudentID          uuid.UUID  `json:"student_id"`
-	ExpiresAt          time.Time  `json:"expires_at"`
-	IsTrial            bool       `json:"is_trial"`
-	MentorAvatar       string     `json:"mentor_avatar,omitempty"`
-	StudentAvatar      string     `json:"student_avatar,omitempty"`
-	LastMessageContent string     `json:"last_message_content,omitempty"`
-	LastMessageTime    *time.Time `json:"last_message_time,omitempty"`
+	ID                       uuid.UUID  `json:"id"`
+	MentorID                 uuid.UUID  `json:"mentor_id"`
+	StudentID                uuid.UUID  `json:"student_id"`
+	ExpiresAt                time.Time  `json:"expires_at"`
+	IsTrial                  bool       `json:"is_trial"`
+	MentorAvatar             string     `json:"mentor_avatar,omitempty"`
+	StudentAvatar            string     `json:"student_avatar,omitempty"`
+	LastMessageContent       string     `json:"last_message_content,omitempty"`
+	LastMessageTime          *time.Time `json:"last_message_time,omitempty"`
+	MentorLastReadMessageID  *uuid.UUID `json:"mentor_last_read_message_id,omitempty"`
+	StudentLastReadMessageID *uuid.UUID `json:"student_last_read_message_id,omitempty"`
+	UnreadCount              int        `json:"unread_count"`
 }
 
 func (r *ChatResponse) PopulateFromEntity(chat *entity.MentoringChat, urlSigner func(string) (string, error)) error {
@@ -38,6 +44,9 @@ func (r *ChatResponse) PopulateFromEntity(chat *entity.MentoringChat, urlSigner
 	r.IsTrial = chat.IsTrial
 	r.MentorAvatar = mentorAvatar
 	r.StudentAvatar = studentAvatar
+	r.MentorLastReadMessageID = chat.MentorLastReadMessageID
+	r.StudentLastReadMessageID = chat.StudentLastReadMessageID
+	r.UnreadCount = chat.UnreadCount
 
 	if chat.LastMessage != nil {
 		r.LastMessageContent = chat.LastMessage.Message
@@ -47,16 +56,147 @@ func (r *ChatResponse) PopulateFromEntity(chat *entity.MentoringChat, urlSigner
 	return nil
 }
 
+type TrialAllowanceResponse struct {
+	TrialsPerStudent int `json:"trials_per_student"`
+	TrialsUsed       int `json:"trials_used"`
+	TrialsRemaining  int `json:"trials_remaining"`
+	DurationSeconds  int `json:"duration_seconds"`
+	MessageLimit     int `json:"message_limit"` // 0 means unlimited
+}
+
 type MessageResponse struct {
-	ID        uuid.UUID `json:"id"`
-	SenderID  uuid.UUID `json:"sender_id"`
-	Message   string    `json:"message"`
-	CreatedAt time.Time `json:"created_at"`
+	ID         uuid.UUID           `json:"id"`
+	SenderID   uuid.UUID           `json:"sender_id"`
+	Message    string              `json:"message"`
+	CreatedAt  time.Time           `json:"created_at"`
+	EditedAt   *time.Time          `json:"edited_at,omitempty"`
+	IsDeleted  bool                `json:"is_deleted"`
+	Attachment *AttachmentResponse `json:"attachment,omitempty"`
 }
 
-func (r *MessageResponse) PopulateFromEntity(message *entity.MentoringMessage) {
+func (r *MessageResponse) PopulateFromEntity(message *entity.MentoringMessage,
+	urlSigner func(string) (string, error)) error {
 	r.ID = message.ID
 	r.SenderID = message.SenderID
-	r.Message = message.Message
 	r.CreatedAt = message.CreatedAt
+	r.EditedAt = message.EditedAt
+
+	// deleted messages keep their place in the chat without their content
+	if message.DeletedAt != nil {
+		r.IsDeleted = true
+		return nil
+	}
+
+	r.Message = message.Message
+
+	if message.Attachment != nil {
+		url, err := urlSigner(fmt.Sprintf("mentoring_attachments/%s/%s",
+			message.Attachment.ChatID, message.Attachment.ID))
+		if err != nil {
+			return err
+		}
+
+		r.Attachment = &AttachmentResponse{
+			ID:          message.Attachment.ID,
+			FileName:    message.Attachment.FileName,
+			ContentType: message.Attachment.ContentType,
+			Size:        message.Attachment.Size,
+			URL:         url,
+		}
+	}
+
+	return nil
+}
+
+type AttachmentResponse struct {
+	ID          uuid.UUID `json:"id"`
+	FileName    string    `json:"file_name"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	URL         string    `json:"url"`
+}
+
+type CreateAttachmentRequest struct {
+	FileName    string `json:"file_name" validate:"required,max=255"`
+	ContentType string `json:"content_type" validate:"required,max=100"`
+	Size        int64  `json:"size" validate:"required,min=1"`
+}
+
+type CreateAttachmentResponse struct {
+	AttachmentID uuid.UUID `json:"attachment_id"`
+	UploadURL    string    `json:"upload_url"`
+}
+
+type SendMessageRequest struct {
+	Message      string     `json:"message" validate:"required_without=AttachmentID,max=2000"`
+	AttachmentID *uuid.UUID `json:"attachment_id"`
+}
+
+type EditMessageRequest struct {
+	Message string `json:"message" validate:"required,max=2000"`
+}
+
+type MarkChatReadRequest struct {
+	MessageID uuid.UUID `json:"message_id" validate:"required"`
+}
+
+type ChatExpiryResponse struct {
+	ChatID    uuid.UUID `json:"chat_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ChatExtension is stored behind the token of an extension link
+type ChatExtension struct {
+	ChatID    uuid.UUID `json:"chat_id"`
+	StudentID uuid.UUID `json:"student_id"`
+	MentorID  uuid.UUID `json:"mentor_id"`
+}
+
+type ReadReceiptResponse struct {
+	ChatID    uuid.UUID `json:"chat_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	MessageID uuid.UUID `json:"message_id"`
+	ReadAt    time.Time `json:"read_at"`
+}
+
+type TypingRequest struct {
+	IsTyping bool `json:"is_typing"`
+}
+
+type TypingResponse struct {
+	UserID   uuid.UUID `json:"user_id"`
+	IsTyping bool      `json:"is_typing"`
+}
+
+type PresenceResponse struct {
+	UserID   uuid.UUID `json:"user_id"`
+	IsOnline bool      `json:"is_online"`
+}
+
+// ChatEventRequest is a frame received through the chat websocket. Data is decoded according to Type.
+type ChatEventRequest struct {
+	Type            enum.ChatEventType `json:"type"`
+	ClientMessageID string             `json:"client_message_id"`
+	Data            json.RawMessage    `json:"data"`
+}
+
+// ChatEvent is a frame sent through the chat websocket
+type ChatEvent struct {
+	Type            enum.ChatEventType `json:"type"`
+	ClientMessageID string             `json:"client_message_id,omitempty"`
+	Data            interface{}        `json:"data,omitempty"`
+}
+
+type CreateTranscriptRequest struct {
+	Format enum.TranscriptFormat `json:"format" validate:"required,oneof=pdf markdown"`
+}
+
+type TranscriptResponse struct {
+	ID          uuid.UUID             `json:"id"`
+	ChatID      uuid.UUID             `json:"chat_id"`
+	Format      enum.TranscriptFormat `json:"format"`
+	Status      enum.TranscriptStatus `json:"status"`
+	URL         string                `json:"url,omitempty"`
+	CreatedAt   time.Time             `json:"created_at"`
+	CompletedAt *time.Time            `json:"completed_at,omitempty"`
 }
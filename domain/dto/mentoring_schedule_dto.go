@@ -0,0 +1,100 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
+)
+
+type AvailabilitySlot struct {
+	Weekday   time.Weekday `json:"weekday" validate:"min=0,max=6"`
+	StartTime string       `json:"start_time" validate:"required,datetime=15:04"`
+	EndTime   string       `json:"end_time" validate:"required,datetime=15:04"`
+}
+
+// Minutes converts the slot times into minutes since midnight
+func (s *AvailabilitySlot) Minutes() (int, int, error) {
+	start, err := time.Parse("15:04", s.StartTime)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	end, err := time.Parse("15:04", s.EndTime)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return start.Hour()*60 + start.Minute(), end.Hour()*60 + end.Minute(), nil
+}
+
+func (s *AvailabilitySlot) PopulateFromEntity(availability *entity.MentorAvailability) {
+	s.Weekday = availability.Weekday
+	s.StartTime = fmt.Sprintf("%02d:%02d", availability.StartMinute/60, availability.StartMinute%60)
+	s.EndTime = fmt.Sprintf("%02d:%02d", availability.EndMinute/60, availability.EndMinute%60)
+}
+
+type SetAvailabilityRequest struct {
+	Slots []AvailabilitySlot `json:"slots" validate:"max=50,dive"`
+}
+
+type CreateAvailabilityExceptionRequest struct {
+	StartsAt time.Time `json:"starts_at" validate:"required"`
+	EndsAt   time.Time `json:"ends_at" validate:"required,gtfield=StartsAt"`
+	Reason   *string   `json:"reason" validate:"omitempty,max=255"`
+}
+
+type AvailabilityExceptionResponse struct {
+	ID       uuid.UUID `json:"id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   *string   `json:"reason,omitempty"`
+}
+
+func (r *AvailabilityExceptionResponse) PopulateFromEntity(exception *entity.MentorAvailabilityException) {
+	r.ID = exception.ID
+	r.StartsAt = exception.StartsAt
+	r.EndsAt = exception.EndsAt
+	r.Reason = exception.Reason
+}
+
+type MentorAvailabilityResponse struct {
+	Timezone   string                           `json:"timezone"`
+	Slots      []*AvailabilitySlot              `json:"slots"`
+	Exceptions []*AvailabilityExceptionResponse `json:"exceptions"`
+}
+
+type AvailableSlotsRequest struct {
+	From string `query:"from" validate:"required,datetime=2006-01-02"`
+	To   string `query:"to" validate:"required,datetime=2006-01-02"`
+}
+
+type AvailableSlotResponse struct {
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+}
+
+type MentoringSessionResponse struct {
+	ID          uuid.UUID                   `json:"id"`
+	MentorID    uuid.UUID                   `json:"mentor_id"`
+	StudentID   uuid.UUID                   `json:"student_id"`
+	MentorName  string                      `json:"mentor_name,omitempty"`
+	StudentName string                      `json:"student_name,omitempty"`
+	StartsAt    time.Time                   `json:"starts_at"`
+	EndsAt      time.Time                   `json:"ends_at"`
+	Status      enum.MentoringSessionStatus `json:"status"`
+}
+
+func (r *MentoringSessionResponse) PopulateFromEntity(session *entity.MentoringSession) {
+	r.ID = session.ID
+	r.MentorID = session.MentorID
+	r.StudentID = session.StudentID
+	r.MentorName = session.MentorName
+	r.StudentName = session.StudentName
+	r.StartsAt = session.StartsAt
+	r.EndsAt = session.EndsAt
+	r.Status = session.Status
+}
@@ -1,25 +1,32 @@
 package dto
 
 import (
-	"github.com/google/uuid"
+	"fmt"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/nathakusuma/elevateu-backend/domain/entity"
 	"github.com/nathakusuma/elevateu-backend/domain/enum"
 )
 
 type PaymentResponse struct {
-	ID        uuid.UUID          `json:"id"`
-	UserID    uuid.UUID          `json:"user_id"`
-	Token     string             `json:"token"`
-	Amount    int                `json:"amount"`
-	Title     string             `json:"title"`
-	Detail    *string            `json:"detail"`
-	Method    string             `json:"method"`
-	Status    enum.PaymentStatus `json:"status"`
-	ExpiredAt time.Time          `json:"expired_at"`
-	CreatedAt time.Time          `json:"created_at"`
-	UpdatedAt time.Time          `json:"updated_at"`
+	ID            uuid.UUID          `json:"id"`
+	UserID        uuid.UUID          `json:"user_id"`
+	Token         string             `json:"token"`
+	Amount        int                `json:"amount"`
+	Discount      int                `json:"discount"`
+	Type          enum.PaymentType   `json:"type"`
+	Title         string             `json:"title"`
+	Detail        *string            `json:"detail"`
+	Method        string             `json:"method"`
+	Status        enum.PaymentStatus `json:"status"`
+	InvoiceNumber string             `json:"invoice_number,omitempty"`
+	RefundReason  *string            `json:"refund_reason,omitempty"`
+	PaidAt        *time.Time         `json:"paid_at,omitempty"`
+	ExpiredAt     time.Time          `json:"expired_at"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
 }
 
 func (p *PaymentResponse) PopulateFromEntity(payment *entity.Payment) {
@@ -27,24 +34,63 @@ func (p *PaymentResponse) PopulateFromEntity(payment *entity.Payment) {
 	p.UserID = payment.UserID
 	p.Token = payment.Token
 	p.Amount = payment.Amount
+	p.Discount = payment.Discount
+	p.Type = payment.Type
 	p.Title = payment.Title
 	p.Detail = payment.Detail
 	p.Method = payment.Method
+	p.RefundReason = payment.RefundReason
+	p.PaidAt = payment.PaidAt
 	p.ExpiredAt = payment.ExpiredAt
 	p.CreatedAt = payment.CreatedAt
 	p.UpdatedAt = payment.UpdatedAt
 
-	if payment.ExpiredAt.Before(time.Now()) {
+	if payment.InvoiceNumber != nil {
+		p.InvoiceNumber = FormatInvoiceNumber(*payment.InvoiceNumber, payment.CreatedAt)
+	}
+
+	if payment.Status == enum.PaymentStatusPending && payment.ExpiredAt.Before(time.Now()) {
 		p.Status = enum.PaymentStatusFailure
 	} else {
 		p.Status = payment.Status
 	}
 }
 
+// FormatInvoiceNumber formats a sequential invoice number, e.g. INV/2025/000042
+func FormatInvoiceNumber(invoiceNumber int64, createdAt time.Time) string {
+	return fmt.Sprintf("INV/%d/%06d", createdAt.Year(), invoiceNumber)
+}
+
 type CreatePaymentRequest struct {
-	UserID  uuid.UUID
-	Amount  int
-	Title   string
-	Detail  *string
-	Payload entity.PaymentPayload
+	UserID   uuid.UUID
+	Amount   int
+	Discount int
+	Badge    *enum.StudentBadge
+	Title    string
+	Detail   *string
+	Payload  entity.PaymentPayload
+}
+
+type PaymentStatusEvent struct {
+	PaymentID     uuid.UUID          `json:"payment_id"`
+	Status        enum.PaymentStatus `json:"status"`
+	Method        string             `json:"method"`
+	InvoiceNumber string             `json:"invoice_number,omitempty"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+func (e *PaymentStatusEvent) PopulateFromEntity(payment *entity.Payment) {
+	var resp PaymentResponse
+	resp.PopulateFromEntity(payment)
+
+	e.PaymentID = resp.ID
+	e.Status = resp.Status
+	e.Method = resp.Method
+	e.InvoiceNumber = resp.InvoiceNumber
+	e.UpdatedAt = resp.UpdatedAt
+}
+
+// IsFinal reports whether no further status transitions are expected
+func (e *PaymentStatusEvent) IsFinal() bool {
+	return e.Status == enum.PaymentStatusSuccess || e.Status == enum.PaymentStatusFailure
 }
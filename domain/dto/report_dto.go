@@ -0,0 +1,109 @@
+package dto
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
+)
+
+type ReportRequest struct {
+	StartDate   string `query:"start_date" validate:"required,datetime=2006-01-02"`
+	EndDate     string `query:"end_date" validate:"required,datetime=2006-01-02"`
+	Granularity string `query:"granularity" validate:"omitempty,oneof=day week month"`
+	Format      string `query:"format" validate:"omitempty,oneof=json csv"`
+}
+
+// ReportFilter is the parsed form of ReportRequest. EndAt is exclusive.
+type ReportFilter struct {
+	StartAt     time.Time
+	EndAt       time.Time
+	Granularity string
+}
+
+// RevenuePeriodReport and the other revenue reports count successful payments by the time they were paid
+type RevenuePeriodReport struct {
+	Period       time.Time `db:"period" json:"period"`
+	Revenue      int64     `db:"revenue" json:"revenue"`
+	Discount     int64     `db:"discount" json:"discount"`
+	Transactions int64     `db:"transactions" json:"transactions"`
+}
+
+func (r *RevenuePeriodReport) CSVRecord() []string {
+	return []string{
+		r.Period.Format("2006-01-02"),
+		strconv.FormatInt(r.Revenue, 10),
+		strconv.FormatInt(r.Discount, 10),
+		strconv.FormatInt(r.Transactions, 10),
+	}
+}
+
+type RevenueByTypeReport struct {
+	Type         enum.PaymentType `db:"type" json:"type"`
+	Revenue      int64            `db:"revenue" json:"revenue"`
+	Transactions int64            `db:"transactions" json:"transactions"`
+}
+
+func (r *RevenueByTypeReport) CSVRecord() []string {
+	return []string{
+		string(r.Type),
+		strconv.FormatInt(r.Revenue, 10),
+		strconv.FormatInt(r.Transactions, 10),
+	}
+}
+
+type RevenueByMethodReport struct {
+	Method       string `db:"method" json:"method"`
+	Revenue      int64  `db:"revenue" json:"revenue"`
+	Transactions int64  `db:"transactions" json:"transactions"`
+}
+
+func (r *RevenueByMethodReport) CSVRecord() []string {
+	return []string{
+		r.Method,
+		strconv.FormatInt(r.Revenue, 10),
+		strconv.FormatInt(r.Transactions, 10),
+	}
+}
+
+type ConversionReport struct {
+	Total          int64   `db:"total" json:"total"`
+	Succeeded      int64   `db:"succeeded" json:"succeeded"`
+	Failed         int64   `db:"failed" json:"failed"`
+	Expired        int64   `db:"expired" json:"expired"`
+	Pending        int64   `db:"pending" json:"pending"`
+	ConversionRate float64 `db:"-" json:"conversion_rate"`
+}
+
+func (r *ConversionReport) CSVRecord() []string {
+	return []string{
+		strconv.FormatInt(r.Total, 10),
+		strconv.FormatInt(r.Succeeded, 10),
+		strconv.FormatInt(r.Failed, 10),
+		strconv.FormatInt(r.Expired, 10),
+		strconv.FormatInt(r.Pending, 10),
+		strconv.FormatFloat(r.ConversionRate, 'f', 4, 64),
+	}
+}
+
+// MentorPayoutReport holds what a mentor earned within the date range.
+// Owed is the current balance of the mentor and ignores the date range.
+type MentorPayoutReport struct {
+	MentorID uuid.UUID `db:"mentor_id" json:"mentor_id"`
+	Name     string    `db:"name" json:"name"`
+	Email    string    `db:"email" json:"email"`
+	Earned   int64     `db:"earned" json:"earned"`
+	Owed     int64     `db:"owed" json:"owed"`
+}
+
+func (r *MentorPayoutReport) CSVRecord() []string {
+	return []string{
+		r.MentorID.String(),
+		r.Name,
+		r.Email,
+		strconv.FormatInt(r.Earned, 10),
+		strconv.FormatInt(r.Owed, 10),
+	}
+}
@@ -41,6 +41,7 @@ type MentorData struct {
 	RatingCount    int     `json:"rating_count,omitempty"`
 	Price          int     `json:"price,omitempty"`
 	Balance        int     `json:"balance,omitempty"`
+	AcceptsTrial   *bool   `json:"accepts_trial,omitempty"`
 }
 
 func (u *UserResponse) PopulateFromEntity(user *entity.User,
@@ -92,6 +93,7 @@ func (u *UserResponse) PopulateFromEntity(user *entity.User,
 			RatingCount:    user.Mentor.RatingCount,
 			Price:          user.Mentor.Price,
 			Balance:        user.Mentor.Balance,
+			AcceptsTrial:   &user.Mentor.AcceptsTrial,
 		}
 	}
 
@@ -132,6 +134,7 @@ func (u *UserResponse) PopulateMinimalFromEntity(user *entity.User,
 			Rating:         user.Mentor.Rating,
 			RatingCount:    user.Mentor.RatingCount,
 			Price:          user.Mentor.Price,
+			AcceptsTrial:   &user.Mentor.AcceptsTrial,
 		}
 	}
 
@@ -162,6 +165,7 @@ type MentorUpdate struct {
 	Bio            *string `db:"bio"`
 	Gender         *string `db:"gender"`
 	Price          *int    `db:"price"`
+	AcceptsTrial   *bool   `db:"accepts_trial"`
 }
 
 type CreateUserRequest struct {
@@ -204,4 +208,5 @@ type UpdateMentorRequest struct {
 	Company        *string `json:"company" validate:"omitempty,min=1,max=255"`
 	Bio            *string `json:"bio" validate:"omitempty,min=1,max=255"`
 	Gender         *string `json:"gender" validate:"omitempty,oneof=male female"`
+	AcceptsTrial   *bool   `json:"accepts_trial"`
 }
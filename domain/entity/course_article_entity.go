@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CourseArticle is a text lesson. Body is the Markdown source and BodyHTML its sanitized rendering.
+type CourseArticle struct {
+	ID        uuid.UUID  `db:"id"`
+	CourseID  uuid.UUID  `db:"course_id"`
+	SectionID *uuid.UUID `db:"section_id"`
+	Title     string     `db:"title"`
+	Subtitle  string     `db:"subtitle"`
+	Body      string     `db:"body"`
+	BodyHTML  string     `db:"body_html"`
+	Revision  int        `db:"revision"`
+	IsFree    bool       `db:"is_free"`
+	Order     int        `db:"order"`
+	CreatedAt time.Time  `db:"created_at"`
+	UpdatedAt time.Time  `db:"updated_at"`
+}
+
+type CourseArticleRevision struct {
+	ArticleID uuid.UUID  `db:"article_id"`
+	Revision  int        `db:"revision"`
+	Title     string     `db:"title"`
+	Body      string     `db:"body"`
+	EditorID  *uuid.UUID `db:"editor_id"`
+	CreatedAt time.Time  `db:"created_at"`
+}
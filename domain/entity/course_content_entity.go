@@ -4,27 +4,57 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
 )
 
 type CourseVideo struct {
+	ID              uuid.UUID        `db:"id"`
+	CourseID        uuid.UUID        `db:"course_id"`
+	SectionID       *uuid.UUID       `db:"section_id"`
+	Title           string           `db:"title"`
+	Description     string           `db:"description"`
+	Duration        int              `db:"duration"`
+	VideoStatus     enum.AssetStatus `db:"video_status"`
+	ThumbnailStatus enum.AssetStatus `db:"thumbnail_status"`
+	IsFree          bool             `db:"is_free"`
+	Order           int              `db:"order"`
+	CreatedAt       time.Time        `db:"created_at"`
+	UpdatedAt       time.Time        `db:"updated_at"`
+
+	Subtitles []*CourseVideoSubtitle `db:"-"`
+}
+
+// CourseVideoSubtitle is a WebVTT subtitle track of a video in one language.
+// Transcript is the text of the track, kept for the course search.
+type CourseVideoSubtitle struct {
+	VideoID    uuid.UUID `db:"video_id"`
+	Language   string    `db:"language"`
+	Label      string    `db:"label"`
+	Transcript string    `db:"transcript"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
+
+type CourseMaterial struct {
+	ID             uuid.UUID        `db:"id"`
+	CourseID       uuid.UUID        `db:"course_id"`
+	SectionID      *uuid.UUID       `db:"section_id"`
+	Title          string           `db:"title"`
+	Subtitle       string           `db:"subtitle"`
+	MaterialStatus enum.AssetStatus `db:"material_status"`
+	IsFree         bool             `db:"is_free"`
+	Order          int              `db:"order"`
+	CreatedAt      time.Time        `db:"created_at"`
+	UpdatedAt      time.Time        `db:"updated_at"`
+}
+
+type CourseSection struct {
 	ID          uuid.UUID `db:"id"`
 	CourseID    uuid.UUID `db:"course_id"`
 	Title       string    `db:"title"`
 	Description string    `db:"description"`
-	Duration    int       `db:"duration"`
-	IsFree      bool      `db:"is_free"`
 	Order       int       `db:"order"`
 	CreatedAt   time.Time `db:"created_at"`
 	UpdatedAt   time.Time `db:"updated_at"`
 }
-
-type CourseMaterial struct {
-	ID        uuid.UUID `db:"id"`
-	CourseID  uuid.UUID `db:"course_id"`
-	Title     string    `db:"title"`
-	Subtitle  string    `db:"subtitle"`
-	IsFree    bool      `db:"is_free"`
-	Order     int       `db:"order"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
-}
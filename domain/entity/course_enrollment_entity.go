@@ -11,6 +11,7 @@ type CourseEnrollment struct {
 	StudentID        uuid.UUID `db:"student_id"`
 	ContentCompleted int       `db:"content_completed"`
 	IsCompleted      bool      `db:"is_completed"`
+	IsPurchased      bool      `db:"is_purchased"`
 	CreatedAt        time.Time `db:"created_at"`
 	LastAccessedAt   time.Time `db:"last_accessed_at"`
 }
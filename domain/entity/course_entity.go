@@ -4,22 +4,28 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
 )
 
 type Course struct {
-	ID              uuid.UUID `db:"id"`
-	CategoryID      uuid.UUID `db:"category_id"`
-	Title           string    `db:"title"`
-	Description     string    `db:"description"`
-	TeacherName     string    `db:"teacher_name"`
-	Rating          float64   `db:"rating"`
-	RatingCount     int64     `db:"rating_count"`
-	TotalRating     float64   `db:"total_rating"`
-	EnrollmentCount int64     `db:"enrollment_count"`
-	ContentCount    int       `db:"content_count"`
-	TotalDuration   int       `db:"total_duration"`
-	CreatedAt       time.Time `db:"created_at"`
-	UpdatedAt       time.Time `db:"updated_at"`
+	ID                 uuid.UUID         `db:"id"`
+	CategoryID         uuid.UUID         `db:"category_id"`
+	Title              string            `db:"title"`
+	Description        string            `db:"description"`
+	TeacherName        string            `db:"teacher_name"`
+	Rating             float64           `db:"rating"`
+	RatingCount        int64             `db:"rating_count"`
+	TotalRating        float64           `db:"total_rating"`
+	EnrollmentCount    int64             `db:"enrollment_count"`
+	ContentCount       int               `db:"content_count"`
+	TotalDuration      int               `db:"total_duration"`
+	Price              int               `db:"price"`
+	Status             enum.CourseStatus `db:"status"`
+	PublishAt          *time.Time        `db:"publish_at"`
+	PreviewVideoStatus enum.AssetStatus  `db:"preview_video_status"`
+	CreatedAt          time.Time         `db:"created_at"`
+	UpdatedAt          time.Time         `db:"updated_at"`
 
 	Category   *Category         `db:"category"`
 	Enrollment *CourseEnrollment `db:"enrollment"`
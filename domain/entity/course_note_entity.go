@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CourseNote is a note of a student on a video at a timestamp, or a bookmark on a material.
+// Exactly one of VideoID and MaterialID is set, and Timestamp is only set for videos.
+type CourseNote struct {
+	ID         uuid.UUID  `db:"id"`
+	StudentID  uuid.UUID  `db:"student_id"`
+	CourseID   uuid.UUID  `db:"course_id"`
+	VideoID    *uuid.UUID `db:"video_id"`
+	MaterialID *uuid.UUID `db:"material_id"`
+	Timestamp  *int       `db:"timestamp"` // seconds into the video
+	Body       string     `db:"body"`
+	CreatedAt  time.Time  `db:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at"`
+
+	// only set when listing the notes of a course
+	ContentTitle string `db:"content_title"`
+	ContentOrder int    `db:"content_order"`
+}
@@ -13,3 +13,21 @@ type CourseMaterialProgress struct {
 	StudentID  uuid.UUID `db:"student_id"`
 	MaterialID uuid.UUID `db:"material_id"`
 }
+
+type CourseArticleProgress struct {
+	StudentID uuid.UUID `db:"student_id"`
+	ArticleID uuid.UUID `db:"article_id"`
+}
+
+type CourseQuizProgress struct {
+	StudentID uuid.UUID `db:"student_id"`
+	QuizID    uuid.UUID `db:"quiz_id"`
+}
+
+// CourseSectionProgress counts a student's completed contents in a section.
+// SectionID is nil for the contents that don't belong to any section.
+type CourseSectionProgress struct {
+	SectionID        *uuid.UUID `db:"section_id"`
+	ContentCount     int        `db:"content_count"`
+	ContentCompleted int        `db:"content_completed"`
+}
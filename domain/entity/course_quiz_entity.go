@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
+)
+
+type CourseQuiz struct {
+	ID           uuid.UUID  `db:"id"`
+	CourseID     uuid.UUID  `db:"course_id"`
+	SectionID    *uuid.UUID `db:"section_id"`
+	Title        string     `db:"title"`
+	Description  string     `db:"description"`
+	PassingScore int        `db:"passing_score"`
+	MaxAttempts  int        `db:"max_attempts"`
+	IsFree       bool       `db:"is_free"`
+	Order        int        `db:"order"`
+	CreatedAt    time.Time  `db:"created_at"`
+	UpdatedAt    time.Time  `db:"updated_at"`
+
+	Questions []*CourseQuizQuestion `db:"-"`
+}
+
+type CourseQuizQuestion struct {
+	ID       uuid.UUID             `db:"id"`
+	QuizID   uuid.UUID             `db:"quiz_id"`
+	Type     enum.QuizQuestionType `db:"type"`
+	Question string                `db:"question"`
+	Points   int                   `db:"points"`
+	Order    int                   `db:"order"`
+
+	Options []*CourseQuizOption `db:"-"`
+}
+
+// CourseQuizOption is a choice of a question. For short answer questions, the options are the accepted answers.
+type CourseQuizOption struct {
+	ID         uuid.UUID `db:"id"`
+	QuestionID uuid.UUID `db:"question_id"`
+	Text       string    `db:"text"`
+	IsCorrect  bool      `db:"is_correct"`
+	Order      int       `db:"order"`
+}
+
+type CourseQuizAttempt struct {
+	ID        uuid.UUID `db:"id"`
+	QuizID    uuid.UUID `db:"quiz_id"`
+	StudentID uuid.UUID `db:"student_id"`
+	Score     int       `db:"score"`
+	MaxScore  int       `db:"max_score"`
+	IsPassed  bool      `db:"is_passed"`
+	CreatedAt time.Time `db:"created_at"`
+
+	Answers []*CourseQuizAnswer `db:"-"`
+}
+
+type CourseQuizAnswer struct {
+	AttemptID  uuid.UUID   `db:"attempt_id"`
+	QuestionID uuid.UUID   `db:"question_id"`
+	AnswerText string      `db:"answer_text"`
+	IsCorrect  bool        `db:"is_correct"`
+	OptionIDs  []uuid.UUID `db:"-"`
+}
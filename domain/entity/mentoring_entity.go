@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
 )
 
 type MentoringChat struct {
@@ -13,13 +15,44 @@ type MentoringChat struct {
 	ExpiresAt time.Time `db:"expires_at"`
 	IsTrial   bool      `db:"is_trial"`
 
+	StudentLastReadMessageID *uuid.UUID `db:"student_last_read_message_id"`
+	MentorLastReadMessageID  *uuid.UUID `db:"mentor_last_read_message_id"`
+
 	LastMessage *MentoringMessage `db:"last_message"`
+
+	// UnreadCount is relative to the user the chat was fetched for
+	UnreadCount int `db:"unread_count"`
 }
 
 type MentoringMessage struct {
-	ID        uuid.UUID `db:"id"`
-	ChatID    uuid.UUID `db:"chat_id"`
-	SenderID  uuid.UUID `db:"sender_id"`
-	Message   string    `db:"message"`
-	CreatedAt time.Time `db:"created_at"`
+	ID        uuid.UUID  `db:"id"`
+	ChatID    uuid.UUID  `db:"chat_id"`
+	SenderID  uuid.UUID  `db:"sender_id"`
+	Message   string     `db:"message"`
+	CreatedAt time.Time  `db:"created_at"`
+	EditedAt  *time.Time `db:"edited_at"`
+	DeletedAt *time.Time `db:"deleted_at"`
+
+	Attachment *MentoringAttachment `db:"attachment"`
+}
+
+type MentoringAttachment struct {
+	ID          uuid.UUID  `db:"id"`
+	ChatID      uuid.UUID  `db:"chat_id"`
+	UploaderID  uuid.UUID  `db:"uploader_id"`
+	MessageID   *uuid.UUID `db:"message_id"`
+	FileName    string     `db:"file_name"`
+	ContentType string     `db:"content_type"`
+	Size        int64      `db:"size"`
+	CreatedAt   time.Time  `db:"created_at"`
+}
+
+type MentoringTranscript struct {
+	ID          uuid.UUID             `db:"id"`
+	ChatID      uuid.UUID             `db:"chat_id"`
+	RequesterID uuid.UUID             `db:"requester_id"`
+	Format      enum.TranscriptFormat `db:"format"`
+	Status      enum.TranscriptStatus `db:"status"`
+	CreatedAt   time.Time             `db:"created_at"`
+	CompletedAt *time.Time            `db:"completed_at"`
 }
@@ -0,0 +1,43 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
+)
+
+// MentorAvailability is a weekly recurring window in which the mentor can be booked.
+// Minutes are counted from midnight in the platform timezone.
+type MentorAvailability struct {
+	ID          uuid.UUID    `db:"id"`
+	MentorID    uuid.UUID    `db:"mentor_id"`
+	Weekday     time.Weekday `db:"weekday"`
+	StartMinute int          `db:"start_minute"`
+	EndMinute   int          `db:"end_minute"`
+}
+
+// MentorAvailabilityException blocks a period that would otherwise be available
+type MentorAvailabilityException struct {
+	ID       uuid.UUID `db:"id"`
+	MentorID uuid.UUID `db:"mentor_id"`
+	StartsAt time.Time `db:"starts_at"`
+	EndsAt   time.Time `db:"ends_at"`
+	Reason   *string   `db:"reason"`
+}
+
+type MentoringSession struct {
+	ID             uuid.UUID                   `db:"id"`
+	MentorID       uuid.UUID                   `db:"mentor_id"`
+	StudentID      uuid.UUID                   `db:"student_id"`
+	StartsAt       time.Time                   `db:"starts_at"`
+	EndsAt         time.Time                   `db:"ends_at"`
+	Status         enum.MentoringSessionStatus `db:"status"`
+	HoldUntil      *time.Time                  `db:"hold_until"`
+	ReminderSentAt *time.Time                  `db:"reminder_sent_at"`
+	CreatedAt      time.Time                   `db:"created_at"`
+
+	MentorName  string `db:"mentor_name"`
+	StudentName string `db:"student_name"`
+}
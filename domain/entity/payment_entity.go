@@ -9,17 +9,25 @@ import (
 )
 
 type Payment struct {
-	ID        uuid.UUID          `db:"id"`
-	UserID    uuid.UUID          `db:"user_id"`
-	Token     string             `db:"token"`
-	Amount    int                `db:"amount"`
-	Title     string             `db:"title"`
-	Detail    *string            `db:"detail"`
-	Method    string             `db:"method"`
-	Status    enum.PaymentStatus `db:"status"`
-	ExpiredAt time.Time          `db:"expired_at"`
-	CreatedAt time.Time          `db:"created_at"`
-	UpdatedAt time.Time          `db:"updated_at"`
+	ID            uuid.UUID          `db:"id"`
+	UserID        uuid.UUID          `db:"user_id"`
+	Token         string             `db:"token"`
+	Amount        int                `db:"amount"`
+	Type          enum.PaymentType   `db:"type"`
+	ProductID     *uuid.UUID         `db:"product_id"`
+	Title         string             `db:"title"`
+	Detail        *string            `db:"detail"`
+	Method        string             `db:"method"`
+	Status        enum.PaymentStatus `db:"status"`
+	InvoiceNumber *int64             `db:"invoice_number"`
+	Discount      int                `db:"discount"`
+	Badge         *enum.StudentBadge `db:"badge"`
+	HasReceipt    bool               `db:"has_receipt"`
+	RefundReason  *string            `db:"refund_reason"`
+	PaidAt        *time.Time         `db:"paid_at"`
+	ExpiredAt     time.Time          `db:"expired_at"`
+	CreatedAt     time.Time          `db:"created_at"`
+	UpdatedAt     time.Time          `db:"updated_at"`
 }
 
 type MentorTransactionHistory struct {
@@ -35,4 +43,6 @@ type PaymentPayload struct {
 	Type      enum.PaymentType
 	StudentID uuid.UUID
 	MentorID  uuid.UUID
+	CourseID  uuid.UUID
+	SessionID uuid.UUID
 }
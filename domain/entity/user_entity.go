@@ -42,4 +42,5 @@ type Mentor struct {
 	RatingTotal    float64 `db:"rating_total"`
 	Price          int     `db:"price"`
 	Balance        int     `db:"balance"`
+	AcceptsTrial   bool    `db:"accepts_trial"`
 }
@@ -0,0 +1,10 @@
+package enum
+
+// AssetStatus tracks a file uploaded directly to the storage through a signed URL
+type AssetStatus string
+
+const (
+	AssetStatusPending AssetStatus = "pending"
+	AssetStatusReady   AssetStatus = "ready"
+	AssetStatusInvalid AssetStatus = "invalid"
+)
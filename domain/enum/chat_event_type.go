@@ -0,0 +1,16 @@
+package enum
+
+type ChatEventType string
+
+const (
+	ChatEventMessage        ChatEventType = "message"
+	ChatEventMessageUpdated ChatEventType = "message_updated"
+	ChatEventMessageDeleted ChatEventType = "message_deleted"
+	ChatEventTyping         ChatEventType = "typing"
+	ChatEventRead           ChatEventType = "read"
+	ChatEventPresence       ChatEventType = "presence"
+	ChatEventExpiring       ChatEventType = "expiring"
+	ChatEventExpired        ChatEventType = "expired"
+	ChatEventError          ChatEventType = "error"
+	ChatEventAck            ChatEventType = "ack"
+)
@@ -0,0 +1,10 @@
+package enum
+
+type CourseStatus string
+
+const (
+	CourseStatusDraft     CourseStatus = "draft"
+	CourseStatusScheduled CourseStatus = "scheduled"
+	CourseStatusPublished CourseStatus = "published"
+	CourseStatusArchived  CourseStatus = "archived"
+)
@@ -0,0 +1,9 @@
+package enum
+
+type MentoringSessionStatus string
+
+const (
+	MentoringSessionStatusPending   MentoringSessionStatus = "pending"
+	MentoringSessionStatusBooked    MentoringSessionStatus = "booked"
+	MentoringSessionStatusCancelled MentoringSessionStatus = "cancelled"
+)
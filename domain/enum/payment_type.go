@@ -6,4 +6,5 @@ const (
 	PaymentTypeBoost     PaymentType = "boost"
 	PaymentTypeChallenge PaymentType = "challenge"
 	PaymentTypeGuidance  PaymentType = "guidance"
+	PaymentTypeCourse    PaymentType = "course"
 )
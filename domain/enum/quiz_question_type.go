@@ -0,0 +1,9 @@
+package enum
+
+type QuizQuestionType string
+
+const (
+	QuizQuestionTypeMultipleChoice QuizQuestionType = "multiple_choice"
+	QuizQuestionTypeMultiSelect    QuizQuestionType = "multi_select"
+	QuizQuestionTypeShortAnswer    QuizQuestionType = "short_answer"
+)
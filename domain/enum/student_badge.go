@@ -21,3 +21,16 @@ func GetBadge(points int) StudentBadge {
 		return BadgeNothing
 	}
 }
+
+func GetBadgeDiscount(badge StudentBadge) int {
+	switch badge {
+	case BadgeGold:
+		return 50
+	case BadgeSilver:
+		return 20
+	case BadgeBronze:
+		return 10
+	default:
+		return 0
+	}
+}
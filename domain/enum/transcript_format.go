@@ -0,0 +1,8 @@
+package enum
+
+type TranscriptFormat string
+
+const (
+	TranscriptFormatPDF      TranscriptFormat = "pdf"
+	TranscriptFormatMarkdown TranscriptFormat = "markdown"
+)
@@ -0,0 +1,9 @@
+package enum
+
+type TranscriptStatus string
+
+const (
+	TranscriptStatusPending TranscriptStatus = "pending"
+	TranscriptStatusReady   TranscriptStatus = "ready"
+	TranscriptStatusFailed  TranscriptStatus = "failed"
+)
@@ -53,6 +53,12 @@ func ErrInvalidFileFormat() *ResponseError {
 		"Invalid file format. Please upload a valid file.")
 }
 
+func ErrInvalidSignedURL() *ResponseError {
+	return newError(http.StatusForbidden,
+		"invalid-signed-url",
+		"The link is invalid or has expired. Please request a new one.")
+}
+
 func ErrValidation() *ResponseError {
 	return newError(http.StatusUnprocessableEntity,
 		"validation-error",
@@ -65,6 +71,18 @@ func ErrRateLimitExceeded() *ResponseError {
 		"Rate limit exceeded. Please try again later.")
 }
 
+func ErrIdempotencyKeyInProgress() *ResponseError {
+	return newError(http.StatusConflict,
+		"idempotency-key-in-progress",
+		"A request with the same Idempotency-Key is still being processed.")
+}
+
+func ErrIdempotencyKeyReused() *ResponseError {
+	return newError(http.StatusUnprocessableEntity,
+		"idempotency-key-reused",
+		"Idempotency-Key has already been used for a different request.")
+}
+
 // Auth
 func ErrCredentialsNotMatch() *ResponseError {
 	return newError(http.StatusUnauthorized,
@@ -134,6 +152,30 @@ func ErrStudentAlreadySubmittedFeedback() *ResponseError {
 		"You have already submitted feedback for this course.")
 }
 
+func ErrNotEnrolled() *ResponseError {
+	return newError(http.StatusUnprocessableEntity,
+		"not-enrolled",
+		"You must enroll in this course first.")
+}
+
+func ErrQuizAttemptLimitReached() *ResponseError {
+	return newError(http.StatusForbidden,
+		"quiz-attempt-limit-reached",
+		"You have used all attempts for this quiz.")
+}
+
+func ErrQuizHasAttempts() *ResponseError {
+	return newError(http.StatusConflict,
+		"quiz-has-attempts",
+		"The questions of a quiz that students have attempted cannot be replaced.")
+}
+
+func ErrSectionOrderTaken() *ResponseError {
+	return newError(http.StatusConflict,
+		"section-order-taken",
+		"Another section of this course already has this order.")
+}
+
 // Challenges
 func ErrStudentAlreadySubmittedChallenge() *ResponseError {
 	return newError(http.StatusConflict,
@@ -166,9 +208,51 @@ func ErrTrialUsed() *ResponseError {
 		"Trial chat has been used. Please purchase Skill Guidance.")
 }
 
+func ErrTrialNotOffered() *ResponseError {
+	return newError(http.StatusUnprocessableEntity,
+		"trial-not-offered",
+		"This mentor does not offer trial chats. Please purchase Skill Guidance.")
+}
+
+func ErrTrialMessageLimitReached() *ResponseError {
+	return newError(http.StatusForbidden,
+		"trial-message-limit-reached",
+		"You have reached the message limit of this trial chat. Please purchase Skill Guidance.")
+}
+
+func ErrMessageEditWindowPassed() *ResponseError {
+	return newError(http.StatusForbidden,
+		"message-edit-window-passed",
+		"Message can only be edited within 15 minutes after it was sent.")
+}
+
+func ErrSessionSlotUnavailable() *ResponseError {
+	return newError(http.StatusConflict,
+		"session-slot-unavailable",
+		"The selected session time is no longer available. Please choose another slot.")
+}
+
 // Payment
 func ErrOKIgnore() *ResponseError {
 	return newError(http.StatusOK,
 		"ok-ignore",
 		"OK to ignore this error.") // For midtrans test notification
 }
+
+func ErrReceiptNotAvailable() *ResponseError {
+	return newError(http.StatusUnprocessableEntity,
+		"receipt-not-available",
+		"Receipt is only available for successful payments.")
+}
+
+func ErrCourseNotForSale() *ResponseError {
+	return newError(http.StatusUnprocessableEntity,
+		"course-not-for-sale",
+		"This course can only be accessed through Skill Boost subscription.")
+}
+
+func ErrCourseAlreadyPurchased() *ResponseError {
+	return newError(http.StatusConflict,
+		"course-already-purchased",
+		"You have already purchased this course.")
+}
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/contract"
+	"github.com/nathakusuma/elevateu-backend/domain/ctxkey"
+	"github.com/nathakusuma/elevateu-backend/domain/dto"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
+	"github.com/nathakusuma/elevateu-backend/domain/errorpkg"
+	"github.com/nathakusuma/elevateu-backend/internal/middleware"
+	"github.com/nathakusuma/elevateu-backend/pkg/log"
+	"github.com/nathakusuma/elevateu-backend/pkg/validator"
+)
+
+type courseArticleHandler struct {
+	val validator.IValidator
+	svc contract.ICourseArticleService
+}
+
+func InitCourseArticleHandler(
+	router fiber.Router,
+	midw *middleware.Middleware,
+	validator validator.IValidator,
+	articleSvc contract.ICourseArticleService,
+) {
+	handler := courseArticleHandler{
+		svc: articleSvc,
+		val: validator,
+	}
+
+	coursesGroup := router.Group("/courses")
+	coursesGroup.Use(midw.RequireAuthenticated)
+
+	coursesGroup.Get("/contents/articles/:id", handler.getArticle)
+	coursesGroup.Patch("/contents/articles/:id",
+		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.updateArticle)
+	coursesGroup.Delete("/contents/articles/:id",
+		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.deleteArticle)
+	coursesGroup.Get("/contents/articles/:id/revisions",
+		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.getRevisions)
+	coursesGroup.Get("/contents/articles/:id/revisions/:revision",
+		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.getRevision)
+
+	coursesGroup.Post("/:courseId/contents/articles",
+		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.createArticle)
+}
+
+func (h *courseArticleHandler) createArticle(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	courseID, err := uuid.Parse(ctx.Params("courseId"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid course ID")
+	}
+
+	var req dto.CreateCourseArticleRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return errorpkg.ErrFailParseRequest()
+	}
+
+	if err := h.val.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.CreateArticle(ctx.Context(), userID, courseID, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(map[string]interface{}{
+		"article": resp,
+	})
+}
+
+func (h *courseArticleHandler) getArticle(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid article ID")
+	}
+
+	resp, err := h.svc.GetArticle(ctx.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(map[string]interface{}{
+		"article": resp,
+	})
+}
+
+func (h *courseArticleHandler) updateArticle(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid article ID")
+	}
+
+	var req dto.UpdateCourseArticleRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return errorpkg.ErrFailParseRequest()
+	}
+
+	if err := h.val.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	if err := h.svc.UpdateArticle(ctx.Context(), userID, id, req); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *courseArticleHandler) deleteArticle(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid article ID")
+	}
+
+	if err := h.svc.DeleteArticle(ctx.Context(), id); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *courseArticleHandler) getRevisions(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid article ID")
+	}
+
+	resp, err := h.svc.GetRevisions(ctx.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(map[string]interface{}{
+		"revisions": resp,
+	})
+}
+
+func (h *courseArticleHandler) getRevision(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid article ID")
+	}
+
+	revision, err := strconv.Atoi(ctx.Params("revision"))
+	if err != nil || revision < 1 {
+		return errorpkg.ErrValidation().WithDetail("invalid revision")
+	}
+
+	resp, err := h.svc.GetRevision(ctx.Context(), id, revision)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(map[string]interface{}{
+		"revision": resp,
+	})
+}
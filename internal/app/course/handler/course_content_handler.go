@@ -37,6 +37,12 @@ func InitCourseContentHandler(
 		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.deleteVideo)
 	coursesGroup.Get("/contents/videos/:id/upload-url",
 		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.getVideoUploadURLs)
+	coursesGroup.Post("/contents/videos/:id/finalize-upload",
+		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.finalizeVideoUpload)
+	coursesGroup.Put("/contents/videos/:id/subtitles/:language",
+		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.uploadVideoSubtitle)
+	coursesGroup.Delete("/contents/videos/:id/subtitles/:language",
+		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.deleteVideoSubtitle)
 
 	coursesGroup.Patch("/contents/materials/:id",
 		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.updateMaterial)
@@ -44,8 +50,19 @@ func InitCourseContentHandler(
 		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.deleteMaterial)
 	coursesGroup.Get("/contents/materials/:id/upload-url",
 		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.getMaterialUploadURL)
+	coursesGroup.Post("/contents/materials/:id/finalize-upload",
+		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.finalizeMaterialUpload)
+
+	coursesGroup.Patch("/contents/sections/:id",
+		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.updateSection)
+	coursesGroup.Delete("/contents/sections/:id",
+		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.deleteSection)
 
 	coursesGroup.Get("/:courseId/contents", handler.getCourseContents)
+	coursesGroup.Post("/:courseId/contents/sections",
+		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.createSection)
+	coursesGroup.Put("/:courseId/contents/order",
+		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.reorderContents)
 	coursesGroup.Post("/:courseId/contents/videos",
 		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.createVideo)
 	coursesGroup.Post("/:courseId/contents/materials",
@@ -63,9 +80,7 @@ func (h *courseContentHandler) getCourseContents(ctx *fiber.Ctx) error {
 		return err
 	}
 
-	return ctx.Status(fiber.StatusOK).JSON(map[string]interface{}{
-		"course_contents": contents,
-	})
+	return ctx.Status(fiber.StatusOK).JSON(contents)
 }
 
 func (h *courseContentHandler) createVideo(ctx *fiber.Ctx) error {
@@ -143,6 +158,67 @@ func (h *courseContentHandler) getVideoUploadURLs(ctx *fiber.Ctx) error {
 	})
 }
 
+func (h *courseContentHandler) finalizeVideoUpload(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid video ID")
+	}
+
+	videoStatus, thumbnailStatus, err := h.svc.FinalizeVideoUpload(ctx.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(map[string]interface{}{
+		"video_status":     videoStatus,
+		"thumbnail_status": thumbnailStatus,
+	})
+}
+
+func (h *courseContentHandler) uploadVideoSubtitle(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid video ID")
+	}
+
+	var req dto.UploadCourseVideoSubtitleRequest
+	if err = ctx.BodyParser(&req); err != nil {
+		return errorpkg.ErrFailParseRequest()
+	}
+
+	req.Language = ctx.Params("language")
+	req.Subtitle, err = ctx.FormFile("subtitle")
+	if err != nil {
+		return errorpkg.ErrFailParseRequest().WithDetail("Fail to parse subtitle")
+	}
+
+	if err = h.val.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.UploadVideoSubtitle(ctx.Context(), id, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(map[string]interface{}{
+		"subtitle": resp,
+	})
+}
+
+func (h *courseContentHandler) deleteVideoSubtitle(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid video ID")
+	}
+
+	if err = h.svc.DeleteVideoSubtitle(ctx.Context(), id, ctx.Params("language")); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
 func (h *courseContentHandler) createMaterial(ctx *fiber.Ctx) error {
 	courseID, err := uuid.Parse(ctx.Params("courseId"))
 	if err != nil {
@@ -216,3 +292,101 @@ func (h *courseContentHandler) getMaterialUploadURL(ctx *fiber.Ctx) error {
 		"material_upload_url": url,
 	})
 }
+
+func (h *courseContentHandler) finalizeMaterialUpload(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid material ID")
+	}
+
+	status, err := h.svc.FinalizeMaterialUpload(ctx.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(map[string]interface{}{
+		"material_status": status,
+	})
+}
+
+func (h *courseContentHandler) createSection(ctx *fiber.Ctx) error {
+	courseID, err := uuid.Parse(ctx.Params("courseId"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid course ID")
+	}
+
+	var req dto.CreateCourseSectionRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return errorpkg.ErrFailParseRequest()
+	}
+
+	if err := h.val.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.CreateSection(ctx.Context(), courseID, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(map[string]interface{}{
+		"section": resp,
+	})
+}
+
+func (h *courseContentHandler) updateSection(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid section ID")
+	}
+
+	var req dto.UpdateCourseSectionRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return errorpkg.ErrFailParseRequest()
+	}
+
+	if err := h.val.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	if err := h.svc.UpdateSection(ctx.Context(), id, req); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *courseContentHandler) deleteSection(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid section ID")
+	}
+
+	if err := h.svc.DeleteSection(ctx.Context(), id); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *courseContentHandler) reorderContents(ctx *fiber.Ctx) error {
+	courseID, err := uuid.Parse(ctx.Params("courseId"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid course ID")
+	}
+
+	var req dto.ReorderCourseContentsRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return errorpkg.ErrFailParseRequest()
+	}
+
+	if err := h.val.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	if err := h.svc.ReorderContents(ctx.Context(), courseID, req); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
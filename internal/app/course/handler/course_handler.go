@@ -42,12 +42,18 @@ func InitCourseHandler(
 	courseGroup.Patch("/:id",
 		midw.RequireOneOfRoles(enum.UserRoleAdmin),
 		handler.updateCourse)
+	courseGroup.Patch("/:id/status",
+		midw.RequireOneOfRoles(enum.UserRoleAdmin),
+		handler.updateCourseStatus)
 	courseGroup.Delete("/:id",
 		midw.RequireOneOfRoles(enum.UserRoleAdmin),
 		handler.deleteCourse)
 	courseGroup.Get("/:id/preview-video-upload-url",
 		midw.RequireOneOfRoles(enum.UserRoleAdmin),
 		handler.GetPreviewVideoUploadURL)
+	courseGroup.Post("/:id/preview-video-finalize-upload",
+		midw.RequireOneOfRoles(enum.UserRoleAdmin),
+		handler.finalizePreviewVideoUpload)
 	courseGroup.Post("/:id/enrollments",
 		midw.RequireOneOfRoles(enum.UserRoleStudent),
 		handler.createEnrollment)
@@ -161,6 +167,28 @@ func (c *courseHandler) updateCourse(ctx *fiber.Ctx) error {
 	return ctx.SendStatus(fiber.StatusNoContent)
 }
 
+func (c *courseHandler) updateCourseStatus(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid course ID")
+	}
+
+	var req dto.UpdateCourseStatusRequest
+	if err2 := ctx.BodyParser(&req); err2 != nil {
+		return errorpkg.ErrFailParseRequest()
+	}
+
+	if err2 := c.val.ValidateStruct(req); err2 != nil {
+		return err2
+	}
+
+	if err2 := c.svc.UpdateCourseStatus(ctx.Context(), id, req); err2 != nil {
+		return err2
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
 func (c *courseHandler) deleteCourse(ctx *fiber.Ctx) error {
 	id, err := uuid.Parse(ctx.Params("id"))
 	if err != nil {
@@ -190,6 +218,22 @@ func (c *courseHandler) GetPreviewVideoUploadURL(ctx *fiber.Ctx) error {
 	})
 }
 
+func (c *courseHandler) finalizePreviewVideoUpload(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid course ID")
+	}
+
+	status, err := c.svc.FinalizePreviewVideoUpload(ctx.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(map[string]interface{}{
+		"preview_video_status": status,
+	})
+}
+
 func (c *courseHandler) createEnrollment(ctx *fiber.Ctx) error {
 	courseID, err := uuid.Parse(ctx.Params("id"))
 	if err != nil {
@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/contract"
+	"github.com/nathakusuma/elevateu-backend/domain/ctxkey"
+	"github.com/nathakusuma/elevateu-backend/domain/dto"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
+	"github.com/nathakusuma/elevateu-backend/domain/errorpkg"
+	"github.com/nathakusuma/elevateu-backend/internal/middleware"
+	"github.com/nathakusuma/elevateu-backend/pkg/log"
+	"github.com/nathakusuma/elevateu-backend/pkg/validator"
+)
+
+type courseNoteHandler struct {
+	val validator.IValidator
+	svc contract.ICourseNoteService
+}
+
+func InitCourseNoteHandler(
+	router fiber.Router,
+	midw *middleware.Middleware,
+	validator validator.IValidator,
+	noteSvc contract.ICourseNoteService,
+) {
+	handler := courseNoteHandler{
+		svc: noteSvc,
+		val: validator,
+	}
+
+	coursesGroup := router.Group("/courses")
+	coursesGroup.Use(midw.RequireAuthenticated)
+
+	coursesGroup.Post("/contents/videos/:id/notes",
+		midw.RequireOneOfRoles(enum.UserRoleStudent), handler.createVideoNote)
+	coursesGroup.Put("/contents/materials/:id/bookmark",
+		midw.RequireOneOfRoles(enum.UserRoleStudent), handler.bookmarkMaterial)
+
+	coursesGroup.Patch("/notes/:id",
+		midw.RequireOneOfRoles(enum.UserRoleStudent), handler.updateNote)
+	coursesGroup.Delete("/notes/:id",
+		midw.RequireOneOfRoles(enum.UserRoleStudent), handler.deleteNote)
+
+	coursesGroup.Get("/:courseId/notes",
+		midw.RequireOneOfRoles(enum.UserRoleStudent), handler.getCourseNotes)
+	coursesGroup.Get("/:courseId/notes/export",
+		midw.RequireOneOfRoles(enum.UserRoleStudent), handler.exportCourseNotes)
+}
+
+func (h *courseNoteHandler) createVideoNote(ctx *fiber.Ctx) error {
+	videoID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("Invalid video ID")
+	}
+
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	var req dto.CreateCourseVideoNoteRequest
+	if err = ctx.BodyParser(&req); err != nil {
+		return errorpkg.ErrFailParseRequest()
+	}
+
+	if err = h.val.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.CreateVideoNote(ctx.Context(), userID, videoID, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(map[string]interface{}{
+		"note": resp,
+	})
+}
+
+func (h *courseNoteHandler) bookmarkMaterial(ctx *fiber.Ctx) error {
+	materialID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("Invalid material ID")
+	}
+
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	var req dto.BookmarkCourseMaterialRequest
+	if len(ctx.Body()) > 0 {
+		if err = ctx.BodyParser(&req); err != nil {
+			return errorpkg.ErrFailParseRequest()
+		}
+	}
+
+	if err = h.val.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.BookmarkMaterial(ctx.Context(), userID, materialID, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(map[string]interface{}{
+		"note": resp,
+	})
+}
+
+func (h *courseNoteHandler) updateNote(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("Invalid note ID")
+	}
+
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	var req dto.UpdateCourseNoteRequest
+	if err = ctx.BodyParser(&req); err != nil {
+		return errorpkg.ErrFailParseRequest()
+	}
+
+	if err = h.val.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	if err = h.svc.UpdateNote(ctx.Context(), userID, id, req); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *courseNoteHandler) deleteNote(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("Invalid note ID")
+	}
+
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	if err = h.svc.DeleteNote(ctx.Context(), userID, id); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *courseNoteHandler) getCourseNotes(ctx *fiber.Ctx) error {
+	courseID, err := uuid.Parse(ctx.Params("courseId"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("Invalid course ID")
+	}
+
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	notes, err := h.svc.GetCourseNotes(ctx.Context(), userID, courseID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(map[string]interface{}{
+		"notes": notes,
+	})
+}
+
+func (h *courseNoteHandler) exportCourseNotes(ctx *fiber.Ctx) error {
+	courseID, err := uuid.Parse(ctx.Params("courseId"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("Invalid course ID")
+	}
+
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	markdown, err := h.svc.ExportCourseNotes(ctx.Context(), userID, courseID)
+	if err != nil {
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentType, "text/markdown; charset=utf-8")
+	ctx.Attachment(fmt.Sprintf("elevateu-notes-%s.md", courseID))
+	return ctx.Send(markdown)
+}
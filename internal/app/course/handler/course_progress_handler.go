@@ -30,12 +30,17 @@ func InitCourseProgressHandler(
 		val: validator,
 	}
 
+	// The roles are checked per route, as a group middleware would also run for every other
+	// /courses/contents route registered after this handler
 	courseProgressGroup := router.Group("/courses/contents")
 	courseProgressGroup.Use(midw.RequireAuthenticated)
-	courseProgressGroup.Use(midw.RequireOneOfRoles(enum.UserRoleStudent))
 
-	courseProgressGroup.Post("/videos/:videoId/progresses", handler.updateVideoProgress)
-	courseProgressGroup.Post("/materials/:materialId/progresses", handler.completeMaterial)
+	courseProgressGroup.Post("/videos/:videoId/progresses",
+		midw.RequireOneOfRoles(enum.UserRoleStudent), handler.updateVideoProgress)
+	courseProgressGroup.Post("/materials/:materialId/progresses",
+		midw.RequireOneOfRoles(enum.UserRoleStudent), handler.completeMaterial)
+	courseProgressGroup.Post("/articles/:articleId/progresses",
+		midw.RequireOneOfRoles(enum.UserRoleStudent), handler.completeArticle)
 }
 
 func (h *courseProgressHandler) updateVideoProgress(ctx *fiber.Ctx) error {
@@ -84,3 +89,22 @@ func (h *courseProgressHandler) completeMaterial(ctx *fiber.Ctx) error {
 
 	return ctx.SendStatus(fiber.StatusNoContent)
 }
+
+func (h *courseProgressHandler) completeArticle(ctx *fiber.Ctx) error {
+	articleID, err := uuid.Parse(ctx.Params("articleId"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("Invalid article ID")
+	}
+
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	if err = h.svc.UpdateArticleProgress(ctx.Context(), userID, articleID); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
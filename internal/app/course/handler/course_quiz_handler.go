@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/contract"
+	"github.com/nathakusuma/elevateu-backend/domain/ctxkey"
+	"github.com/nathakusuma/elevateu-backend/domain/dto"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
+	"github.com/nathakusuma/elevateu-backend/domain/errorpkg"
+	"github.com/nathakusuma/elevateu-backend/internal/middleware"
+	"github.com/nathakusuma/elevateu-backend/pkg/log"
+	"github.com/nathakusuma/elevateu-backend/pkg/validator"
+)
+
+type courseQuizHandler struct {
+	val validator.IValidator
+	svc contract.ICourseQuizService
+}
+
+func InitCourseQuizHandler(
+	router fiber.Router,
+	midw *middleware.Middleware,
+	validator validator.IValidator,
+	quizSvc contract.ICourseQuizService,
+) {
+	handler := courseQuizHandler{
+		svc: quizSvc,
+		val: validator,
+	}
+
+	coursesGroup := router.Group("/courses")
+	coursesGroup.Use(midw.RequireAuthenticated)
+
+	coursesGroup.Get("/contents/quizzes/:id", handler.getQuiz)
+	coursesGroup.Patch("/contents/quizzes/:id",
+		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.updateQuiz)
+	coursesGroup.Delete("/contents/quizzes/:id",
+		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.deleteQuiz)
+	coursesGroup.Put("/contents/quizzes/:id/questions",
+		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.replaceQuestions)
+	coursesGroup.Post("/contents/quizzes/:id/attempts",
+		midw.RequireOneOfRoles(enum.UserRoleStudent), handler.submitAttempt)
+	coursesGroup.Get("/contents/quizzes/:id/attempts",
+		midw.RequireOneOfRoles(enum.UserRoleStudent), handler.getAttempts)
+
+	coursesGroup.Post("/:courseId/contents/quizzes",
+		midw.RequireOneOfRoles(enum.UserRoleAdmin), handler.createQuiz)
+}
+
+func (h *courseQuizHandler) createQuiz(ctx *fiber.Ctx) error {
+	courseID, err := uuid.Parse(ctx.Params("courseId"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid course ID")
+	}
+
+	var req dto.CreateCourseQuizRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return errorpkg.ErrFailParseRequest()
+	}
+
+	if err := h.val.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.CreateQuiz(ctx.Context(), courseID, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(map[string]interface{}{
+		"quiz": resp,
+	})
+}
+
+func (h *courseQuizHandler) getQuiz(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid quiz ID")
+	}
+
+	resp, err := h.svc.GetQuiz(ctx.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(map[string]interface{}{
+		"quiz": resp,
+	})
+}
+
+func (h *courseQuizHandler) updateQuiz(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid quiz ID")
+	}
+
+	var req dto.UpdateCourseQuizRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return errorpkg.ErrFailParseRequest()
+	}
+
+	if err := h.val.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	if err := h.svc.UpdateQuiz(ctx.Context(), id, req); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *courseQuizHandler) deleteQuiz(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid quiz ID")
+	}
+
+	if err := h.svc.DeleteQuiz(ctx.Context(), id); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *courseQuizHandler) replaceQuestions(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid quiz ID")
+	}
+
+	var req dto.ReplaceCourseQuizQuestionsRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return errorpkg.ErrFailParseRequest()
+	}
+
+	if err := h.val.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	if err := h.svc.ReplaceQuestions(ctx.Context(), id, req); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *courseQuizHandler) submitAttempt(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid quiz ID")
+	}
+
+	var req dto.SubmitCourseQuizRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return errorpkg.ErrFailParseRequest()
+	}
+
+	if err := h.val.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.SubmitAttempt(ctx.Context(), userID, id, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(map[string]interface{}{
+		"attempt": resp,
+	})
+}
+
+func (h *courseQuizHandler) getAttempts(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("invalid quiz ID")
+	}
+
+	resp, err := h.svc.GetAttempts(ctx.Context(), userID, id)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(resp)
+}
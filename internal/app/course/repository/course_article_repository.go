@@ -0,0 +1,276 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/nathakusuma/elevateu-backend/domain/contract"
+	"github.com/nathakusuma/elevateu-backend/domain/dto"
+	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/pkg/sqlutil"
+)
+
+type courseArticleRepository struct {
+	db *sqlx.DB
+}
+
+func NewCourseArticleRepository(conn *sqlx.DB) contract.ICourseArticleRepository {
+	return &courseArticleRepository{
+		db: conn,
+	}
+}
+
+func (r *courseArticleRepository) CreateArticle(ctx context.Context, article *entity.CourseArticle,
+	editorID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// New contents go after the last content of the course
+	if article.Order, err = nextContentOrder(ctx, tx, article.CourseID); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO course_articles (
+			id, course_id, section_id, title, subtitle, body, body_html, is_free, "order"
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8, $9
+		)
+		RETURNING revision, created_at, updated_at
+	`
+
+	err = tx.QueryRowxContext(ctx, query, article.ID, article.CourseID, article.SectionID, article.Title,
+		article.Subtitle, article.Body, article.BodyHTML, article.IsFree, article.Order).
+		Scan(&article.Revision, &article.CreatedAt, &article.UpdatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "course_articles_course_id_fkey" {
+			return fmt.Errorf("course not found: %w", err)
+		}
+
+		return fmt.Errorf("failed to create article: %w", err)
+	}
+
+	if err = r.insertRevision(ctx, tx, article.ID, editorID); err != nil {
+		return err
+	}
+
+	// After creating the article, update the course's content_count
+	updateQuery := `
+		UPDATE courses
+		SET content_count = content_count + 1,
+		    updated_at = NOW()
+		WHERE id = $1
+	`
+	_, err = tx.ExecContext(ctx, updateQuery, article.CourseID)
+	if err != nil {
+		return fmt.Errorf("failed to update course stats: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// insertRevision snapshots the current title and body of the article as its current revision
+func (r *courseArticleRepository) insertRevision(ctx context.Context, tx *sqlx.Tx, articleID,
+	editorID uuid.UUID) error {
+	query := `
+		INSERT INTO course_article_revisions (article_id, revision, title, body, editor_id)
+		SELECT id, revision, title, body, $2
+		FROM course_articles
+		WHERE id = $1
+	`
+	if _, err := tx.ExecContext(ctx, query, articleID, editorID); err != nil {
+		return fmt.Errorf("failed to create article revision: %w", err)
+	}
+
+	return nil
+}
+
+func (r *courseArticleRepository) GetArticleByID(ctx context.Context, id uuid.UUID) (*entity.CourseArticle, error) {
+	var article entity.CourseArticle
+	query := `
+		SELECT id, course_id, section_id, title, subtitle, body, body_html, revision, is_free, "order",
+		       created_at, updated_at
+		FROM course_articles
+		WHERE id = $1
+	`
+	err := r.db.GetContext(ctx, &article, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("article not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get article: %w", err)
+	}
+
+	return &article, nil
+}
+
+// GetArticlesByCourseID returns the articles of the course sorted by order, without their body
+func (r *courseArticleRepository) GetArticlesByCourseID(ctx context.Context,
+	courseID uuid.UUID) ([]*entity.CourseArticle, error) {
+	query := `
+		SELECT id, course_id, section_id, title, subtitle, revision, is_free, "order", created_at, updated_at
+		FROM course_articles
+		WHERE course_id = $1
+		ORDER BY "order"
+	`
+
+	var articles []*entity.CourseArticle
+	if err := r.db.SelectContext(ctx, &articles, query, courseID); err != nil {
+		return nil, fmt.Errorf("failed to get course articles: %w", err)
+	}
+
+	return articles, nil
+}
+
+// UpdateArticle applies the updates. When the title or the body changes, the article moves to the next revision
+// and the result is recorded in its revision history.
+func (r *courseArticleRepository) UpdateArticle(ctx context.Context, id uuid.UUID, updates dto.CourseArticleUpdate,
+	editorID uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var revision int
+	err = tx.GetContext(ctx, &revision, `SELECT revision FROM course_articles WHERE id = $1 FOR UPDATE`, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("article not found: %w", err)
+		}
+		return fmt.Errorf("failed to get article: %w", err)
+	}
+
+	isRevised := updates.Title != nil || updates.Body != nil
+	if isRevised {
+		revision++
+		updates.Revision = &revision
+	}
+
+	builder := sqlutil.NewSQLUpdateBuilder("course_articles").
+		WithUpdatedAt().
+		Where("id = ?", id)
+
+	query, args, err := builder.BuildFromStruct(updates)
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	// No fields to update (query is empty)
+	if query == "" {
+		return nil
+	}
+
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to update article: %w", err)
+	}
+
+	if isRevised {
+		if err = r.insertRevision(ctx, tx, id, editorID); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *courseArticleRepository) DeleteArticle(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var courseID uuid.UUID
+	err = tx.GetContext(ctx, &courseID, `DELETE FROM course_articles WHERE id = $1 RETURNING course_id`, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("article not found: %w", err)
+		}
+		return fmt.Errorf("failed to delete article: %w", err)
+	}
+
+	// Update the course's content_count
+	updateQuery := `
+		UPDATE courses
+		SET content_count = content_count - 1,
+		    updated_at = NOW()
+		WHERE id = $1
+	`
+	_, err = tx.ExecContext(ctx, updateQuery, courseID)
+	if err != nil {
+		return fmt.Errorf("failed to update course stats: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetRevisions returns the revision history of the article, newest first, without the bodies
+func (r *courseArticleRepository) GetRevisions(ctx context.Context,
+	articleID uuid.UUID) ([]*entity.CourseArticleRevision, error) {
+	var exists bool
+	err := r.db.GetContext(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM course_articles WHERE id = $1)`, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check article: %w", err)
+	}
+
+	if !exists {
+		return nil, errors.New("article not found")
+	}
+
+	query := `
+		SELECT article_id, revision, title, editor_id, created_at
+		FROM course_article_revisions
+		WHERE article_id = $1
+		ORDER BY revision DESC
+	`
+
+	var revisions []*entity.CourseArticleRevision
+	if err = r.db.SelectContext(ctx, &revisions, query, articleID); err != nil {
+		return nil, fmt.Errorf("failed to get article revisions: %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (r *courseArticleRepository) GetRevision(ctx context.Context, articleID uuid.UUID,
+	revision int) (*entity.CourseArticleRevision, error) {
+	query := `
+		SELECT article_id, revision, title, body, editor_id, created_at
+		FROM course_article_revisions
+		WHERE article_id = $1 AND revision = $2
+	`
+
+	var articleRevision entity.CourseArticleRevision
+	err := r.db.GetContext(ctx, &articleRevision, query, articleID, revision)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("revision not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get article revision: %w", err)
+	}
+
+	return &articleRevision, nil
+}
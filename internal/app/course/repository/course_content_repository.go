@@ -33,11 +33,16 @@ func (r *courseContentRepository) CreateVideo(ctx context.Context, video *entity
 	}
 	defer tx.Rollback()
 
+	// New contents go after the last content of the course
+	if video.Order, err = nextContentOrder(ctx, tx, video.CourseID); err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO course_videos (
-			id, course_id, title, description, duration, is_free, "order"
+			id, course_id, section_id, title, description, duration, is_free, "order"
 		) VALUES (
-			:id, :course_id, :title, :description, :duration, :is_free, :order
+			:id, :course_id, :section_id, :title, :description, :duration, :is_free, :order
 		)
 	`
 
@@ -179,7 +184,8 @@ func (r *courseContentRepository) DeleteVideo(ctx context.Context, id uuid.UUID)
 func (r *courseContentRepository) GetVideoByID(ctx context.Context, id uuid.UUID) (*entity.CourseVideo, error) {
 	var video entity.CourseVideo
 	query := `
-		SELECT id, course_id, title, description, duration, is_free, "order", created_at, updated_at
+		SELECT id, course_id, section_id, title, description, duration, video_status, thumbnail_status, is_free,
+		       "order", created_at, updated_at
 		FROM course_videos
 		WHERE id = $1
 	`
@@ -194,6 +200,70 @@ func (r *courseContentRepository) GetVideoByID(ctx context.Context, id uuid.UUID
 	return &video, nil
 }
 
+// UpsertVideoSubtitle creates the subtitle track, or replaces it if the video already has one in the language
+func (r *courseContentRepository) UpsertVideoSubtitle(ctx context.Context,
+	subtitle *entity.CourseVideoSubtitle) error {
+	query := `
+		INSERT INTO course_video_subtitles (video_id, language, label, transcript)
+		VALUES (:video_id, :language, :label, :transcript)
+		ON CONFLICT (video_id, language) DO UPDATE
+		SET label = EXCLUDED.label,
+		    transcript = EXCLUDED.transcript,
+		    updated_at = NOW()
+	`
+
+	_, err := r.db.NamedExecContext(ctx, query, subtitle)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "course_video_subtitles_video_id_fkey" {
+			return fmt.Errorf("video not found: %w", err)
+		}
+
+		return fmt.Errorf("failed to upsert video subtitle: %w", err)
+	}
+
+	return nil
+}
+
+func (r *courseContentRepository) DeleteVideoSubtitle(ctx context.Context, videoID uuid.UUID,
+	language string) error {
+	query := `DELETE FROM course_video_subtitles WHERE video_id = $1 AND language = $2`
+
+	result, err := r.db.ExecContext(ctx, query, videoID, language)
+	if err != nil {
+		return fmt.Errorf("failed to delete video subtitle: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("subtitle not found")
+	}
+
+	return nil
+}
+
+// GetVideoSubtitles returns the subtitle tracks of the video without their transcripts
+func (r *courseContentRepository) GetVideoSubtitles(ctx context.Context,
+	videoID uuid.UUID) ([]*entity.CourseVideoSubtitle, error) {
+	query := `
+		SELECT video_id, language, label, created_at, updated_at
+		FROM course_video_subtitles
+		WHERE video_id = $1
+		ORDER BY language
+	`
+
+	var subtitles []*entity.CourseVideoSubtitle
+	if err := r.db.SelectContext(ctx, &subtitles, query, videoID); err != nil {
+		return nil, fmt.Errorf("failed to get video subtitles: %w", err)
+	}
+
+	return subtitles, nil
+}
+
 func (r *courseContentRepository) CreateMaterial(ctx context.Context, material *entity.CourseMaterial) error {
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
@@ -201,11 +271,16 @@ func (r *courseContentRepository) CreateMaterial(ctx context.Context, material *
 	}
 	defer tx.Rollback()
 
+	// New contents go after the last content of the course
+	if material.Order, err = nextContentOrder(ctx, tx, material.CourseID); err != nil {
+		return err
+	}
+
 	query := `
 		INSERT INTO course_materials (
-			id, course_id, title, subtitle, is_free, "order"
+			id, course_id, section_id, title, subtitle, is_free, "order"
 		) VALUES (
-			:id, :course_id, :title, :subtitle, :is_free, :order
+			:id, :course_id, :section_id, :title, :subtitle, :is_free, :order
 		)
 	`
 
@@ -317,7 +392,8 @@ func (r *courseContentRepository) DeleteMaterial(ctx context.Context, id uuid.UU
 func (r *courseContentRepository) GetMaterialByID(ctx context.Context, id uuid.UUID) (*entity.CourseMaterial, error) {
 	var material entity.CourseMaterial
 	query := `
-		SELECT id, course_id, title, subtitle, is_free, "order", created_at, updated_at
+		SELECT id, course_id, section_id, title, subtitle, material_status, is_free, "order", created_at,
+		       updated_at
 		FROM course_materials
 		WHERE id = $1
 	`
@@ -332,23 +408,138 @@ func (r *courseContentRepository) GetMaterialByID(ctx context.Context, id uuid.U
 	return &material, nil
 }
 
+func (r *courseContentRepository) CreateSection(ctx context.Context, section *entity.CourseSection) error {
+	query := `
+		INSERT INTO course_sections (
+			id, course_id, title, description, "order"
+		) VALUES (
+			:id, :course_id, :title, :description, :order
+		)
+	`
+
+	_, err := r.db.NamedExecContext(ctx, query, section)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.ConstraintName {
+			case "course_sections_course_id_fkey":
+				return fmt.Errorf("course not found: %w", err)
+			case "course_sections_course_id_order_key":
+				return fmt.Errorf("section order taken: %w", err)
+			}
+		}
+
+		return fmt.Errorf("failed to create section: %w", err)
+	}
+
+	return nil
+}
+
+func (r *courseContentRepository) UpdateSection(ctx context.Context, id uuid.UUID,
+	updates dto.CourseSectionUpdate) error {
+	builder := sqlutil.NewSQLUpdateBuilder("course_sections").
+		WithUpdatedAt().
+		Where("id = ?", id)
+
+	query, args, err := builder.BuildFromStruct(updates)
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	// No fields to update (query is empty)
+	if query == "" {
+		return nil
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "course_sections_course_id_order_key" {
+			return fmt.Errorf("section order taken: %w", err)
+		}
+		return fmt.Errorf("failed to update section: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("section not found")
+	}
+
+	return nil
+}
+
+// DeleteSection deletes the section. Its contents are kept and become contents without a section.
+func (r *courseContentRepository) DeleteSection(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM course_sections WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete section: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("section not found")
+	}
+
+	return nil
+}
+
+func (r *courseContentRepository) GetSectionByID(ctx context.Context, id uuid.UUID) (*entity.CourseSection, error) {
+	var section entity.CourseSection
+	query := `
+		SELECT id, course_id, title, description, "order", created_at, updated_at
+		FROM course_sections
+		WHERE id = $1
+	`
+	err := r.db.GetContext(ctx, &section, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("section not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get section: %w", err)
+	}
+
+	return &section, nil
+}
+
 func (r *courseContentRepository) GetCourseContents(ctx context.Context,
-	courseID uuid.UUID) ([]*entity.CourseVideo, []*entity.CourseMaterial, error) {
+	courseID uuid.UUID) ([]*entity.CourseSection, []*entity.CourseVideo, []*entity.CourseMaterial, error) {
 	// Check if course exists
 	courseExistsQuery := `SELECT EXISTS(SELECT 1 FROM courses WHERE id = $1)`
 	var exists bool
 	err := r.db.GetContext(ctx, &exists, courseExistsQuery, courseID)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	if !exists {
-		return nil, nil, errors.New("course not found")
+		return nil, nil, nil, errors.New("course not found")
+	}
+
+	// Get sections
+	sectionsQuery := `
+		SELECT id, course_id, title, description, "order", created_at, updated_at
+		FROM course_sections
+		WHERE course_id = $1
+		ORDER BY "order"
+	`
+	var sections []*entity.CourseSection
+	err = r.db.SelectContext(ctx, &sections, sectionsQuery, courseID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get course sections: %w", err)
 	}
 
 	// Get videos
 	videosQuery := `
-		SELECT id, course_id, title, description, duration, is_free, "order", created_at, updated_at
+		SELECT id, course_id, section_id, title, description, duration, video_status, thumbnail_status, is_free,
+		       "order", created_at, updated_at
 		FROM course_videos
 		WHERE course_id = $1
 		ORDER BY "order"
@@ -356,12 +547,37 @@ func (r *courseContentRepository) GetCourseContents(ctx context.Context,
 	var videos []*entity.CourseVideo
 	err = r.db.SelectContext(ctx, &videos, videosQuery, courseID)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get course videos: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to get course videos: %w", err)
+	}
+
+	// Attach the subtitle tracks to their videos
+	subtitlesQuery := `
+		SELECT s.video_id, s.language, s.label, s.created_at, s.updated_at
+		FROM course_video_subtitles s
+		JOIN course_videos v ON v.id = s.video_id
+		WHERE v.course_id = $1
+		ORDER BY s.language
+	`
+	var subtitles []*entity.CourseVideoSubtitle
+	err = r.db.SelectContext(ctx, &subtitles, subtitlesQuery, courseID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get video subtitles: %w", err)
+	}
+
+	videoByID := make(map[uuid.UUID]*entity.CourseVideo, len(videos))
+	for _, video := range videos {
+		videoByID[video.ID] = video
+	}
+	for _, subtitle := range subtitles {
+		if video, ok := videoByID[subtitle.VideoID]; ok {
+			video.Subtitles = append(video.Subtitles, subtitle)
+		}
 	}
 
 	// Get materials
 	materialsQuery := `
-		SELECT id, course_id, title, subtitle, is_free, "order", created_at, updated_at
+		SELECT id, course_id, section_id, title, subtitle, material_status, is_free, "order", created_at,
+		       updated_at
 		FROM course_materials
 		WHERE course_id = $1
 		ORDER BY "order"
@@ -369,8 +585,105 @@ func (r *courseContentRepository) GetCourseContents(ctx context.Context,
 	var materials []*entity.CourseMaterial
 	err = r.db.SelectContext(ctx, &materials, materialsQuery, courseID)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get course materials: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to get course materials: %w", err)
+	}
+
+	return sections, videos, materials, nil
+}
+
+// nextContentOrder locks the course and returns the order after its last content.
+// The lock keeps contents created at the same time, or while reordering, from sharing an order.
+func nextContentOrder(ctx context.Context, tx *sqlx.Tx, courseID uuid.UUID) (int, error) {
+	var lockedID uuid.UUID
+	err := tx.GetContext(ctx, &lockedID, `SELECT id FROM courses WHERE id = $1 FOR UPDATE`, courseID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("course not found: %w", err)
+		}
+		return 0, fmt.Errorf("failed to lock course: %w", err)
+	}
+
+	query := `
+		SELECT COALESCE(MAX("order"), 0) + 1
+		FROM (
+			SELECT "order" FROM course_videos WHERE course_id = $1
+			UNION ALL
+			SELECT "order" FROM course_materials WHERE course_id = $1
+			UNION ALL
+			SELECT "order" FROM course_articles WHERE course_id = $1
+			UNION ALL
+			SELECT "order" FROM course_quizzes WHERE course_id = $1
+		) AS contents
+	`
+	var order int
+	if err = tx.GetContext(ctx, &order, query, courseID); err != nil {
+		return 0, fmt.Errorf("failed to get next content order: %w", err)
+	}
+
+	return order, nil
+}
+
+// ReorderContents sets the order of each content to its position in contentIDs, starting from 1.
+// contentIDs must hold every video, material, article and quiz of the course exactly once.
+func (r *courseContentRepository) ReorderContents(ctx context.Context, courseID uuid.UUID,
+	contentIDs []uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the course so contents can't be created or deleted while reordering
+	var lockedID uuid.UUID
+	err = tx.GetContext(ctx, &lockedID, `SELECT id FROM courses WHERE id = $1 FOR UPDATE`, courseID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("course not found: %w", err)
+		}
+		return fmt.Errorf("failed to lock course: %w", err)
+	}
+
+	contentsQuery := `
+		SELECT id, 'course_videos' AS table_name FROM course_videos WHERE course_id = $1
+		UNION ALL
+		SELECT id, 'course_materials' AS table_name FROM course_materials WHERE course_id = $1
+		UNION ALL
+		SELECT id, 'course_articles' AS table_name FROM course_articles WHERE course_id = $1
+		UNION ALL
+		SELECT id, 'course_quizzes' AS table_name FROM course_quizzes WHERE course_id = $1
+	`
+	var contents []struct {
+		ID        uuid.UUID `db:"id"`
+		TableName string    `db:"table_name"`
+	}
+	if err = tx.SelectContext(ctx, &contents, contentsQuery, courseID); err != nil {
+		return fmt.Errorf("failed to get course contents: %w", err)
+	}
+
+	if len(contents) != len(contentIDs) {
+		return errors.New("content list mismatch")
+	}
+
+	tableByID := make(map[uuid.UUID]string, len(contents))
+	for _, content := range contents {
+		tableByID[content.ID] = content.TableName
 	}
 
-	return videos, materials, nil
+	for i, id := range contentIDs {
+		table, ok := tableByID[id]
+		if !ok {
+			return errors.New("content list mismatch")
+		}
+
+		updateQuery := fmt.Sprintf(`UPDATE %s SET "order" = $1, updated_at = NOW() WHERE id = $2`, table)
+		if _, err = tx.ExecContext(ctx, updateQuery, i+1, id); err != nil {
+			return fmt.Errorf("failed to update content order: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
 }
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/nathakusuma/elevateu-backend/domain/contract"
+	"github.com/nathakusuma/elevateu-backend/domain/dto"
+	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/pkg/sqlutil"
+)
+
+type courseNoteRepository struct {
+	db *sqlx.DB
+}
+
+func NewCourseNoteRepository(db *sqlx.DB) contract.ICourseNoteRepository {
+	return &courseNoteRepository{
+		db: db,
+	}
+}
+
+func (r *courseNoteRepository) CreateNote(ctx context.Context, note *entity.CourseNote) error {
+	query := `
+		INSERT INTO course_notes (id, student_id, course_id, video_id, material_id, timestamp, body)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at, updated_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, query, note.ID, note.StudentID, note.CourseID, note.VideoID,
+		note.MaterialID, note.Timestamp, note.Body).Scan(&note.CreatedAt, &note.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create note: %w", err)
+	}
+
+	return nil
+}
+
+// UpsertMaterialBookmark creates the bookmark, or updates the note of the existing one.
+// The ID and timestamps of the note are set to the ones of the stored bookmark.
+func (r *courseNoteRepository) UpsertMaterialBookmark(ctx context.Context, note *entity.CourseNote) error {
+	query := `
+		INSERT INTO course_notes (id, student_id, course_id, material_id, body)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (student_id, material_id) DO UPDATE
+		SET body = EXCLUDED.body,
+		    updated_at = NOW()
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, query, note.ID, note.StudentID, note.CourseID, note.MaterialID,
+		note.Body).Scan(&note.ID, &note.CreatedAt, &note.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to upsert material bookmark: %w", err)
+	}
+
+	return nil
+}
+
+func (r *courseNoteRepository) GetNoteByID(ctx context.Context, id uuid.UUID) (*entity.CourseNote, error) {
+	query := `
+		SELECT id, student_id, course_id, video_id, material_id, timestamp, body, created_at, updated_at
+		FROM course_notes
+		WHERE id = $1
+	`
+
+	var note entity.CourseNote
+	err := r.db.GetContext(ctx, &note, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("note not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get note: %w", err)
+	}
+
+	return &note, nil
+}
+
+// GetNotesByCourseID returns the notes of the student in the course, ordered by the order of their contents,
+// then by timestamp
+func (r *courseNoteRepository) GetNotesByCourseID(ctx context.Context,
+	courseID, studentID uuid.UUID) ([]*entity.CourseNote, error) {
+	query := `
+		SELECT
+			n.id, n.student_id, n.course_id, n.video_id, n.material_id, n.timestamp, n.body, n.created_at,
+			n.updated_at,
+			COALESCE(v.title, m.title) AS content_title,
+			COALESCE(v."order", m."order") AS content_order
+		FROM course_notes n
+		LEFT JOIN course_videos v ON v.id = n.video_id
+		LEFT JOIN course_materials m ON m.id = n.material_id
+		WHERE n.course_id = $1 AND n.student_id = $2
+		ORDER BY content_order, n.video_id, n.material_id, n.timestamp, n.created_at
+	`
+
+	var notes []*entity.CourseNote
+	if err := r.db.SelectContext(ctx, &notes, query, courseID, studentID); err != nil {
+		return nil, fmt.Errorf("failed to get notes: %w", err)
+	}
+
+	return notes, nil
+}
+
+func (r *courseNoteRepository) UpdateNote(ctx context.Context, id uuid.UUID, updates dto.CourseNoteUpdate) error {
+	builder := sqlutil.NewSQLUpdateBuilder("course_notes").
+		WithUpdatedAt().
+		Where("id = ?", id)
+
+	query, args, err := builder.BuildFromStruct(updates)
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	// No fields to update
+	if query == "" {
+		return nil
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update note: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("note not found")
+	}
+
+	return nil
+}
+
+func (r *courseNoteRepository) DeleteNote(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM course_notes WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete note: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("note not found")
+	}
+
+	return nil
+}
@@ -101,6 +101,53 @@ func (r *courseProgressRepository) UpdateMaterialProgress(ctx context.Context, t
 	return rowsAffected > 0, nil
 }
 
+func (r *courseProgressRepository) UpdateArticleProgress(ctx context.Context, txWrapper database.ITransaction,
+	progress entity.CourseArticleProgress) (bool, error) {
+	tx := txWrapper.GetTx()
+
+	query := `
+		INSERT INTO course_article_progresses (student_id, article_id)
+		VALUES ($1, $2)
+		ON CONFLICT (student_id, article_id) DO NOTHING
+	`
+	result, err := tx.ExecContext(ctx, query, progress.StudentID, progress.ArticleID)
+	if err != nil {
+		return false, fmt.Errorf("failed to update article progress: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	// Return true if the article was newly completed, indicating that the content completion count should be incremented
+	return rowsAffected > 0, nil
+}
+
+func (r *courseProgressRepository) UpdateQuizProgress(ctx context.Context, txWrapper database.ITransaction,
+	progress entity.CourseQuizProgress) (bool, error) {
+	tx := txWrapper.GetTx()
+
+	query := `
+		INSERT INTO course_quiz_progresses (student_id, quiz_id)
+		VALUES ($1, $2)
+		ON CONFLICT (student_id, quiz_id) DO NOTHING
+	`
+	result, err := tx.ExecContext(ctx, query, progress.StudentID, progress.QuizID)
+	if err != nil {
+		return false, fmt.Errorf("failed to update quiz progress: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	// Return true if the quiz was passed for the first time, indicating that the content completion count
+	// should be incremented
+	return rowsAffected > 0, nil
+}
+
 func (r *courseProgressRepository) IncrementCourseProgress(ctx context.Context, txWrapper database.ITransaction,
 	courseID, studentID uuid.UUID) (bool, error) {
 	tx := txWrapper.GetTx()
@@ -166,6 +213,10 @@ func (r *courseProgressRepository) GetContentCourseID(ctx context.Context, conte
 		query = `SELECT course_id FROM course_videos WHERE id = $1`
 	} else if contentType == "material" {
 		query = `SELECT course_id FROM course_materials WHERE id = $1`
+	} else if contentType == "article" {
+		query = `SELECT course_id FROM course_articles WHERE id = $1`
+	} else if contentType == "quiz" {
+		query = `SELECT course_id FROM course_quizzes WHERE id = $1`
 	} else {
 		return uuid.Nil, fmt.Errorf("invalid content type: %s", contentType)
 	}
@@ -182,6 +233,46 @@ func (r *courseProgressRepository) GetContentCourseID(ctx context.Context, conte
 	return courseID, nil
 }
 
+// GetSectionProgresses counts the contents and the student's completed contents of the course per section
+func (r *courseProgressRepository) GetSectionProgresses(ctx context.Context,
+	courseID, studentID uuid.UUID) ([]*entity.CourseSectionProgress, error) {
+	query := `
+		WITH contents AS (
+			SELECT v.section_id, COALESCE(vp.is_completed, FALSE) AS is_completed
+			FROM course_videos v
+			LEFT JOIN course_video_progresses vp ON vp.video_id = v.id AND vp.student_id = $2
+			WHERE v.course_id = $1
+			UNION ALL
+			SELECT m.section_id, mp.material_id IS NOT NULL AS is_completed
+			FROM course_materials m
+			LEFT JOIN course_material_progresses mp ON mp.material_id = m.id AND mp.student_id = $2
+			WHERE m.course_id = $1
+			UNION ALL
+			SELECT a.section_id, ap.article_id IS NOT NULL AS is_completed
+			FROM course_articles a
+			LEFT JOIN course_article_progresses ap ON ap.article_id = a.id AND ap.student_id = $2
+			WHERE a.course_id = $1
+			UNION ALL
+			SELECT q.section_id, qp.quiz_id IS NOT NULL AS is_completed
+			FROM course_quizzes q
+			LEFT JOIN course_quiz_progresses qp ON qp.quiz_id = q.id AND qp.student_id = $2
+			WHERE q.course_id = $1
+		)
+		SELECT section_id,
+		       COUNT(*) AS content_count,
+		       COUNT(*) FILTER (WHERE is_completed) AS content_completed
+		FROM contents
+		GROUP BY section_id
+	`
+
+	var progresses []*entity.CourseSectionProgress
+	if err := r.db.SelectContext(ctx, &progresses, query, courseID, studentID); err != nil {
+		return nil, fmt.Errorf("failed to get section progresses: %w", err)
+	}
+
+	return progresses, nil
+}
+
 func (r *courseProgressRepository) BatchDecrementCourseProgress(ctx context.Context, txWrapper database.ITransaction,
 	courseID uuid.UUID, contentID uuid.UUID, contentType string) error {
 	tx := txWrapper.GetTx()
@@ -206,6 +297,24 @@ func (r *courseProgressRepository) BatchDecrementCourseProgress(ctx context.Cont
 			  AND ce.course_id = $1
 			  AND cmp.material_id = $2
 		`
+	} else if contentType == "article" {
+		query = `
+			UPDATE course_enrollments ce
+			SET content_completed = content_completed - 1
+			FROM course_article_progresses cap
+			WHERE ce.student_id = cap.student_id
+			  AND ce.course_id = $1
+			  AND cap.article_id = $2
+		`
+	} else if contentType == "quiz" {
+		query = `
+			UPDATE course_enrollments ce
+			SET content_completed = content_completed - 1
+			FROM course_quiz_progresses cqp
+			WHERE ce.student_id = cqp.student_id
+			  AND ce.course_id = $1
+			  AND cqp.quiz_id = $2
+		`
 	} else {
 		return fmt.Errorf("invalid content type: %s", contentType)
 	}
@@ -0,0 +1,401 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/nathakusuma/elevateu-backend/domain/contract"
+	"github.com/nathakusuma/elevateu-backend/domain/dto"
+	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/internal/infra/database"
+	"github.com/nathakusuma/elevateu-backend/pkg/sqlutil"
+)
+
+type courseQuizRepository struct {
+	db *sqlx.DB
+}
+
+func NewCourseQuizRepository(conn *sqlx.DB) contract.ICourseQuizRepository {
+	return &courseQuizRepository{
+		db: conn,
+	}
+}
+
+func (r *courseQuizRepository) CreateQuiz(ctx context.Context, quiz *entity.CourseQuiz) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// New contents go after the last content of the course
+	if quiz.Order, err = nextContentOrder(ctx, tx, quiz.CourseID); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO course_quizzes (
+			id, course_id, section_id, title, description, passing_score, max_attempts, is_free, "order"
+		) VALUES (
+			:id, :course_id, :section_id, :title, :description, :passing_score, :max_attempts, :is_free, :order
+		)
+	`
+
+	_, err = tx.NamedExecContext(ctx, query, quiz)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.ConstraintName == "course_quizzes_course_id_fkey" {
+			return fmt.Errorf("course not found: %w", err)
+		}
+
+		return fmt.Errorf("failed to create quiz: %w", err)
+	}
+
+	if err = r.insertQuestions(ctx, tx, quiz.Questions); err != nil {
+		return err
+	}
+
+	// After creating the quiz, update the course's content_count
+	updateQuery := `
+		UPDATE courses
+		SET content_count = content_count + 1,
+		    updated_at = NOW()
+		WHERE id = $1
+	`
+	_, err = tx.ExecContext(ctx, updateQuery, quiz.CourseID)
+	if err != nil {
+		return fmt.Errorf("failed to update course stats: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *courseQuizRepository) insertQuestions(ctx context.Context, tx *sqlx.Tx,
+	questions []*entity.CourseQuizQuestion) error {
+	questionQuery := `
+		INSERT INTO course_quiz_questions (
+			id, quiz_id, type, question, points, "order"
+		) VALUES (
+			:id, :quiz_id, :type, :question, :points, :order
+		)
+	`
+	optionQuery := `
+		INSERT INTO course_quiz_options (
+			id, question_id, text, is_correct, "order"
+		) VALUES (
+			:id, :question_id, :text, :is_correct, :order
+		)
+	`
+
+	for _, question := range questions {
+		if _, err := tx.NamedExecContext(ctx, questionQuery, question); err != nil {
+			return fmt.Errorf("failed to create question: %w", err)
+		}
+
+		for _, option := range question.Options {
+			if _, err := tx.NamedExecContext(ctx, optionQuery, option); err != nil {
+				return fmt.Errorf("failed to create option: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *courseQuizRepository) GetQuizByID(ctx context.Context, id uuid.UUID) (*entity.CourseQuiz, error) {
+	var quiz entity.CourseQuiz
+	query := `
+		SELECT id, course_id, section_id, title, description, passing_score, max_attempts, is_free, "order",
+		       created_at, updated_at
+		FROM course_quizzes
+		WHERE id = $1
+	`
+	err := r.db.GetContext(ctx, &quiz, query, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("quiz not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get quiz: %w", err)
+	}
+
+	questionsQuery := `
+		SELECT id, quiz_id, type, question, points, "order"
+		FROM course_quiz_questions
+		WHERE quiz_id = $1
+		ORDER BY "order"
+	`
+	if err = r.db.SelectContext(ctx, &quiz.Questions, questionsQuery, id); err != nil {
+		return nil, fmt.Errorf("failed to get quiz questions: %w", err)
+	}
+
+	optionsQuery := `
+		SELECT o.id, o.question_id, o.text, o.is_correct, o."order"
+		FROM course_quiz_options o
+		JOIN course_quiz_questions q ON q.id = o.question_id
+		WHERE q.quiz_id = $1
+		ORDER BY o."order"
+	`
+	var options []*entity.CourseQuizOption
+	if err = r.db.SelectContext(ctx, &options, optionsQuery, id); err != nil {
+		return nil, fmt.Errorf("failed to get quiz options: %w", err)
+	}
+
+	questionByID := make(map[uuid.UUID]*entity.CourseQuizQuestion, len(quiz.Questions))
+	for _, question := range quiz.Questions {
+		questionByID[question.ID] = question
+	}
+	for _, option := range options {
+		question := questionByID[option.QuestionID]
+		question.Options = append(question.Options, option)
+	}
+
+	return &quiz, nil
+}
+
+// GetQuizzesByCourseID returns the quizzes of the course sorted by order, without their questions
+func (r *courseQuizRepository) GetQuizzesByCourseID(ctx context.Context,
+	courseID uuid.UUID) ([]*entity.CourseQuiz, error) {
+	query := `
+		SELECT id, course_id, section_id, title, description, passing_score, max_attempts, is_free, "order",
+		       created_at, updated_at
+		FROM course_quizzes
+		WHERE course_id = $1
+		ORDER BY "order"
+	`
+
+	var quizzes []*entity.CourseQuiz
+	if err := r.db.SelectContext(ctx, &quizzes, query, courseID); err != nil {
+		return nil, fmt.Errorf("failed to get course quizzes: %w", err)
+	}
+
+	return quizzes, nil
+}
+
+func (r *courseQuizRepository) UpdateQuiz(ctx context.Context, id uuid.UUID, updates dto.CourseQuizUpdate) error {
+	builder := sqlutil.NewSQLUpdateBuilder("course_quizzes").
+		WithUpdatedAt().
+		Where("id = ?", id)
+
+	query, args, err := builder.BuildFromStruct(updates)
+	if err != nil {
+		return fmt.Errorf("failed to build update query: %w", err)
+	}
+
+	// No fields to update (query is empty)
+	if query == "" {
+		return nil
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update quiz: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("quiz not found")
+	}
+
+	return nil
+}
+
+// ReplaceQuestions replaces all questions of the quiz. It refuses to once the quiz has attempts,
+// as removing the old questions would also remove the answers of the attempts.
+func (r *courseQuizRepository) ReplaceQuestions(ctx context.Context, quizID uuid.UUID,
+	questions []*entity.CourseQuizQuestion) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `UPDATE course_quizzes SET updated_at = NOW() WHERE id = $1`, quizID)
+	if err != nil {
+		return fmt.Errorf("failed to update quiz: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("quiz not found")
+	}
+
+	// The update above waits for the attempts being saved, which lock the quiz too
+	var hasAttempts bool
+	err = tx.GetContext(ctx, &hasAttempts,
+		`SELECT EXISTS(SELECT 1 FROM course_quiz_attempts WHERE quiz_id = $1)`, quizID)
+	if err != nil {
+		return fmt.Errorf("failed to check attempts: %w", err)
+	}
+
+	if hasAttempts {
+		return errors.New("quiz has attempts")
+	}
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM course_quiz_questions WHERE quiz_id = $1`, quizID); err != nil {
+		return fmt.Errorf("failed to delete questions: %w", err)
+	}
+
+	if err = r.insertQuestions(ctx, tx, questions); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *courseQuizRepository) DeleteQuiz(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var courseID uuid.UUID
+	err = tx.GetContext(ctx, &courseID, `DELETE FROM course_quizzes WHERE id = $1 RETURNING course_id`, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("quiz not found: %w", err)
+		}
+		return fmt.Errorf("failed to delete quiz: %w", err)
+	}
+
+	// Update the course's content_count
+	updateQuery := `
+		UPDATE courses
+		SET content_count = content_count - 1,
+		    updated_at = NOW()
+		WHERE id = $1
+	`
+	_, err = tx.ExecContext(ctx, updateQuery, courseID)
+	if err != nil {
+		return fmt.Errorf("failed to update course stats: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// CountAttempts counts the student's attempts of the quiz. It locks the student's enrollment in the course
+// until the transaction ends, so concurrent attempts are counted one after another.
+func (r *courseQuizRepository) CountAttempts(ctx context.Context, txWrapper database.ITransaction,
+	quizID, studentID uuid.UUID) (int, error) {
+	tx := txWrapper.GetTx()
+
+	lockQuery := `
+		SELECT e.student_id
+		FROM course_enrollments e
+		JOIN course_quizzes q ON q.course_id = e.course_id
+		WHERE q.id = $1
+		  AND e.student_id = $2
+		FOR UPDATE OF e
+	`
+	var lockedID uuid.UUID
+	if err := tx.GetContext(ctx, &lockedID, lockQuery, quizID, studentID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("enrollment not found: %w", err)
+		}
+		return 0, fmt.Errorf("failed to lock enrollment: %w", err)
+	}
+
+	var count int
+	countQuery := `SELECT COUNT(*) FROM course_quiz_attempts WHERE quiz_id = $1 AND student_id = $2`
+	if err := tx.GetContext(ctx, &count, countQuery, quizID, studentID); err != nil {
+		return 0, fmt.Errorf("failed to count attempts: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *courseQuizRepository) CreateAttempt(ctx context.Context, txWrapper database.ITransaction,
+	attempt *entity.CourseQuizAttempt) error {
+	tx := txWrapper.GetTx()
+
+	// Lock the quiz against ReplaceQuestions until the attempt is committed
+	if _, err := tx.ExecContext(ctx, `SELECT 1 FROM course_quizzes WHERE id = $1 FOR SHARE`,
+		attempt.QuizID); err != nil {
+		return fmt.Errorf("failed to lock quiz: %w", err)
+	}
+
+	query := `
+		INSERT INTO course_quiz_attempts (
+			id, quiz_id, student_id, score, max_score, is_passed
+		) VALUES (
+			$1, $2, $3, $4, $5, $6
+		)
+		RETURNING created_at
+	`
+	err := tx.QueryRowxContext(ctx, query, attempt.ID, attempt.QuizID, attempt.StudentID, attempt.Score,
+		attempt.MaxScore, attempt.IsPassed).Scan(&attempt.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create attempt: %w", err)
+	}
+
+	answerQuery := `
+		INSERT INTO course_quiz_attempt_answers (
+			attempt_id, question_id, answer_text, is_correct
+		) VALUES (
+			:attempt_id, :question_id, :answer_text, :is_correct
+		)
+	`
+	selectionQuery := `
+		INSERT INTO course_quiz_attempt_selections (attempt_id, question_id, option_id)
+		VALUES ($1, $2, $3)
+	`
+	for _, answer := range attempt.Answers {
+		if _, err = tx.NamedExecContext(ctx, answerQuery, answer); err != nil {
+			return fmt.Errorf("failed to save answer: %w", err)
+		}
+
+		for _, optionID := range answer.OptionIDs {
+			if _, err = tx.ExecContext(ctx, selectionQuery, answer.AttemptID, answer.QuestionID,
+				optionID); err != nil {
+				return fmt.Errorf("failed to save selected option: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// GetAttempts returns the student's attempts of the quiz, newest first, without their answers
+func (r *courseQuizRepository) GetAttempts(ctx context.Context,
+	quizID, studentID uuid.UUID) ([]*entity.CourseQuizAttempt, error) {
+	query := `
+		SELECT id, quiz_id, student_id, score, max_score, is_passed, created_at
+		FROM course_quiz_attempts
+		WHERE quiz_id = $1
+		  AND student_id = $2
+		ORDER BY created_at DESC
+	`
+
+	var attempts []*entity.CourseQuizAttempt
+	if err := r.db.SelectContext(ctx, &attempts, query, quizID, studentID); err != nil {
+		return nil, fmt.Errorf("failed to get attempts: %w", err)
+	}
+
+	return attempts, nil
+}
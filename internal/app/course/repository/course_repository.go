@@ -15,10 +15,19 @@ import (
 	"github.com/nathakusuma/elevateu-backend/domain/contract"
 	"github.com/nathakusuma/elevateu-backend/domain/dto"
 	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
 	"github.com/nathakusuma/elevateu-backend/internal/infra/database"
 	"github.com/nathakusuma/elevateu-backend/pkg/sqlutil"
 )
 
+// A scheduled course becomes published once its publish_at has passed, without anything having to update it.
+// courseStatusColumn selects that effective status and courseVisibleCondition matches the published courses.
+const (
+	courseStatusColumn = `CASE WHEN c.status = 'scheduled' AND c.publish_at <= NOW() THEN 'published' ` +
+		`ELSE c.status END`
+	courseVisibleCondition = `(c.status = 'published' OR (c.status = 'scheduled' AND c.publish_at <= NOW()))`
+)
+
 type courseRepository struct {
 	db *sqlx.DB
 }
@@ -32,9 +41,9 @@ func NewCourseRepository(conn *sqlx.DB) contract.ICourseRepository {
 func (r *courseRepository) CreateCourse(ctx context.Context, course *entity.Course) error {
 	query := `
 		INSERT INTO courses (
-			id, category_id, title, description, teacher_name
+			id, category_id, title, description, teacher_name, price
 		) VALUES (
-			:id, :category_id, :title, :description, :teacher_name
+			:id, :category_id, :title, :description, :teacher_name, :price
 		)
 	`
 
@@ -56,7 +65,8 @@ func (r *courseRepository) GetCourseByID(ctx context.Context, id uuid.UUID) (*en
 		SELECT
 			c.id, c.category_id, c.title, c.description, c.teacher_name,
 			c.rating, c.rating_count, c.total_rating, c.enrollment_count,
-			c.content_count, c.total_duration, c.created_at, c.updated_at,
+			c.content_count, c.total_duration, c.price, ` + courseStatusColumn + ` AS status, c.publish_at,
+			c.preview_video_status, c.created_at, c.updated_at,
 			cat.id AS "category.id", cat.name AS "category.name"
 		FROM courses c
 		LEFT JOIN categories cat ON c.category_id = cat.id
@@ -85,7 +95,8 @@ func (r *courseRepository) GetCourses(ctx context.Context, query dto.GetCoursesQ
        SELECT
           c.id, c.category_id, c.title, c.description, c.teacher_name,
           c.rating, c.rating_count, c.total_rating, c.enrollment_count,
-          c.content_count, c.total_duration, c.created_at, c.updated_at,
+          c.content_count, c.total_duration, c.price, ` + courseStatusColumn + ` AS status, c.publish_at,
+          c.preview_video_status, c.created_at, c.updated_at,
           cat.id AS "category.id", cat.name AS "category.name"
        FROM courses c
        LEFT JOIN categories cat ON c.category_id = cat.id
@@ -102,12 +113,27 @@ func (r *courseRepository) GetCourses(ctx context.Context, query dto.GetCoursesQ
 		argIndex++
 	}
 
+	// the title search also matches the spoken content through the subtitle transcripts
 	if query.Title != "" {
-		whereConditions = append(whereConditions, fmt.Sprintf("c.title ILIKE $%d", argIndex))
+		whereConditions = append(whereConditions, fmt.Sprintf(`(c.title ILIKE $%[1]d OR EXISTS (
+			SELECT 1 FROM course_video_subtitles s
+			JOIN course_videos v ON v.id = s.video_id
+			WHERE v.course_id = c.id AND s.transcript ILIKE $%[1]d
+		))`, argIndex))
 		args = append(args, "%"+query.Title+"%")
 		argIndex++
 	}
 
+	if query.Status != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("%s = $%d", courseStatusColumn, argIndex))
+		args = append(args, query.Status)
+		argIndex++
+	}
+
+	if query.VisibleOnly {
+		whereConditions = append(whereConditions, courseVisibleCondition)
+	}
+
 	// cursor-based pagination
 	if paginationReq.Cursor != uuid.Nil {
 		var operator string
@@ -250,6 +276,48 @@ func (r *courseRepository) UpdateCourse(ctx context.Context, txWrapper database.
 	return nil
 }
 
+func (r *courseRepository) UpdateCourseStatus(ctx context.Context, id uuid.UUID, status enum.CourseStatus,
+	publishAt *time.Time) error {
+	query := `UPDATE courses SET status = $2, publish_at = $3, updated_at = NOW() WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id, status, publishAt)
+	if err != nil {
+		return fmt.Errorf("failed to update course status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("course not found")
+	}
+
+	return nil
+}
+
+func (r *courseRepository) UpdatePreviewVideoStatus(ctx context.Context, id uuid.UUID,
+	status enum.AssetStatus) error {
+	query := `UPDATE courses SET preview_video_status = $2, updated_at = NOW() WHERE id = $1`
+
+	result, err := r.db.ExecContext(ctx, query, id, status)
+	if err != nil {
+		return fmt.Errorf("failed to update preview video status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("course not found")
+	}
+
+	return nil
+}
+
 func (r *courseRepository) DeleteCourse(ctx context.Context, txWrapper database.ITransaction, id uuid.UUID) error {
 	tx := txWrapper.GetTx()
 
@@ -280,6 +348,20 @@ func (r *courseRepository) CreateEnrollment(ctx context.Context, courseID, stude
 	}
 	defer tx.Rollback()
 
+	// Students can only enroll in published courses
+	var isVisible bool
+	err = tx.GetContext(ctx, &isVisible, `SELECT `+courseVisibleCondition+` FROM courses c WHERE c.id = $1`, courseID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("course not found: %w", err)
+		}
+		return fmt.Errorf("failed to check course status: %w", err)
+	}
+
+	if !isVisible {
+		return errors.New("course not found: course is not published")
+	}
+
 	query := `INSERT INTO course_enrollments (course_id, student_id)
 				VALUES ($1, $2)`
 
@@ -318,10 +400,12 @@ func (r *courseRepository) GetEnrolledCourses(ctx context.Context, studentID uui
        SELECT
           c.id, c.category_id, c.title, c.description, c.teacher_name,
           c.rating, c.rating_count, c.total_rating, c.enrollment_count,
-          c.content_count, c.total_duration, c.created_at, c.updated_at,
+          c.content_count, c.total_duration, c.price, ` + courseStatusColumn + ` AS status, c.publish_at,
+          c.preview_video_status, c.created_at, c.updated_at,
           cat.id AS "category.id", cat.name AS "category.name",
           ce.content_completed AS "enrollment.content_completed",
-          ce.is_completed AS "enrollment.is_completed"
+          ce.is_completed AS "enrollment.is_completed",
+          ce.is_purchased AS "enrollment.is_purchased"
        FROM courses c
        JOIN categories cat ON c.category_id = cat.id
        JOIN course_enrollments ce ON c.id = ce.course_id
@@ -417,7 +501,7 @@ func (r *courseRepository) GetEnrollment(ctx context.Context, courseID,
 	studentID uuid.UUID) (*entity.CourseEnrollment, error) {
 	query := `
 		SELECT
-			content_completed, is_completed
+			content_completed, is_completed, is_purchased
 		FROM course_enrollments
 		WHERE course_id = $1 AND student_id = $2
 	`
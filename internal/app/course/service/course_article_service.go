@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/contract"
+	"github.com/nathakusuma/elevateu-backend/domain/ctxkey"
+	"github.com/nathakusuma/elevateu-backend/domain/dto"
+	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
+	"github.com/nathakusuma/elevateu-backend/domain/errorpkg"
+	"github.com/nathakusuma/elevateu-backend/pkg/log"
+	"github.com/nathakusuma/elevateu-backend/pkg/markdown"
+	"github.com/nathakusuma/elevateu-backend/pkg/uuidpkg"
+)
+
+type courseArticleService struct {
+	articleRepo contract.ICourseArticleRepository
+	contentRepo contract.ICourseContentRepository
+	courseRepo  contract.ICourseRepository
+	uuid        uuidpkg.IUUID
+}
+
+func NewCourseArticleService(
+	articleRepo contract.ICourseArticleRepository,
+	contentRepo contract.ICourseContentRepository,
+	courseRepo contract.ICourseRepository,
+	uuid uuidpkg.IUUID,
+) contract.ICourseArticleService {
+	return &courseArticleService{
+		articleRepo: articleRepo,
+		contentRepo: contentRepo,
+		courseRepo:  courseRepo,
+		uuid:        uuid,
+	}
+}
+
+func (s *courseArticleService) CreateArticle(ctx context.Context, editorID, courseID uuid.UUID,
+	req dto.CreateCourseArticleRequest) (*dto.CourseArticleResponse, error) {
+	if err := validateSection(ctx, s.contentRepo, courseID, req.SectionID); err != nil {
+		return nil, err
+	}
+
+	articleID, err := s.uuid.NewV7()
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+		}, "Failed to generate article ID")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	bodyHTML, err := s.renderBody(ctx, req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	article := &entity.CourseArticle{
+		ID:        articleID,
+		CourseID:  courseID,
+		SectionID: req.SectionID,
+		Title:     req.Title,
+		Subtitle:  req.Subtitle,
+		Body:      req.Body,
+		BodyHTML:  bodyHTML,
+		IsFree:    req.IsFree,
+	}
+
+	err = s.articleRepo.CreateArticle(ctx, article, editorID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "course not found") {
+			return nil, errorpkg.ErrValidation().WithDetail("Course not found")
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":     err,
+			"course.id": courseID,
+		}, "Failed to create article")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"article.id": article.ID,
+		"course.id":  courseID,
+	}, "Article created")
+
+	resp := &dto.CourseArticleResponse{}
+	resp.PopulateFromEntity(article, true)
+
+	return resp, nil
+}
+
+// GetArticle returns the rendered article. Only admins get the Markdown source.
+func (s *courseArticleService) GetArticle(ctx context.Context, id uuid.UUID) (*dto.CourseArticleResponse, error) {
+	article, err := s.articleRepo.GetArticleByID(ctx, id)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "article not found") {
+			return nil, errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"article.id": id,
+		}, "Failed to get article")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	role, ok := ctx.Value(ctxkey.UserRole).(enum.UserRole)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx, nil, "Failed to get user role from context")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	isAdmin := role == enum.UserRoleAdmin
+	if !isAdmin {
+		_, canAccess, err := getContentAccess(ctx, s.courseRepo, article.CourseID, article.IsFree)
+		if err != nil {
+			return nil, err
+		}
+
+		if !canAccess {
+			return nil, errorpkg.ErrNotSubscribed()
+		}
+	}
+
+	resp := &dto.CourseArticleResponse{}
+	resp.PopulateFromEntity(article, isAdmin)
+
+	return resp, nil
+}
+
+func (s *courseArticleService) UpdateArticle(ctx context.Context, editorID, id uuid.UUID,
+	req dto.UpdateCourseArticleRequest) error {
+	if req.SectionID != nil {
+		article, err := s.articleRepo.GetArticleByID(ctx, id)
+		if err != nil {
+			if strings.HasPrefix(err.Error(), "article not found") {
+				return errorpkg.ErrNotFound()
+			}
+			traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+				"error":      err,
+				"article.id": id,
+			}, "Failed to get article")
+			return errorpkg.ErrInternalServer().WithTraceID(traceID)
+		}
+
+		if err = validateSection(ctx, s.contentRepo, article.CourseID, req.SectionID); err != nil {
+			return err
+		}
+	}
+
+	updates := dto.CourseArticleUpdate{
+		SectionID: sectionUpdate(req.SectionID, req.RemoveSection),
+		Title:     req.Title,
+		Subtitle:  req.Subtitle,
+		Body:      req.Body,
+		IsFree:    req.IsFree,
+	}
+
+	if req.Body != nil {
+		bodyHTML, err := s.renderBody(ctx, *req.Body)
+		if err != nil {
+			return err
+		}
+		updates.BodyHTML = &bodyHTML
+	}
+
+	err := s.articleRepo.UpdateArticle(ctx, id, updates, editorID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "article not found") {
+			return errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"article.id": id,
+		}, "Failed to update article")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"article.id": id,
+		"editor.id":  editorID,
+	}, "Article updated")
+
+	return nil
+}
+
+func (s *courseArticleService) DeleteArticle(ctx context.Context, id uuid.UUID) error {
+	err := s.articleRepo.DeleteArticle(ctx, id)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "article not found") {
+			return errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"article.id": id,
+		}, "Failed to delete article")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"article.id": id,
+	}, "Article deleted")
+
+	return nil
+}
+
+func (s *courseArticleService) GetRevisions(ctx context.Context,
+	id uuid.UUID) ([]*dto.CourseArticleRevisionResponse, error) {
+	revisions, err := s.articleRepo.GetRevisions(ctx, id)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "article not found") {
+			return nil, errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"article.id": id,
+		}, "Failed to get article revisions")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	resp := make([]*dto.CourseArticleRevisionResponse, len(revisions))
+	for i, revision := range revisions {
+		resp[i] = &dto.CourseArticleRevisionResponse{}
+		resp[i].PopulateFromEntity(revision)
+	}
+
+	return resp, nil
+}
+
+func (s *courseArticleService) GetRevision(ctx context.Context, id uuid.UUID,
+	revision int) (*dto.CourseArticleRevisionResponse, error) {
+	articleRevision, err := s.articleRepo.GetRevision(ctx, id, revision)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "revision not found") {
+			return nil, errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"article.id": id,
+			"revision":   revision,
+		}, "Failed to get article revision")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	resp := &dto.CourseArticleRevisionResponse{}
+	resp.PopulateFromEntity(articleRevision)
+
+	return resp, nil
+}
+
+func (s *courseArticleService) renderBody(ctx context.Context, body string) (string, error) {
+	bodyHTML, err := markdown.ToSafeHTML(body)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+		}, "Failed to render article body")
+		return "", errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	return bodyHTML, nil
+}
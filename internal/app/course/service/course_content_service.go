@@ -1,8 +1,12 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/google/uuid"
@@ -11,35 +15,60 @@ import (
 	"github.com/nathakusuma/elevateu-backend/domain/ctxkey"
 	"github.com/nathakusuma/elevateu-backend/domain/dto"
 	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
 	"github.com/nathakusuma/elevateu-backend/domain/errorpkg"
 	"github.com/nathakusuma/elevateu-backend/pkg/fileutil"
 	"github.com/nathakusuma/elevateu-backend/pkg/log"
 	"github.com/nathakusuma/elevateu-backend/pkg/uuidpkg"
+	"github.com/nathakusuma/elevateu-backend/pkg/webvtt"
+)
+
+var (
+	maxVideoSize    = 2 * fileutil.GigaByte
+	maxImageSize    = 2 * fileutil.MegaByte
+	maxMaterialSize = 50 * fileutil.MegaByte
+	maxSubtitleSize = 1 * fileutil.MegaByte
+
+	videoContentTypes    = []string{"video/mp4"}
+	materialContentTypes = []string{"application/pdf"}
 )
 
 type courseContentService struct {
-	contentRepo contract.ICourseContentRepository
-	courseRepo  contract.ICourseRepository
-	fileUtil    fileutil.IFileUtil
-	uuid        uuidpkg.IUUID
+	contentRepo  contract.ICourseContentRepository
+	courseRepo   contract.ICourseRepository
+	progressRepo contract.ICourseProgressRepository
+	articleRepo  contract.ICourseArticleRepository
+	quizRepo     contract.ICourseQuizRepository
+	fileUtil     fileutil.IFileUtil
+	uuid         uuidpkg.IUUID
 }
 
 func NewCourseContentService(
 	contentRepo contract.ICourseContentRepository,
 	courseRepo contract.ICourseRepository,
+	progressRepo contract.ICourseProgressRepository,
+	articleRepo contract.ICourseArticleRepository,
+	quizRepo contract.ICourseQuizRepository,
 	fileUtil fileutil.IFileUtil,
 	uuid uuidpkg.IUUID,
 ) contract.ICourseContentService {
 	return &courseContentService{
-		contentRepo: contentRepo,
-		courseRepo:  courseRepo,
-		fileUtil:    fileUtil,
-		uuid:        uuid,
+		contentRepo:  contentRepo,
+		courseRepo:   courseRepo,
+		progressRepo: progressRepo,
+		articleRepo:  articleRepo,
+		quizRepo:     quizRepo,
+		fileUtil:     fileUtil,
+		uuid:         uuid,
 	}
 }
 
 func (s *courseContentService) CreateVideo(ctx context.Context, courseID uuid.UUID,
 	req dto.CreateCourseVideoRequest) (dto.CreateCourseVideoResponse, error) {
+	if err := validateSection(ctx, s.contentRepo, courseID, req.SectionID); err != nil {
+		return dto.CreateCourseVideoResponse{}, err
+	}
+
 	videoID, err := s.uuid.NewV7()
 	if err != nil {
 		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
@@ -51,11 +80,11 @@ func (s *courseContentService) CreateVideo(ctx context.Context, courseID uuid.UU
 	video := &entity.CourseVideo{
 		ID:          videoID,
 		CourseID:    courseID,
+		SectionID:   req.SectionID,
 		Title:       req.Title,
 		Description: req.Description,
 		Duration:    req.Duration,
 		IsFree:      req.IsFree,
-		Order:       req.Order,
 	}
 
 	err = s.contentRepo.CreateVideo(ctx, video)
@@ -82,7 +111,7 @@ func (s *courseContentService) CreateVideo(ctx context.Context, courseID uuid.UU
 	}
 
 	thumbnailUploadURL, err := s.fileUtil.GetUploadSignedURL(
-		fmt.Sprintf("course_videos/thumbnail/%s", videoID), "application/pdf")
+		fmt.Sprintf("course_videos/thumbnail/%s", videoID), "image/jpeg")
 	if err != nil {
 		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
 			"error":    err,
@@ -108,12 +137,30 @@ func (s *courseContentService) CreateVideo(ctx context.Context, courseID uuid.UU
 }
 
 func (s *courseContentService) UpdateVideo(ctx context.Context, id uuid.UUID, req dto.UpdateCourseVideoRequest) error {
+	if req.SectionID != nil {
+		video, err := s.contentRepo.GetVideoByID(ctx, id)
+		if err != nil {
+			if strings.HasPrefix(err.Error(), "video not found") {
+				return errorpkg.ErrNotFound()
+			}
+			traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+				"error":    err,
+				"video.id": id,
+			}, "Failed to get video")
+			return errorpkg.ErrInternalServer().WithTraceID(traceID)
+		}
+
+		if err = validateSection(ctx, s.contentRepo, video.CourseID, req.SectionID); err != nil {
+			return err
+		}
+	}
+
 	updates := dto.CourseVideoUpdate{
+		SectionID:   sectionUpdate(req.SectionID, req.RemoveSection),
 		Title:       req.Title,
 		Description: req.Description,
 		Duration:    req.Duration,
 		IsFree:      req.IsFree,
-		Order:       req.Order,
 	}
 
 	err := s.contentRepo.UpdateVideo(ctx, id, updates)
@@ -138,7 +185,17 @@ func (s *courseContentService) UpdateVideo(ctx context.Context, id uuid.UUID, re
 }
 
 func (s *courseContentService) DeleteVideo(ctx context.Context, id uuid.UUID) error {
-	err := s.contentRepo.DeleteVideo(ctx, id)
+	// the subtitle rows are deleted along with the video, so get them first to delete their files
+	subtitles, err := s.contentRepo.GetVideoSubtitles(ctx, id)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":    err,
+			"video.id": id,
+		}, "Failed to get video subtitles")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	err = s.contentRepo.DeleteVideo(ctx, id)
 	if err != nil {
 		if strings.HasPrefix(err.Error(), "video not found") {
 			return errorpkg.ErrNotFound()
@@ -169,6 +226,18 @@ func (s *courseContentService) DeleteVideo(ctx context.Context, id uuid.UUID) er
 		// Continue execution, don't return error to client as the database deletion succeeded
 	}
 
+	for _, subtitle := range subtitles {
+		err = s.fileUtil.Delete(ctx, fmt.Sprintf("course_videos/subtitle/%s/%s", id, subtitle.Language))
+		if err != nil {
+			log.Error(ctx, map[string]interface{}{
+				"error":    err,
+				"video.id": id,
+				"language": subtitle.Language,
+			}, "Failed to delete subtitle file")
+			// Continue execution, don't return error to client as the database deletion succeeded
+		}
+	}
+
 	log.Info(ctx, map[string]interface{}{
 		"video.id": id,
 	}, "Video deleted")
@@ -176,9 +245,14 @@ func (s *courseContentService) DeleteVideo(ctx context.Context, id uuid.UUID) er
 	return nil
 }
 
+// GetVideoUploadURLs marks the video and thumbnail as pending, as the new files are not verified until
+// the upload is finalized
 func (s *courseContentService) GetVideoUploadURLs(ctx context.Context, id uuid.UUID) (string, string, error) {
-	// Check if video exists
-	_, err := s.contentRepo.GetVideoByID(ctx, id)
+	pending := enum.AssetStatusPending
+	err := s.contentRepo.UpdateVideo(ctx, id, dto.CourseVideoUpdate{
+		VideoStatus:     &pending,
+		ThumbnailStatus: &pending,
+	})
 	if err != nil {
 		if strings.HasPrefix(err.Error(), "video not found") {
 			return "", "", errorpkg.ErrNotFound()
@@ -186,7 +260,7 @@ func (s *courseContentService) GetVideoUploadURLs(ctx context.Context, id uuid.U
 		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
 			"error":    err,
 			"video.id": id,
-		}, "Failed to get video")
+		}, "Failed to reset video asset status")
 		return "", "", errorpkg.ErrInternalServer().WithTraceID(traceID)
 	}
 
@@ -213,8 +287,184 @@ func (s *courseContentService) GetVideoUploadURLs(ctx context.Context, id uuid.U
 	return videoURL, thumbnailURL, nil
 }
 
+// FinalizeVideoUpload verifies the files uploaded through the signed URLs and records their status
+func (s *courseContentService) FinalizeVideoUpload(ctx context.Context,
+	id uuid.UUID) (enum.AssetStatus, enum.AssetStatus, error) {
+	videoStatus, err := verifyUploadedAsset(ctx, s.fileUtil, fmt.Sprintf("course_videos/video/%s", id),
+		videoContentTypes, maxVideoSize)
+	if err != nil {
+		return "", "", err
+	}
+
+	thumbnailStatus, err := verifyUploadedAsset(ctx, s.fileUtil, fmt.Sprintf("course_videos/thumbnail/%s", id),
+		fileutil.ImageContentTypes, maxImageSize)
+	if err != nil {
+		return "", "", err
+	}
+
+	updates := dto.CourseVideoUpdate{
+		VideoStatus:     &videoStatus,
+		ThumbnailStatus: &thumbnailStatus,
+	}
+
+	err = s.contentRepo.UpdateVideo(ctx, id, updates)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "video not found") {
+			return "", "", errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":    err,
+			"video.id": id,
+		}, "Failed to update video asset status")
+		return "", "", errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"video.id":         id,
+		"video_status":     videoStatus,
+		"thumbnail_status": thumbnailStatus,
+	}, "Video upload finalized")
+
+	return videoStatus, thumbnailStatus, nil
+}
+
+// UploadVideoSubtitle validates the WebVTT track, stores it and indexes its text for the course search.
+// A track uploaded again in the same language replaces the old one.
+func (s *courseContentService) UploadVideoSubtitle(ctx context.Context, videoID uuid.UUID,
+	req dto.UploadCourseVideoSubtitleRequest) (*dto.CourseVideoSubtitleResponse, error) {
+	if req.Subtitle.Size > maxSubtitleSize {
+		return nil, errorpkg.ErrFileTooLarge().WithDetail(
+			fmt.Sprintf("File size is too large (%s). Please upload a file less than %s",
+				fileutil.ByteToAppropriateUnit(req.Subtitle.Size), fileutil.ByteToAppropriateUnit(maxSubtitleSize)))
+	}
+
+	_, err := s.contentRepo.GetVideoByID(ctx, videoID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "video not found") {
+			return nil, errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":    err,
+			"video.id": videoID,
+		}, "Failed to get video")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	file, err := req.Subtitle.Open()
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":    err,
+			"video.id": videoID,
+		}, "Failed to open subtitle file")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":    err,
+			"video.id": videoID,
+		}, "Failed to read subtitle file")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	cues, err := webvtt.Parse(data)
+	if err != nil {
+		return nil, errorpkg.ErrInvalidFileFormat().WithDetail(fmt.Sprintf("Invalid WebVTT subtitle: %s", err))
+	}
+
+	// language tags are case-insensitive, so they are stored lowercased to keep one track per language
+	subtitle := &entity.CourseVideoSubtitle{
+		VideoID:    videoID,
+		Language:   strings.ToLower(req.Language),
+		Label:      req.Label,
+		Transcript: webvtt.Transcript(cues),
+	}
+
+	path := fmt.Sprintf("course_videos/subtitle/%s/%s", videoID, subtitle.Language)
+	// browsers only load a track served as text/vtt
+	if _, err = s.fileUtil.Upload(ctx, bytes.NewReader(data), path, "text/vtt"); err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+			"path":  path,
+		}, "Failed to upload subtitle file")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	err = s.contentRepo.UpsertVideoSubtitle(ctx, subtitle)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "video not found") {
+			return nil, errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":    err,
+			"video.id": videoID,
+			"language": subtitle.Language,
+		}, "Failed to save video subtitle")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	resp := &dto.CourseVideoSubtitleResponse{}
+	resp.PopulateFromEntity(subtitle)
+	resp.URL, err = s.fileUtil.GetSignedURL(path)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+			"path":  path,
+		}, "Failed to sign subtitle URL")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"video.id": videoID,
+		"language": subtitle.Language,
+		"cues":     len(cues),
+	}, "Video subtitle uploaded")
+
+	return resp, nil
+}
+
+func (s *courseContentService) DeleteVideoSubtitle(ctx context.Context, videoID uuid.UUID, language string) error {
+	language = strings.ToLower(language)
+
+	err := s.contentRepo.DeleteVideoSubtitle(ctx, videoID, language)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "subtitle not found") {
+			return errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":    err,
+			"video.id": videoID,
+			"language": language,
+		}, "Failed to delete video subtitle")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	err = s.fileUtil.Delete(ctx, fmt.Sprintf("course_videos/subtitle/%s/%s", videoID, language))
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error":    err,
+			"video.id": videoID,
+			"language": language,
+		}, "Failed to delete subtitle file")
+		// Continue execution, don't return error to client as the database deletion succeeded
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"video.id": videoID,
+		"language": language,
+	}, "Video subtitle deleted")
+
+	return nil
+}
+
 func (s *courseContentService) CreateMaterial(ctx context.Context, courseID uuid.UUID,
 	req dto.CreateCourseMaterialRequest) (dto.CreateCourseMaterialResponse, error) {
+	if err := validateSection(ctx, s.contentRepo, courseID, req.SectionID); err != nil {
+		return dto.CreateCourseMaterialResponse{}, err
+	}
+
 	materialID, err := s.uuid.NewV7()
 	if err != nil {
 		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
@@ -224,12 +474,12 @@ func (s *courseContentService) CreateMaterial(ctx context.Context, courseID uuid
 	}
 
 	material := &entity.CourseMaterial{
-		ID:       materialID,
-		CourseID: courseID,
-		Title:    req.Title,
-		Subtitle: req.Subtitle,
-		IsFree:   req.IsFree,
-		Order:    req.Order,
+		ID:        materialID,
+		CourseID:  courseID,
+		SectionID: req.SectionID,
+		Title:     req.Title,
+		Subtitle:  req.Subtitle,
+		IsFree:    req.IsFree,
 	}
 
 	err = s.contentRepo.CreateMaterial(ctx, material)
@@ -272,11 +522,29 @@ func (s *courseContentService) CreateMaterial(ctx context.Context, courseID uuid
 
 func (s *courseContentService) UpdateCourseMaterial(ctx context.Context, id uuid.UUID,
 	req dto.UpdateCourseMaterialRequest) error {
+	if req.SectionID != nil {
+		material, err := s.contentRepo.GetMaterialByID(ctx, id)
+		if err != nil {
+			if strings.HasPrefix(err.Error(), "material not found") {
+				return errorpkg.ErrNotFound()
+			}
+			traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+				"error":       err,
+				"material.id": id,
+			}, "Failed to get material")
+			return errorpkg.ErrInternalServer().WithTraceID(traceID)
+		}
+
+		if err = validateSection(ctx, s.contentRepo, material.CourseID, req.SectionID); err != nil {
+			return err
+		}
+	}
+
 	updates := dto.CourseMaterialUpdate{
-		Title:    req.Title,
-		Subtitle: req.Subtitle,
-		IsFree:   req.IsFree,
-		Order:    req.Order,
+		SectionID: sectionUpdate(req.SectionID, req.RemoveSection),
+		Title:     req.Title,
+		Subtitle:  req.Subtitle,
+		IsFree:    req.IsFree,
 	}
 
 	err := s.contentRepo.UpdateMaterial(ctx, id, updates)
@@ -330,9 +598,10 @@ func (s *courseContentService) DeleteCourseMaterial(ctx context.Context, id uuid
 	return nil
 }
 
+// GetMaterialUploadURL marks the material as pending, as the new file is not verified until the upload is finalized
 func (s *courseContentService) GetMaterialUploadURL(ctx context.Context, id uuid.UUID) (string, error) {
-	// Check if material exists
-	_, err := s.contentRepo.GetMaterialByID(ctx, id)
+	pending := enum.AssetStatusPending
+	err := s.contentRepo.UpdateMaterial(ctx, id, dto.CourseMaterialUpdate{MaterialStatus: &pending})
 	if err != nil {
 		if strings.HasPrefix(err.Error(), "material not found") {
 			return "", errorpkg.ErrNotFound()
@@ -340,7 +609,7 @@ func (s *courseContentService) GetMaterialUploadURL(ctx context.Context, id uuid
 		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
 			"error":       err,
 			"material.id": id,
-		}, "Failed to get material")
+		}, "Failed to reset material asset status")
 		return "", errorpkg.ErrInternalServer().WithTraceID(traceID)
 	}
 
@@ -357,8 +626,36 @@ func (s *courseContentService) GetMaterialUploadURL(ctx context.Context, id uuid
 	return url, nil
 }
 
+// FinalizeMaterialUpload verifies the file uploaded through the signed URL and records its status
+func (s *courseContentService) FinalizeMaterialUpload(ctx context.Context, id uuid.UUID) (enum.AssetStatus, error) {
+	status, err := verifyUploadedAsset(ctx, s.fileUtil, fmt.Sprintf("course_materials/material/%s", id),
+		materialContentTypes, maxMaterialSize)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.contentRepo.UpdateMaterial(ctx, id, dto.CourseMaterialUpdate{MaterialStatus: &status})
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "material not found") {
+			return "", errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":       err,
+			"material.id": id,
+		}, "Failed to update material asset status")
+		return "", errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"material.id":     id,
+		"material_status": status,
+	}, "Material upload finalized")
+
+	return status, nil
+}
+
 func (s *courseContentService) GetCourseContents(ctx context.Context,
-	courseID uuid.UUID) ([]*dto.CourseContentResponse, error) {
+	courseID uuid.UUID) (*dto.CourseContentsResponse, error) {
 	userID, ok := ctx.Value(ctxkey.UserID).(uuid.UUID)
 	isSubscribedBoost, ok2 := ctx.Value(ctxkey.IsSubscribedBoost).(bool)
 	if !ok || !ok2 {
@@ -366,8 +663,8 @@ func (s *courseContentService) GetCourseContents(ctx context.Context,
 		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
 	}
 
-	isEnrolled := true
-	_, err := s.courseRepo.GetEnrollment(ctx, courseID, userID)
+	isEnrolled, isPurchased := true, false
+	enrollment, err := s.courseRepo.GetEnrollment(ctx, courseID, userID)
 	if err != nil {
 		if strings.HasPrefix(err.Error(), "enrollment not found") {
 			isEnrolled = false
@@ -379,12 +676,20 @@ func (s *courseContentService) GetCourseContents(ctx context.Context,
 		}, "Failed to get enrollment")
 		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
 	}
+	isPurchased = enrollment.IsPurchased
 
 pass:
-	isRestricted := !(isEnrolled && isSubscribedBoost)
+	if !isEnrolled {
+		if err = checkCourseVisible(ctx, s.courseRepo, courseID); err != nil {
+			return nil, err
+		}
+	}
+
+	// purchased courses stay accessible without an active Skill Boost subscription
+	isRestricted := !(isEnrolled && (isSubscribedBoost || isPurchased))
 
-	// (both are already sorted by order)
-	videos, materials, err := s.contentRepo.GetCourseContents(ctx, courseID)
+	// (all are already sorted by order)
+	sections, videos, materials, err := s.contentRepo.GetCourseContents(ctx, courseID)
 	if err != nil {
 		if strings.HasPrefix(err.Error(), "course not found") {
 			return nil, errorpkg.ErrValidation().WithDetail("Course not found")
@@ -396,69 +701,407 @@ pass:
 		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
 	}
 
-	totalContents := len(videos) + len(materials)
-	responses := make([]*dto.CourseContentResponse, totalContents)
+	articles, err := s.articleRepo.GetArticlesByCourseID(ctx, courseID)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":     err,
+			"course.id": courseID,
+		}, "Failed to get course articles")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	quizzes, err := s.quizRepo.GetQuizzesByCourseID(ctx, courseID)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":     err,
+			"course.id": courseID,
+		}, "Failed to get course quizzes")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
 
-	videoIdx, materialIdx, resultIdx := 0, 0, 0
+	contents, err := s.mergeContents(ctx, videos, materials, articles, quizzes, isRestricted)
+	if err != nil {
+		return nil, err
+	}
 
-	for videoIdx < len(videos) && materialIdx < len(materials) {
-		// Compare orders and process the one with smaller order first
-		if videos[videoIdx].Order <= materials[materialIdx].Order {
-			responses[resultIdx] = &dto.CourseContentResponse{}
-			err = responses[resultIdx].PopulateFromCourseVideo(videos[videoIdx], isRestricted, s.fileUtil.GetSignedURL)
-			if err != nil {
-				traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
-					"error":    err,
-					"video.id": videos[videoIdx].ID,
-				}, "Failed to populate video response")
-				return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
-			}
-			videoIdx++
-		} else {
-			responses[resultIdx] = &dto.CourseContentResponse{}
-			err = responses[resultIdx].PopulateFromCourseMaterial(materials[materialIdx], isRestricted,
-				s.fileUtil.GetSignedURL)
-			if err != nil {
-				traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
-					"error":       err,
-					"material.id": materials[materialIdx].ID,
-				}, "Failed to populate material response")
-				return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	resp := &dto.CourseContentsResponse{
+		Sections: make([]*dto.CourseSectionResponse, len(sections)),
+		Contents: contents,
+	}
+
+	sectionByID := make(map[uuid.UUID]*dto.CourseSectionResponse, len(sections))
+	for i, section := range sections {
+		resp.Sections[i] = &dto.CourseSectionResponse{}
+		resp.Sections[i].PopulateFromEntity(section)
+		sectionByID[section.ID] = resp.Sections[i]
+	}
+
+	for _, content := range contents {
+		if content.SectionID == nil {
+			continue
+		}
+
+		// a section deleted between the queries is left out
+		section, ok := sectionByID[*content.SectionID]
+		if !ok {
+			continue
+		}
+
+		section.Contents = append(section.Contents, content)
+		section.ContentCount++
+	}
+
+	if isEnrolled {
+		progresses, err := s.progressRepo.GetSectionProgresses(ctx, courseID, userID)
+		if err != nil {
+			traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+				"error":     err,
+				"course.id": courseID,
+			}, "Failed to get section progresses")
+			return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+		}
+
+		completedBySection := make(map[uuid.UUID]int, len(progresses))
+		for _, progress := range progresses {
+			if progress.SectionID != nil {
+				completedBySection[*progress.SectionID] = progress.ContentCompleted
 			}
-			materialIdx++
 		}
-		resultIdx++
+
+		for _, section := range resp.Sections {
+			completed := completedBySection[section.ID]
+			section.ContentCompleted = &completed
+		}
+	}
+
+	return resp, nil
+}
+
+// mergeContents merges the videos, materials, articles and quizzes into one list sorted by order.
+// On equal order, they keep that order of types.
+func (s *courseContentService) mergeContents(ctx context.Context, videos []*entity.CourseVideo,
+	materials []*entity.CourseMaterial, articles []*entity.CourseArticle, quizzes []*entity.CourseQuiz,
+	isRestricted bool) ([]*dto.CourseContentResponse, error) {
+	type orderedContent struct {
+		order    int
+		response *dto.CourseContentResponse
+	}
+
+	var paths []string
+	for _, video := range videos {
+		paths = append(paths, dto.CourseVideoAssetPaths(video, isRestricted)...)
+	}
+	for _, material := range materials {
+		paths = append(paths, dto.CourseMaterialAssetPaths(material, isRestricted)...)
 	}
 
-	// Process remaining
-	for videoIdx < len(videos) {
-		responses[resultIdx] = &dto.CourseContentResponse{}
-		err = responses[resultIdx].PopulateFromCourseVideo(videos[videoIdx], isRestricted, s.fileUtil.GetSignedURL)
+	urlSigner, err := batchURLSigner(s.fileUtil, paths)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+			"paths": paths,
+		}, "Failed to sign course content URLs")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	contents := make([]orderedContent, 0, len(videos)+len(materials)+len(articles)+len(quizzes))
+
+	for _, video := range videos {
+		response := &dto.CourseContentResponse{}
+		err := response.PopulateFromCourseVideo(video, isRestricted, urlSigner)
 		if err != nil {
 			traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
 				"error":    err,
-				"video.id": videos[videoIdx].ID,
+				"video.id": video.ID,
 			}, "Failed to populate video response")
 			return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
 		}
-		videoIdx++
-		resultIdx++
+		contents = append(contents, orderedContent{order: video.Order, response: response})
 	}
 
-	for materialIdx < len(materials) {
-		responses[resultIdx] = &dto.CourseContentResponse{}
-		err = responses[resultIdx].PopulateFromCourseMaterial(materials[materialIdx], isRestricted,
-			s.fileUtil.GetSignedURL)
+	for _, material := range materials {
+		response := &dto.CourseContentResponse{}
+		err := response.PopulateFromCourseMaterial(material, isRestricted, urlSigner)
 		if err != nil {
 			traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
 				"error":       err,
-				"material.id": materials[materialIdx].ID,
+				"material.id": material.ID,
 			}, "Failed to populate material response")
 			return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
 		}
-		materialIdx++
-		resultIdx++
+		contents = append(contents, orderedContent{order: material.Order, response: response})
+	}
+
+	for _, article := range articles {
+		response := &dto.CourseContentResponse{}
+		response.PopulateFromCourseArticle(article)
+		contents = append(contents, orderedContent{order: article.Order, response: response})
+	}
+
+	for _, quiz := range quizzes {
+		response := &dto.CourseContentResponse{}
+		response.PopulateFromCourseQuiz(quiz)
+		contents = append(contents, orderedContent{order: quiz.Order, response: response})
+	}
+
+	sort.SliceStable(contents, func(i, j int) bool {
+		return contents[i].order < contents[j].order
+	})
+
+	responses := make([]*dto.CourseContentResponse, len(contents))
+	for i, content := range contents {
+		responses[i] = content.response
 	}
 
 	return responses, nil
 }
+
+func (s *courseContentService) CreateSection(ctx context.Context, courseID uuid.UUID,
+	req dto.CreateCourseSectionRequest) (*dto.CourseSectionResponse, error) {
+	sectionID, err := s.uuid.NewV7()
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+		}, "Failed to generate section ID")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	section := &entity.CourseSection{
+		ID:          sectionID,
+		CourseID:    courseID,
+		Title:       req.Title,
+		Description: req.Description,
+		Order:       req.Order,
+	}
+
+	err = s.contentRepo.CreateSection(ctx, section)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "course not found") {
+			return nil, errorpkg.ErrValidation().WithDetail("Course not found")
+		}
+		if strings.HasPrefix(err.Error(), "section order taken") {
+			return nil, errorpkg.ErrSectionOrderTaken()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"section": section,
+		}, "Failed to create section")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"section": section,
+	}, "Section created")
+
+	resp := &dto.CourseSectionResponse{}
+	resp.PopulateFromEntity(section)
+
+	return resp, nil
+}
+
+func (s *courseContentService) UpdateSection(ctx context.Context, id uuid.UUID,
+	req dto.UpdateCourseSectionRequest) error {
+	updates := dto.CourseSectionUpdate{
+		Title:       req.Title,
+		Description: req.Description,
+		Order:       req.Order,
+	}
+
+	err := s.contentRepo.UpdateSection(ctx, id, updates)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "section not found") {
+			return errorpkg.ErrNotFound()
+		}
+		if strings.HasPrefix(err.Error(), "section order taken") {
+			return errorpkg.ErrSectionOrderTaken()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"section.id": id,
+			"updates":    updates,
+		}, "Failed to update section")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"section.id": id,
+		"updates":    updates,
+	}, "Section updated")
+
+	return nil
+}
+
+func (s *courseContentService) DeleteSection(ctx context.Context, id uuid.UUID) error {
+	err := s.contentRepo.DeleteSection(ctx, id)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "section not found") {
+			return errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"section.id": id,
+		}, "Failed to delete section")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"section.id": id,
+	}, "Section deleted")
+
+	return nil
+}
+
+func (s *courseContentService) ReorderContents(ctx context.Context, courseID uuid.UUID,
+	req dto.ReorderCourseContentsRequest) error {
+	err := s.contentRepo.ReorderContents(ctx, courseID, req.ContentIDs)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "course not found") {
+			return errorpkg.ErrNotFound().WithDetail("Course not found")
+		}
+		if strings.HasPrefix(err.Error(), "content list mismatch") {
+			return errorpkg.ErrValidation().WithDetail("The list must contain every content of the course exactly once")
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":     err,
+			"course.id": courseID,
+		}, "Failed to reorder contents")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"course.id":   courseID,
+		"content.ids": req.ContentIDs,
+	}, "Contents reordered")
+
+	return nil
+}
+
+// validateSection checks that the section, if any, belongs to the course
+func validateSection(ctx context.Context, contentRepo contract.ICourseContentRepository, courseID uuid.UUID,
+	sectionID *uuid.UUID) error {
+	if sectionID == nil {
+		return nil
+	}
+
+	section, err := contentRepo.GetSectionByID(ctx, *sectionID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "section not found") {
+			return errorpkg.ErrValidation().WithDetail("Section not found")
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"section.id": sectionID,
+		}, "Failed to get section")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	if section.CourseID != courseID {
+		return errorpkg.ErrValidation().WithDetail("Section not found")
+	}
+
+	return nil
+}
+
+// sectionUpdate returns the section_id update of a content. A nil sectionID leaves the section as is,
+// and removeSection sets it to NULL.
+func sectionUpdate(sectionID *uuid.UUID, removeSection bool) *uuid.NullUUID {
+	if removeSection {
+		return &uuid.NullUUID{}
+	}
+	if sectionID == nil {
+		return nil
+	}
+	return &uuid.NullUUID{UUID: *sectionID, Valid: true}
+}
+
+// verifyUploadedAsset inspects a file uploaded directly to the storage through a signed URL. It stays pending
+// until the file is uploaded, and becomes invalid when the file is empty, too large or of another content type.
+func verifyUploadedAsset(ctx context.Context, fileUtil fileutil.IFileUtil, path string, contentTypes []string,
+	maxSize int64) (enum.AssetStatus, error) {
+	attrs, err := fileUtil.GetAttributes(ctx, path)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+			"path":  path,
+		}, "Failed to get uploaded file attributes")
+		return "", errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	if attrs == nil {
+		return enum.AssetStatusPending, nil
+	}
+
+	contentType, _, _ := strings.Cut(attrs.ContentType, ";")
+	if attrs.Size == 0 || attrs.Size > maxSize || !slices.Contains(contentTypes, strings.TrimSpace(contentType)) {
+		log.Warn(ctx, map[string]interface{}{
+			"path":         path,
+			"size":         attrs.Size,
+			"content_type": attrs.ContentType,
+		}, "Uploaded file is invalid")
+		return enum.AssetStatusInvalid, nil
+	}
+
+	return enum.AssetStatusReady, nil
+}
+
+// getContentAccess reports whether the user is enrolled in the course and whether they can access
+// a content of it, following the same rules as GetCourseContents
+func getContentAccess(ctx context.Context, courseRepo contract.ICourseRepository, courseID uuid.UUID,
+	isFree bool) (bool, bool, error) {
+	userID, ok := ctx.Value(ctxkey.UserID).(uuid.UUID)
+	isSubscribedBoost, ok2 := ctx.Value(ctxkey.IsSubscribedBoost).(bool)
+	if !ok || !ok2 {
+		traceID := log.ErrorWithTraceID(ctx, nil, "Failed to get user ID or subscription status from context")
+		return false, false, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	enrollment, err := courseRepo.GetEnrollment(ctx, courseID, userID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "enrollment not found") {
+			if err = checkCourseVisible(ctx, courseRepo, courseID); err != nil {
+				return false, false, err
+			}
+			return false, isFree, nil
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":     err,
+			"course.id": courseID,
+		}, "Failed to get enrollment")
+		return false, false, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	// purchased courses stay accessible without an active Skill Boost subscription
+	return true, isFree || isSubscribedBoost || enrollment.IsPurchased, nil
+}
+
+// checkCourseVisible hides courses that are not published from everyone but admins. It is only meant for users
+// who are not enrolled, since enrolled students keep their access when a course gets archived.
+func checkCourseVisible(ctx context.Context, courseRepo contract.ICourseRepository, courseID uuid.UUID) error {
+	role, ok := ctx.Value(ctxkey.UserRole).(enum.UserRole)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx, nil, "Failed to get user role from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	if role == enum.UserRoleAdmin {
+		return nil
+	}
+
+	course, err := courseRepo.GetCourseByID(ctx, courseID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "course not found") {
+			return errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":     err,
+			"course.id": courseID,
+		}, "Failed to get course")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	if course.Status != enum.CourseStatusPublished {
+		return errorpkg.ErrNotFound()
+	}
+
+	return nil
+}
@@ -0,0 +1,361 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/contract"
+	"github.com/nathakusuma/elevateu-backend/domain/dto"
+	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/domain/errorpkg"
+	"github.com/nathakusuma/elevateu-backend/pkg/log"
+	"github.com/nathakusuma/elevateu-backend/pkg/uuidpkg"
+)
+
+type courseNoteService struct {
+	noteRepo    contract.ICourseNoteRepository
+	contentRepo contract.ICourseContentRepository
+	courseRepo  contract.ICourseRepository
+	uuid        uuidpkg.IUUID
+}
+
+func NewCourseNoteService(
+	noteRepo contract.ICourseNoteRepository,
+	contentRepo contract.ICourseContentRepository,
+	courseRepo contract.ICourseRepository,
+	uuid uuidpkg.IUUID,
+) contract.ICourseNoteService {
+	return &courseNoteService{
+		noteRepo:    noteRepo,
+		contentRepo: contentRepo,
+		courseRepo:  courseRepo,
+		uuid:        uuid,
+	}
+}
+
+func (s *courseNoteService) CreateVideoNote(ctx context.Context, studentID, videoID uuid.UUID,
+	req dto.CreateCourseVideoNoteRequest) (*dto.CourseNoteResponse, error) {
+	video, err := s.contentRepo.GetVideoByID(ctx, videoID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "video not found") {
+			return nil, errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":    err,
+			"video.id": videoID,
+		}, "Failed to get video")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	if err = checkNoteAccess(ctx, s.courseRepo, video.CourseID, video.IsFree); err != nil {
+		return nil, err
+	}
+
+	if *req.Timestamp > video.Duration {
+		return nil, errorpkg.ErrValidation().WithDetail("Timestamp must not be after the end of the video")
+	}
+
+	noteID, err := s.uuid.NewV7()
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+		}, "Failed to generate note ID")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	note := &entity.CourseNote{
+		ID:           noteID,
+		StudentID:    studentID,
+		CourseID:     video.CourseID,
+		VideoID:      &video.ID,
+		Timestamp:    req.Timestamp,
+		Body:         req.Body,
+		ContentTitle: video.Title,
+	}
+
+	if err = s.noteRepo.CreateNote(ctx, note); err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":    err,
+			"video.id": videoID,
+		}, "Failed to create note")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"note.id":  note.ID,
+		"video.id": videoID,
+	}, "Video note created")
+
+	resp := &dto.CourseNoteResponse{}
+	resp.PopulateFromEntity(note)
+
+	return resp, nil
+}
+
+func (s *courseNoteService) BookmarkMaterial(ctx context.Context, studentID, materialID uuid.UUID,
+	req dto.BookmarkCourseMaterialRequest) (*dto.CourseNoteResponse, error) {
+	material, err := s.contentRepo.GetMaterialByID(ctx, materialID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "material not found") {
+			return nil, errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":       err,
+			"material.id": materialID,
+		}, "Failed to get material")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	if err = checkNoteAccess(ctx, s.courseRepo, material.CourseID, material.IsFree); err != nil {
+		return nil, err
+	}
+
+	noteID, err := s.uuid.NewV7()
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+		}, "Failed to generate note ID")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	note := &entity.CourseNote{
+		ID:           noteID,
+		StudentID:    studentID,
+		CourseID:     material.CourseID,
+		MaterialID:   &material.ID,
+		Body:         req.Body,
+		ContentTitle: material.Title,
+	}
+
+	if err = s.noteRepo.UpsertMaterialBookmark(ctx, note); err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":       err,
+			"material.id": materialID,
+		}, "Failed to bookmark material")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	resp := &dto.CourseNoteResponse{}
+	resp.PopulateFromEntity(note)
+
+	return resp, nil
+}
+
+func (s *courseNoteService) UpdateNote(ctx context.Context, studentID, id uuid.UUID,
+	req dto.UpdateCourseNoteRequest) error {
+	note, err := s.getOwnNote(ctx, studentID, id)
+	if err != nil {
+		return err
+	}
+
+	if req.Timestamp != nil && note.VideoID == nil {
+		return errorpkg.ErrValidation().WithDetail("Only notes on videos have a timestamp")
+	}
+
+	if req.Body != nil && *req.Body == "" && note.VideoID != nil {
+		return errorpkg.ErrValidation().WithDetail("Notes on videos must have a body")
+	}
+
+	if req.Timestamp != nil {
+		video, err := s.contentRepo.GetVideoByID(ctx, *note.VideoID)
+		if err != nil {
+			if strings.HasPrefix(err.Error(), "video not found") {
+				return errorpkg.ErrNotFound()
+			}
+			traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+				"error":    err,
+				"video.id": *note.VideoID,
+			}, "Failed to get video")
+			return errorpkg.ErrInternalServer().WithTraceID(traceID)
+		}
+
+		if *req.Timestamp > video.Duration {
+			return errorpkg.ErrValidation().WithDetail("Timestamp must not be after the end of the video")
+		}
+	}
+
+	updates := dto.CourseNoteUpdate{
+		Timestamp: req.Timestamp,
+		Body:      req.Body,
+	}
+
+	err = s.noteRepo.UpdateNote(ctx, id, updates)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "note not found") {
+			return errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"note.id": id,
+		}, "Failed to update note")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	return nil
+}
+
+func (s *courseNoteService) DeleteNote(ctx context.Context, studentID, id uuid.UUID) error {
+	if _, err := s.getOwnNote(ctx, studentID, id); err != nil {
+		return err
+	}
+
+	err := s.noteRepo.DeleteNote(ctx, id)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "note not found") {
+			return errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"note.id": id,
+		}, "Failed to delete note")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	return nil
+}
+
+func (s *courseNoteService) GetCourseNotes(ctx context.Context, studentID,
+	courseID uuid.UUID) ([]*dto.CourseNoteResponse, error) {
+	notes, err := s.getCourseNotes(ctx, studentID, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*dto.CourseNoteResponse, len(notes))
+	for i, note := range notes {
+		resp[i] = &dto.CourseNoteResponse{}
+		resp[i].PopulateFromEntity(note)
+	}
+
+	return resp, nil
+}
+
+// ExportCourseNotes renders the notes of the student in the course as a Markdown document,
+// with a heading for each content in the course order
+func (s *courseNoteService) ExportCourseNotes(ctx context.Context, studentID, courseID uuid.UUID) ([]byte, error) {
+	notes, err := s.getCourseNotes(ctx, studentID, courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	course, err := s.courseRepo.GetCourseByID(ctx, courseID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "course not found") {
+			return nil, errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":     err,
+			"course.id": courseID,
+		}, "Failed to get course")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# Notes: %s\n", course.Title)
+
+	var lastContentID *uuid.UUID
+	for _, note := range notes {
+		contentID := note.VideoID
+		if contentID == nil {
+			contentID = note.MaterialID
+		}
+
+		if lastContentID == nil || *lastContentID != *contentID {
+			if note.MaterialID != nil {
+				fmt.Fprintf(&buf, "\n## %s (bookmarked)\n\n", note.ContentTitle)
+			} else {
+				fmt.Fprintf(&buf, "\n## %s\n\n", note.ContentTitle)
+			}
+			lastContentID = contentID
+		}
+
+		if note.Timestamp != nil {
+			// indent the following lines so multi-line notes stay in their list item
+			body := strings.ReplaceAll(note.Body, "\n", "\n  ")
+			fmt.Fprintf(&buf, "- **[%s]** %s\n", formatNoteTimestamp(*note.Timestamp), body)
+		} else if note.Body != "" {
+			fmt.Fprintf(&buf, "%s\n", note.Body)
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (s *courseNoteService) getOwnNote(ctx context.Context, studentID, id uuid.UUID) (*entity.CourseNote, error) {
+	note, err := s.noteRepo.GetNoteByID(ctx, id)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "note not found") {
+			return nil, errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"note.id": id,
+		}, "Failed to get note")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	if note.StudentID != studentID {
+		return nil, errorpkg.ErrForbiddenUser().WithDetail("You can only manage your own notes")
+	}
+
+	return note, nil
+}
+
+// getCourseNotes returns the notes of the student in the course. Only enrolled students can take notes,
+// so the others get ErrNotEnrolled instead of an empty list.
+func (s *courseNoteService) getCourseNotes(ctx context.Context, studentID,
+	courseID uuid.UUID) ([]*entity.CourseNote, error) {
+	_, err := s.courseRepo.GetEnrollment(ctx, courseID, studentID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "enrollment not found") {
+			return nil, errorpkg.ErrNotEnrolled()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":     err,
+			"course.id": courseID,
+		}, "Failed to get enrollment")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	notes, err := s.noteRepo.GetNotesByCourseID(ctx, courseID, studentID)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":     err,
+			"course.id": courseID,
+		}, "Failed to get notes")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	return notes, nil
+}
+
+// checkNoteAccess only lets the students take notes on the contents they can access in the courses
+// they are enrolled in
+func checkNoteAccess(ctx context.Context, courseRepo contract.ICourseRepository, courseID uuid.UUID,
+	isFree bool) error {
+	isEnrolled, canAccess, err := getContentAccess(ctx, courseRepo, courseID, isFree)
+	if err != nil {
+		return err
+	}
+
+	if !isEnrolled {
+		return errorpkg.ErrNotEnrolled()
+	}
+
+	if !canAccess {
+		return errorpkg.ErrNotSubscribed()
+	}
+
+	return nil
+}
+
+func formatNoteTimestamp(seconds int) string {
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
@@ -184,3 +184,79 @@ func (s *courseProgressService) UpdateMaterialProgress(ctx context.Context, stud
 
 	return nil
 }
+
+func (s *courseProgressService) UpdateArticleProgress(ctx context.Context, studentID uuid.UUID,
+	articleID uuid.UUID) error {
+	tx, err := s.txManager.BeginTx(ctx)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+		}, "Failed to begin transaction")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+	defer tx.Rollback()
+
+	courseID, err := s.repo.GetContentCourseID(ctx, articleID, "article")
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "course content not found") {
+			return errorpkg.ErrValidation().WithDetail("Article not found")
+		}
+
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"article.id": articleID,
+		}, "Failed to get course ID for article")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	progress := entity.CourseArticleProgress{
+		StudentID: studentID,
+		ArticleID: articleID,
+	}
+
+	newlyCompleted, err := s.repo.UpdateArticleProgress(ctx, tx, progress)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"article.id": articleID,
+		}, "Failed to update article progress")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	// If the article was newly completed, update the course progress
+	if newlyCompleted {
+		courseCompleted, err := s.repo.IncrementCourseProgress(ctx, tx, courseID, studentID)
+		if err != nil {
+			traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+				"error":     err,
+				"course.id": courseID,
+			}, "Failed to update course progress")
+			return errorpkg.ErrInternalServer().WithTraceID(traceID)
+		}
+
+		// If the course was just completed, add points to the student
+		if courseCompleted {
+			if err = s.userRepo.AddPoint(ctx, tx, studentID, 50); err != nil {
+				traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+					"error":      err,
+					"student.id": studentID,
+				}, "Failed to add points to student")
+				return errorpkg.ErrInternalServer().WithTraceID(traceID)
+			}
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+		}, "Failed to commit transaction")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"article.id": articleID,
+	}, "Article progress updated")
+
+	return nil
+}
@@ -0,0 +1,561 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/contract"
+	"github.com/nathakusuma/elevateu-backend/domain/ctxkey"
+	"github.com/nathakusuma/elevateu-backend/domain/dto"
+	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
+	"github.com/nathakusuma/elevateu-backend/domain/errorpkg"
+	"github.com/nathakusuma/elevateu-backend/internal/infra/database"
+	"github.com/nathakusuma/elevateu-backend/pkg/log"
+	"github.com/nathakusuma/elevateu-backend/pkg/uuidpkg"
+)
+
+type courseQuizService struct {
+	quizRepo     contract.ICourseQuizRepository
+	contentRepo  contract.ICourseContentRepository
+	courseRepo   contract.ICourseRepository
+	progressRepo contract.ICourseProgressRepository
+	userRepo     contract.IUserRepository
+	txManager    database.ITransactionManager
+	uuid         uuidpkg.IUUID
+}
+
+func NewCourseQuizService(
+	quizRepo contract.ICourseQuizRepository,
+	contentRepo contract.ICourseContentRepository,
+	courseRepo contract.ICourseRepository,
+	progressRepo contract.ICourseProgressRepository,
+	userRepo contract.IUserRepository,
+	txManager database.ITransactionManager,
+	uuid uuidpkg.IUUID,
+) contract.ICourseQuizService {
+	return &courseQuizService{
+		quizRepo:     quizRepo,
+		contentRepo:  contentRepo,
+		courseRepo:   courseRepo,
+		progressRepo: progressRepo,
+		userRepo:     userRepo,
+		txManager:    txManager,
+		uuid:         uuid,
+	}
+}
+
+func (s *courseQuizService) CreateQuiz(ctx context.Context, courseID uuid.UUID,
+	req dto.CreateCourseQuizRequest) (*dto.CourseQuizResponse, error) {
+	if err := validateSection(ctx, s.contentRepo, courseID, req.SectionID); err != nil {
+		return nil, err
+	}
+
+	quizID, err := s.uuid.NewV7()
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+		}, "Failed to generate quiz ID")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	questions, err := s.buildQuestions(ctx, quizID, req.Questions)
+	if err != nil {
+		return nil, err
+	}
+
+	quiz := &entity.CourseQuiz{
+		ID:           quizID,
+		CourseID:     courseID,
+		SectionID:    req.SectionID,
+		Title:        req.Title,
+		Description:  req.Description,
+		PassingScore: req.PassingScore,
+		MaxAttempts:  req.MaxAttempts,
+		IsFree:       req.IsFree,
+		Questions:    questions,
+	}
+
+	err = s.quizRepo.CreateQuiz(ctx, quiz)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "course not found") {
+			return nil, errorpkg.ErrValidation().WithDetail("Course not found")
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":     err,
+			"course.id": courseID,
+		}, "Failed to create quiz")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"quiz.id":   quiz.ID,
+		"course.id": courseID,
+	}, "Quiz created")
+
+	resp := &dto.CourseQuizResponse{}
+	resp.PopulateFromEntity(quiz, true)
+
+	return resp, nil
+}
+
+// GetQuiz returns the quiz with its questions. Only admins get the correct answers.
+func (s *courseQuizService) GetQuiz(ctx context.Context, id uuid.UUID) (*dto.CourseQuizResponse, error) {
+	quiz, err := s.getQuiz(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	role, ok := ctx.Value(ctxkey.UserRole).(enum.UserRole)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx, nil, "Failed to get user role from context")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	isAdmin := role == enum.UserRoleAdmin
+	if !isAdmin {
+		_, canAccess, err := getContentAccess(ctx, s.courseRepo, quiz.CourseID, quiz.IsFree)
+		if err != nil {
+			return nil, err
+		}
+
+		if !canAccess {
+			return nil, errorpkg.ErrNotSubscribed()
+		}
+	}
+
+	resp := &dto.CourseQuizResponse{}
+	resp.PopulateFromEntity(quiz, isAdmin)
+
+	return resp, nil
+}
+
+func (s *courseQuizService) UpdateQuiz(ctx context.Context, id uuid.UUID, req dto.UpdateCourseQuizRequest) error {
+	if req.SectionID != nil {
+		quiz, err := s.getQuiz(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if err = validateSection(ctx, s.contentRepo, quiz.CourseID, req.SectionID); err != nil {
+			return err
+		}
+	}
+
+	updates := dto.CourseQuizUpdate{
+		SectionID:    sectionUpdate(req.SectionID, req.RemoveSection),
+		Title:        req.Title,
+		Description:  req.Description,
+		PassingScore: req.PassingScore,
+		MaxAttempts:  req.MaxAttempts,
+		IsFree:       req.IsFree,
+	}
+
+	err := s.quizRepo.UpdateQuiz(ctx, id, updates)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "quiz not found") {
+			return errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"quiz.id": id,
+			"updates": updates,
+		}, "Failed to update quiz")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"quiz.id": id,
+		"updates": updates,
+	}, "Quiz updated")
+
+	return nil
+}
+
+func (s *courseQuizService) ReplaceQuestions(ctx context.Context, id uuid.UUID,
+	req dto.ReplaceCourseQuizQuestionsRequest) error {
+	questions, err := s.buildQuestions(ctx, id, req.Questions)
+	if err != nil {
+		return err
+	}
+
+	err = s.quizRepo.ReplaceQuestions(ctx, id, questions)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "quiz not found") {
+			return errorpkg.ErrNotFound()
+		}
+		if strings.HasPrefix(err.Error(), "quiz has attempts") {
+			return errorpkg.ErrQuizHasAttempts()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"quiz.id": id,
+		}, "Failed to replace quiz questions")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"quiz.id": id,
+	}, "Quiz questions replaced")
+
+	return nil
+}
+
+func (s *courseQuizService) DeleteQuiz(ctx context.Context, id uuid.UUID) error {
+	err := s.quizRepo.DeleteQuiz(ctx, id)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "quiz not found") {
+			return errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"quiz.id": id,
+		}, "Failed to delete quiz")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"quiz.id": id,
+	}, "Quiz deleted")
+
+	return nil
+}
+
+func (s *courseQuizService) SubmitAttempt(ctx context.Context, studentID, quizID uuid.UUID,
+	req dto.SubmitCourseQuizRequest) (*dto.CourseQuizAttemptResponse, error) {
+	quiz, err := s.getQuiz(ctx, quizID)
+	if err != nil {
+		return nil, err
+	}
+
+	isEnrolled, canAccess, err := getContentAccess(ctx, s.courseRepo, quiz.CourseID, quiz.IsFree)
+	if err != nil {
+		return nil, err
+	}
+	if !isEnrolled {
+		return nil, errorpkg.ErrNotEnrolled()
+	}
+	if !canAccess {
+		return nil, errorpkg.ErrNotSubscribed()
+	}
+
+	attemptID, err := s.uuid.NewV7()
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+		}, "Failed to generate attempt ID")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	attempt, err := gradeAttempt(quiz, req.Answers)
+	if err != nil {
+		return nil, err
+	}
+	attempt.ID = attemptID
+	attempt.StudentID = studentID
+	for _, answer := range attempt.Answers {
+		answer.AttemptID = attemptID
+	}
+
+	tx, err := s.txManager.BeginTx(ctx)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+		}, "Failed to begin transaction")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+	defer tx.Rollback()
+
+	attemptCount, err := s.quizRepo.CountAttempts(ctx, tx, quizID, studentID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "enrollment not found") {
+			return nil, errorpkg.ErrNotEnrolled()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"quiz.id": quizID,
+		}, "Failed to count quiz attempts")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	if quiz.MaxAttempts > 0 && attemptCount >= quiz.MaxAttempts {
+		return nil, errorpkg.ErrQuizAttemptLimitReached()
+	}
+
+	if err = s.quizRepo.CreateAttempt(ctx, tx, attempt); err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"quiz.id": quizID,
+		}, "Failed to create quiz attempt")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	// A passed quiz counts as completed content
+	if attempt.IsPassed {
+		progress := entity.CourseQuizProgress{
+			StudentID: studentID,
+			QuizID:    quizID,
+		}
+
+		newlyCompleted, err := s.progressRepo.UpdateQuizProgress(ctx, tx, progress)
+		if err != nil {
+			traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+				"error":   err,
+				"quiz.id": quizID,
+			}, "Failed to update quiz progress")
+			return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+		}
+
+		if newlyCompleted {
+			courseCompleted, err := s.progressRepo.IncrementCourseProgress(ctx, tx, quiz.CourseID, studentID)
+			if err != nil {
+				traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+					"error":     err,
+					"course.id": quiz.CourseID,
+				}, "Failed to update course progress")
+				return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+			}
+
+			if courseCompleted {
+				if err = s.userRepo.AddPoint(ctx, tx, studentID, 50); err != nil {
+					traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+						"error":      err,
+						"student.id": studentID,
+					}, "Failed to add points to student")
+					return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+				}
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+		}, "Failed to commit transaction")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"quiz.id":    quizID,
+		"attempt.id": attempt.ID,
+		"score":      attempt.Score,
+		"is_passed":  attempt.IsPassed,
+	}, "Quiz attempt submitted")
+
+	resp := &dto.CourseQuizAttemptResponse{}
+	resp.PopulateFromEntity(attempt)
+
+	return resp, nil
+}
+
+func (s *courseQuizService) GetAttempts(ctx context.Context, studentID,
+	quizID uuid.UUID) (*dto.CourseQuizAttemptsResponse, error) {
+	quiz, err := s.getQuiz(ctx, quizID)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts, err := s.quizRepo.GetAttempts(ctx, quizID, studentID)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"quiz.id": quizID,
+		}, "Failed to get quiz attempts")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	resp := &dto.CourseQuizAttemptsResponse{
+		Attempts: make([]*dto.CourseQuizAttemptResponse, len(attempts)),
+	}
+	for i, attempt := range attempts {
+		resp.Attempts[i] = &dto.CourseQuizAttemptResponse{}
+		resp.Attempts[i].PopulateFromEntity(attempt)
+		resp.IsPassed = resp.IsPassed || attempt.IsPassed
+	}
+
+	if quiz.MaxAttempts > 0 {
+		remaining := max(quiz.MaxAttempts-len(attempts), 0)
+		resp.AttemptsRemaining = &remaining
+	}
+
+	return resp, nil
+}
+
+func (s *courseQuizService) getQuiz(ctx context.Context, id uuid.UUID) (*entity.CourseQuiz, error) {
+	quiz, err := s.quizRepo.GetQuizByID(ctx, id)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "quiz not found") {
+			return nil, errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"quiz.id": id,
+		}, "Failed to get quiz")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	return quiz, nil
+}
+
+func (s *courseQuizService) buildQuestions(ctx context.Context, quizID uuid.UUID,
+	reqs []dto.CourseQuizQuestionRequest) ([]*entity.CourseQuizQuestion, error) {
+	questions := make([]*entity.CourseQuizQuestion, len(reqs))
+	for i, req := range reqs {
+		correctCount := 0
+		for _, option := range req.Options {
+			if option.IsCorrect {
+				correctCount++
+			}
+		}
+
+		switch req.Type {
+		case enum.QuizQuestionTypeMultipleChoice:
+			if len(req.Options) < 2 || correctCount != 1 {
+				return nil, errorpkg.ErrValidation().WithDetail(
+					"Multiple choice questions need at least two options with exactly one correct option")
+			}
+		case enum.QuizQuestionTypeMultiSelect:
+			if len(req.Options) < 2 || correctCount == 0 {
+				return nil, errorpkg.ErrValidation().WithDetail(
+					"Multi-select questions need at least two options with at least one correct option")
+			}
+		}
+
+		questionID, err := s.uuid.NewV7()
+		if err != nil {
+			traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+				"error": err,
+			}, "Failed to generate question ID")
+			return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+		}
+
+		points := req.Points
+		if points == 0 {
+			points = 1
+		}
+
+		questions[i] = &entity.CourseQuizQuestion{
+			ID:       questionID,
+			QuizID:   quizID,
+			Type:     req.Type,
+			Question: req.Question,
+			Points:   points,
+			Order:    i + 1,
+			Options:  make([]*entity.CourseQuizOption, len(req.Options)),
+		}
+
+		for j, option := range req.Options {
+			optionID, err := s.uuid.NewV7()
+			if err != nil {
+				traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+					"error": err,
+				}, "Failed to generate option ID")
+				return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+			}
+
+			questions[i].Options[j] = &entity.CourseQuizOption{
+				ID:         optionID,
+				QuestionID: questionID,
+				Text:       option.Text,
+				// every option of a short answer question is an accepted answer
+				IsCorrect: option.IsCorrect || req.Type == enum.QuizQuestionTypeShortAnswer,
+				Order:     j + 1,
+			}
+		}
+	}
+
+	return questions, nil
+}
+
+// gradeAttempt grades the answers against the quiz. Unanswered questions are graded as incorrect.
+func gradeAttempt(quiz *entity.CourseQuiz, reqs []dto.CourseQuizAnswerRequest) (*entity.CourseQuizAttempt, error) {
+	answerByQuestionID := make(map[uuid.UUID]*dto.CourseQuizAnswerRequest, len(reqs))
+	for i := range reqs {
+		if _, exists := answerByQuestionID[reqs[i].QuestionID]; exists {
+			return nil, errorpkg.ErrValidation().WithDetail("Each question can only be answered once")
+		}
+		answerByQuestionID[reqs[i].QuestionID] = &reqs[i]
+	}
+
+	attempt := &entity.CourseQuizAttempt{
+		QuizID:  quiz.ID,
+		Answers: make([]*entity.CourseQuizAnswer, len(quiz.Questions)),
+	}
+
+	for i, question := range quiz.Questions {
+		attempt.MaxScore += question.Points
+		attempt.Answers[i] = &entity.CourseQuizAnswer{
+			QuestionID: question.ID,
+		}
+
+		req, ok := answerByQuestionID[question.ID]
+		if !ok {
+			continue
+		}
+		delete(answerByQuestionID, question.ID)
+
+		isCorrect, err := gradeAnswer(question, req)
+		if err != nil {
+			return nil, err
+		}
+
+		attempt.Answers[i].AnswerText = req.Text
+		attempt.Answers[i].OptionIDs = req.OptionIDs
+		attempt.Answers[i].IsCorrect = isCorrect
+		if isCorrect {
+			attempt.Score += question.Points
+		}
+	}
+
+	if len(answerByQuestionID) > 0 {
+		return nil, errorpkg.ErrValidation().WithDetail("Answers contain a question that is not in this quiz")
+	}
+
+	attempt.IsPassed = attempt.MaxScore > 0 && attempt.Score*100/attempt.MaxScore >= quiz.PassingScore
+
+	return attempt, nil
+}
+
+func gradeAnswer(question *entity.CourseQuizQuestion, req *dto.CourseQuizAnswerRequest) (bool, error) {
+	if question.Type == enum.QuizQuestionTypeShortAnswer {
+		given := normalizeShortAnswer(req.Text)
+		if given == "" {
+			return false, nil
+		}
+
+		for _, option := range question.Options {
+			if normalizeShortAnswer(option.Text) == given {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+
+	selected := make(map[uuid.UUID]bool, len(req.OptionIDs))
+	for _, optionID := range req.OptionIDs {
+		selected[optionID] = true
+	}
+
+	// The selection is correct when it matches the correct options exactly
+	isCorrect := len(selected) > 0
+	for _, option := range question.Options {
+		if option.IsCorrect != selected[option.ID] {
+			isCorrect = false
+		}
+		delete(selected, option.ID)
+	}
+
+	if len(selected) > 0 {
+		return false, errorpkg.ErrValidation().WithDetail("Answers contain an option that is not in its question")
+	}
+
+	return isCorrect, nil
+}
+
+// normalizeShortAnswer makes short answers comparable regardless of letter case and spacing
+func normalizeShortAnswer(answer string) string {
+	return strings.Join(strings.Fields(strings.ToLower(answer)), " ")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/nathakusuma/elevateu-backend/domain/ctxkey"
 	"github.com/nathakusuma/elevateu-backend/domain/dto"
 	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
 	"github.com/nathakusuma/elevateu-backend/domain/errorpkg"
 	"github.com/nathakusuma/elevateu-backend/internal/infra/database"
 	"github.com/nathakusuma/elevateu-backend/pkg/fileutil"
@@ -78,6 +80,7 @@ func (s *courseService) CreateCourse(ctx context.Context,
 		Title:       req.Title,
 		Description: req.Description,
 		TeacherName: req.TeacherName,
+		Price:       req.Price,
 	}
 
 	// Create course
@@ -118,6 +121,13 @@ func (s *courseService) GetCourseByID(ctx context.Context, id uuid.UUID) (*dto.C
 		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
 	}
 
+	// Unpublished courses stay visible to admins and to the students who are already enrolled
+	if course.Status != enum.CourseStatusPublished {
+		if err = s.checkEnrolledOrAdmin(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
 	resp := &dto.CourseResponse{}
 	err = resp.PopulateFromEntity(course, s.fileUtil.GetSignedURL)
 	if err != nil {
@@ -133,6 +143,17 @@ func (s *courseService) GetCourseByID(ctx context.Context, id uuid.UUID) (*dto.C
 
 func (s *courseService) GetCourses(ctx context.Context, query dto.GetCoursesQuery,
 	pageReq dto.PaginationRequest) ([]*dto.CourseResponse, dto.PaginationResponse, error) {
+	role, ok := ctx.Value(ctxkey.UserRole).(enum.UserRole)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx, nil, "Failed to get user role from context")
+		return nil, dto.PaginationResponse{}, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	if role != enum.UserRoleAdmin {
+		query.Status = ""
+		query.VisibleOnly = true
+	}
+
 	courses, pageResp, err := s.repo.GetCourses(ctx, query, pageReq)
 	if err != nil {
 		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
@@ -143,10 +164,24 @@ func (s *courseService) GetCourses(ctx context.Context, query dto.GetCoursesQuer
 		return nil, dto.PaginationResponse{}, errorpkg.ErrInternalServer().WithTraceID(traceID)
 	}
 
+	var paths []string
+	for _, course := range courses {
+		paths = append(paths, dto.CourseAssetPaths(course)...)
+	}
+
+	urlSigner, err := batchURLSigner(s.fileUtil, paths)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+			"paths": paths,
+		}, "Failed to sign course asset URLs")
+		return nil, dto.PaginationResponse{}, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
 	resp := make([]*dto.CourseResponse, len(courses))
 	for i, course := range courses {
 		resp[i] = &dto.CourseResponse{}
-		err = resp[i].PopulateFromEntity(course, s.fileUtil.GetSignedURL)
+		err = resp[i].PopulateFromEntity(course, urlSigner)
 		if err != nil {
 			traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
 				"error":  err,
@@ -166,6 +201,7 @@ func (s *courseService) UpdateCourse(ctx context.Context, id uuid.UUID, req *dto
 		Title:       req.Title,
 		Description: req.Description,
 		TeacherName: req.TeacherName,
+		Price:       req.Price,
 	}
 
 	tx, err := s.txManager.BeginTx(ctx)
@@ -224,6 +260,141 @@ func (s *courseService) UpdateCourse(ctx context.Context, id uuid.UUID, req *dto
 	return nil
 }
 
+// UpdateCourseStatus moves the course through its publishing workflow. A course can only be published or
+// scheduled once its assets are uploaded, its preview video is verified, and it has at least one content.
+func (s *courseService) UpdateCourseStatus(ctx context.Context, id uuid.UUID,
+	req dto.UpdateCourseStatusRequest) error {
+	course, err := s.repo.GetCourseByID(ctx, id)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "course not found") {
+			return errorpkg.ErrNotFound()
+		}
+
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+			"id":    id,
+		}, "Failed to get course by ID")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	var publishAt *time.Time
+	switch req.Status {
+	case enum.CourseStatusDraft:
+		publishAt = nil
+	case enum.CourseStatusScheduled:
+		if req.PublishAt == nil || !req.PublishAt.After(time.Now()) {
+			return errorpkg.ErrValidation().WithDetail("publish_at must be in the future to schedule a course")
+		}
+		if err = s.checkPublishable(ctx, course); err != nil {
+			return err
+		}
+		publishAt = req.PublishAt
+	case enum.CourseStatusPublished:
+		if err = s.checkPublishable(ctx, course); err != nil {
+			return err
+		}
+		// keep the original publish time when the course is already published
+		publishAt = course.PublishAt
+		if course.Status != enum.CourseStatusPublished || publishAt == nil {
+			now := time.Now()
+			publishAt = &now
+		}
+	case enum.CourseStatusArchived:
+		publishAt = course.PublishAt
+	}
+
+	err = s.repo.UpdateCourseStatus(ctx, id, req.Status, publishAt)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "course not found") {
+			return errorpkg.ErrNotFound()
+		}
+
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"id":      id,
+			"request": req,
+		}, "Failed to update course status")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"course.id":  id,
+		"status":     req.Status,
+		"publish_at": publishAt,
+	}, "Course status updated")
+
+	return nil
+}
+
+func (s *courseService) checkPublishable(ctx context.Context, course *entity.Course) error {
+	if course.ContentCount < 1 {
+		return errorpkg.ErrValidation().WithDetail("Course must have at least one content before publishing")
+	}
+
+	if course.PreviewVideoStatus != enum.AssetStatusReady {
+		return errorpkg.ErrValidation().WithDetail("Course preview video must be uploaded and verified before publishing")
+	}
+
+	assets := []struct {
+		name string
+		path string
+	}{
+		{"teacher avatar", fmt.Sprintf("courses/teacher_avatar/%s", course.ID)},
+		{"thumbnail", fmt.Sprintf("courses/thumbnail/%s", course.ID)},
+	}
+
+	for _, asset := range assets {
+		exists, err := s.fileUtil.Exists(ctx, asset.path)
+		if err != nil {
+			traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+				"error": err,
+				"path":  asset.path,
+			}, "Failed to check course asset")
+			return errorpkg.ErrInternalServer().WithTraceID(traceID)
+		}
+
+		if !exists {
+			return errorpkg.ErrValidation().WithDetail(
+				fmt.Sprintf("Course %s must be uploaded before publishing", asset.name))
+		}
+	}
+
+	return nil
+}
+
+func (s *courseService) checkEnrolledOrAdmin(ctx context.Context, courseID uuid.UUID) error {
+	role, ok := ctx.Value(ctxkey.UserRole).(enum.UserRole)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx, nil, "Failed to get user role from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	if role == enum.UserRoleAdmin {
+		return nil
+	}
+
+	userID, ok := ctx.Value(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx, nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	_, err := s.repo.GetEnrollment(ctx, courseID, userID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "enrollment not found") {
+			return errorpkg.ErrNotFound()
+		}
+
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":     err,
+			"course.id": courseID,
+		}, "Failed to get enrollment")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	return nil
+}
+
 func (s *courseService) DeleteCourse(ctx context.Context, id uuid.UUID) error {
 	tx, err := s.txManager.BeginTx(ctx)
 	if err != nil {
@@ -289,7 +460,21 @@ func (s *courseService) DeleteCourse(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// GetPreviewVideoUploadURL marks the preview video as pending, as the new file is not verified until
+// the upload is finalized
 func (s *courseService) GetPreviewVideoUploadURL(ctx context.Context, id uuid.UUID) (string, error) {
+	err := s.repo.UpdatePreviewVideoStatus(ctx, id, enum.AssetStatusPending)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "course not found") {
+			return "", errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+			"id":    id,
+		}, "Failed to reset preview video status")
+		return "", errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
 	url, err := s.fileUtil.GetUploadSignedURL(fmt.Sprintf("courses/preview_video/%s", id), "video/mp4")
 	if err != nil {
 		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
@@ -302,6 +487,35 @@ func (s *courseService) GetPreviewVideoUploadURL(ctx context.Context, id uuid.UU
 	return url, nil
 }
 
+// FinalizePreviewVideoUpload verifies the preview video uploaded through the signed URL and records its status
+func (s *courseService) FinalizePreviewVideoUpload(ctx context.Context, id uuid.UUID) (enum.AssetStatus, error) {
+	status, err := verifyUploadedAsset(ctx, s.fileUtil, fmt.Sprintf("courses/preview_video/%s", id),
+		videoContentTypes, maxVideoSize)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.repo.UpdatePreviewVideoStatus(ctx, id, status)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "course not found") {
+			return "", errorpkg.ErrNotFound()
+		}
+
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+			"id":    id,
+		}, "Failed to update preview video status")
+		return "", errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"course.id":            id,
+		"preview_video_status": status,
+	}, "Preview video upload finalized")
+
+	return status, nil
+}
+
 func (s *courseService) CreateEnrollment(ctx context.Context, courseID, studentID uuid.UUID) error {
 	isSubscribedBoost, ok := ctx.Value(ctxkey.IsSubscribedBoost).(bool)
 	if !ok {
@@ -346,10 +560,24 @@ func (s *courseService) GetEnrolledCourses(ctx context.Context, studentID uuid.U
 		return nil, dto.PaginationResponse{}, errorpkg.ErrInternalServer().WithTraceID(traceID)
 	}
 
+	var paths []string
+	for _, course := range courses {
+		paths = append(paths, dto.CourseAssetPaths(course)...)
+	}
+
+	urlSigner, err := batchURLSigner(s.fileUtil, paths)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+			"paths": paths,
+		}, "Failed to sign course asset URLs")
+		return nil, dto.PaginationResponse{}, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
 	resp := make([]*dto.CourseResponse, len(courses))
 	for i, course := range courses {
 		resp[i] = &dto.CourseResponse{}
-		err = resp[i].PopulateFromEntity(course, s.fileUtil.GetSignedURL)
+		err = resp[i].PopulateFromEntity(course, urlSigner)
 		if err != nil {
 			traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
 				"error":  err,
@@ -362,3 +590,19 @@ func (s *courseService) GetEnrolledCourses(ctx context.Context, studentID uuid.U
 
 	return resp, pageResp, nil
 }
+
+// batchURLSigner signs the paths in one batch, then returns a signer which serves them from the batch.
+// Paths outside the batch are still signed one by one.
+func batchURLSigner(fileUtil fileutil.IFileUtil, paths []string) (func(string) (string, error), error) {
+	signedURLs, err := fileUtil.GetSignedURLs(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(path string) (string, error) {
+		if signedURL, ok := signedURLs[path]; ok {
+			return signedURL, nil
+		}
+		return fileUtil.GetSignedURL(path)
+	}, nil
+}
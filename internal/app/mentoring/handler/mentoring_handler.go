@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/bytedance/sonic"
 	"github.com/gofiber/contrib/websocket"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -17,6 +18,7 @@ import (
 	"github.com/nathakusuma/elevateu-backend/pkg/jwt"
 	"github.com/nathakusuma/elevateu-backend/pkg/log"
 	"github.com/nathakusuma/elevateu-backend/pkg/validator"
+	"github.com/nathakusuma/elevateu-backend/pkg/wsconn"
 )
 
 type mentoringHandler struct {
@@ -44,6 +46,10 @@ func InitMentoringHandler(
 		midw.RequireAuthenticated,
 		midw.RequireOneOfRoles(enum.UserRoleStudent),
 		handler.createTrialChat)
+	mentoringsGroup.Get("/trial/allowance",
+		midw.RequireAuthenticated,
+		midw.RequireOneOfRoles(enum.UserRoleStudent),
+		handler.getTrialAllowance)
 
 	mentoringsGroup.Get("/chats/my",
 		midw.RequireAuthenticated,
@@ -56,6 +62,21 @@ func InitMentoringHandler(
 		midw.RequireAuthenticated,
 		handler.getMessages)
 
+	mentoringsGroup.Patch("/chats/:chatId/messages/:messageId",
+		midw.RequireAuthenticated,
+		handler.editMessage)
+	mentoringsGroup.Delete("/chats/:chatId/messages/:messageId",
+		midw.RequireAuthenticated,
+		handler.deleteMessage)
+
+	mentoringsGroup.Post("/chats/:chatId/attachments",
+		midw.RequireAuthenticated,
+		handler.createAttachment)
+
+	mentoringsGroup.Post("/chats/:chatId/read",
+		midw.RequireAuthenticated,
+		handler.markChatRead)
+
 	mentoringsGroup.Get("/chats/:chatId/ws",
 		middleware.WebsocketUpgrade,
 		websocket.New(handler.handleWebSocket),
@@ -80,7 +101,7 @@ func (h *mentoringHandler) createTrialChat(ctx *fiber.Ctx) error {
 		return err
 	}
 
-	chatResp, err := h.svc.CreateChat(ctx.Context(), req.MentorID, userID, true)
+	chatResp, err := h.svc.CreateChat(ctx.Context(), nil, req.MentorID, userID, true)
 	if err != nil {
 		return err
 	}
@@ -88,6 +109,23 @@ func (h *mentoringHandler) createTrialChat(ctx *fiber.Ctx) error {
 	return ctx.Status(fiber.StatusCreated).JSON(chatResp)
 }
 
+func (h *mentoringHandler) getTrialAllowance(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	allowance, err := h.svc.GetTrialAllowance(ctx.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(map[string]interface{}{
+		"allowance": allowance,
+	})
+}
+
 func (h *mentoringHandler) getMyChats(ctx *fiber.Ctx) error {
 	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
 	if !ok {
@@ -117,9 +155,7 @@ func (h *mentoringHandler) sendMessage(ctx *fiber.Ctx) error {
 		return errorpkg.ErrValidation().WithDetail("Invalid chat ID")
 	}
 
-	var req struct {
-		Message string `json:"message" validate:"required,min=1,max=2000"`
-	}
+	var req dto.SendMessageRequest
 	if err = ctx.BodyParser(&req); err != nil {
 		return errorpkg.ErrFailParseRequest()
 	}
@@ -128,7 +164,7 @@ func (h *mentoringHandler) sendMessage(ctx *fiber.Ctx) error {
 		return err
 	}
 
-	if err = h.svc.SendMessage(ctx.Context(), userID, chatID, req.Message); err != nil {
+	if _, err = h.svc.SendMessage(ctx.Context(), userID, chatID, req); err != nil {
 		return err
 	}
 
@@ -167,17 +203,142 @@ func (h *mentoringHandler) getMessages(ctx *fiber.Ctx) error {
 	})
 }
 
+func (h *mentoringHandler) editMessage(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	chatID, err := uuid.Parse(ctx.Params("chatId"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("Invalid chat ID")
+	}
+
+	messageID, err := uuid.Parse(ctx.Params("messageId"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("Invalid message ID")
+	}
+
+	var req dto.EditMessageRequest
+	if err = ctx.BodyParser(&req); err != nil {
+		return errorpkg.ErrFailParseRequest()
+	}
+
+	if err = h.val.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	message, err := h.svc.EditMessage(ctx.Context(), userID, chatID, messageID, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(map[string]interface{}{
+		"message": message,
+	})
+}
+
+func (h *mentoringHandler) deleteMessage(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	chatID, err := uuid.Parse(ctx.Params("chatId"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("Invalid chat ID")
+	}
+
+	messageID, err := uuid.Parse(ctx.Params("messageId"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("Invalid message ID")
+	}
+
+	if err = h.svc.DeleteMessage(ctx.Context(), userID, chatID, messageID); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *mentoringHandler) createAttachment(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	chatID, err := uuid.Parse(ctx.Params("chatId"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("Invalid chat ID")
+	}
+
+	var req dto.CreateAttachmentRequest
+	if err = ctx.BodyParser(&req); err != nil {
+		return errorpkg.ErrFailParseRequest()
+	}
+
+	if err = h.val.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.CreateAttachment(ctx.Context(), userID, chatID, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(resp)
+}
+
+func (h *mentoringHandler) markChatRead(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	chatID, err := uuid.Parse(ctx.Params("chatId"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("Invalid chat ID")
+	}
+
+	var req dto.MarkChatReadRequest
+	if err = ctx.BodyParser(&req); err != nil {
+		return errorpkg.ErrFailParseRequest()
+	}
+
+	if err = h.val.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	if err = h.svc.MarkChatRead(ctx.Context(), userID, chatID, req.MessageID); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func errorEvent(clientMessageID string, err error) *dto.ChatEvent {
+	return &dto.ChatEvent{
+		Type:            enum.ChatEventError,
+		ClientMessageID: clientMessageID,
+		Data:            err,
+	}
+}
+
 func (h *mentoringHandler) handleWebSocket(conn *websocket.Conn) {
 	token := conn.Headers("Authorization")
 	if token == "" {
-		conn.WriteJSON(errorpkg.ErrNoBearerToken())
+		conn.WriteJSON(errorEvent("", errorpkg.ErrNoBearerToken()))
 		conn.Close()
 		return
 	}
 
 	validateResp, err := h.jwt.Validate(token)
 	if err != nil {
-		conn.WriteJSON(errorpkg.ErrInvalidBearerToken())
+		conn.WriteJSON(errorEvent("", errorpkg.ErrInvalidBearerToken()))
 		conn.Close()
 		return
 	}
@@ -187,11 +348,25 @@ func (h *mentoringHandler) handleWebSocket(conn *websocket.Conn) {
 	chatIDStr := conn.Params("chatId")
 	chatID, err := uuid.Parse(chatIDStr)
 	if err != nil {
-		conn.WriteJSON(errorpkg.ErrValidation().WithDetail("Invalid chat ID"))
+		conn.WriteJSON(errorEvent("", errorpkg.ErrValidation().WithDetail("Invalid chat ID")))
+		conn.Close()
+		return
+	}
+
+	// from here on the connection is also written by broadcasts
+	client := wsconn.New(conn)
+
+	if err = h.svc.RegisterClient(userID, chatID, client); err != nil {
+		client.WriteJSON(errorEvent("", err))
 		conn.Close()
 		return
 	}
 
+	defer func() {
+		h.svc.UnregisterClient(userID, chatID, client)
+		conn.Close()
+	}()
+
 	// Set ping/pong handlers
 	pingInterval := 30 * time.Second
 	pongWait := 60 * time.Second
@@ -206,7 +381,7 @@ func (h *mentoringHandler) handleWebSocket(conn *websocket.Conn) {
 		defer pingTicker.Stop()
 
 		for range pingTicker.C {
-			if err := conn.WriteControl(websocket.PingMessage, []byte{}, time.Now().Add(10*time.Second)); err != nil {
+			if err := client.WriteControl(websocket.PingMessage, []byte{}, time.Now().Add(10*time.Second)); err != nil {
 				if websocket.IsCloseError(err,
 					websocket.CloseNormalClosure,
 					websocket.CloseGoingAway,
@@ -221,22 +396,20 @@ func (h *mentoringHandler) handleWebSocket(conn *websocket.Conn) {
 				}, "Failed to send ping")
 				return
 			}
+
+			h.svc.KeepAlive(userID, chatID)
 		}
 	}()
 
 	conn.SetReadDeadline(time.Now().Add(pongWait))
 
-	h.svc.RegisterClient(userID, chatID, conn)
+	eventCtx := context.WithValue(context.Background(), ctxkey.ConnectionID, client.ID)
 
 	var (
-		msg []byte
+		msg     []byte
+		ackData interface{}
 	)
 
-	defer func() {
-		h.svc.UnregisterClient(userID, chatID)
-		conn.Close()
-	}()
-
 	for {
 		if _, msg, err = conn.ReadMessage(); err != nil {
 			if websocket.IsCloseError(err,
@@ -254,9 +427,63 @@ func (h *mentoringHandler) handleWebSocket(conn *websocket.Conn) {
 			break
 		}
 
-		if err = h.svc.SendMessage(context.Background(), userID, chatID, string(msg)); err != nil {
-			conn.WriteJSON(err)
-			break
+		var event dto.ChatEventRequest
+		if err = sonic.Unmarshal(msg, &event); err != nil {
+			client.WriteJSON(errorEvent("", errorpkg.ErrFailParseRequest()))
+			continue
+		}
+
+		ackData, err = h.handleChatEvent(eventCtx, userID, chatID, &event)
+		if err != nil {
+			client.WriteJSON(errorEvent(event.ClientMessageID, err))
+			continue
+		}
+
+		// messages are always acknowledged so the sender learns the stored message
+		if event.Type == enum.ChatEventMessage || event.ClientMessageID != "" {
+			client.WriteJSON(&dto.ChatEvent{
+				Type:            enum.ChatEventAck,
+				ClientMessageID: event.ClientMessageID,
+				Data:            ackData,
+			})
+		}
+	}
+}
+
+// handleChatEvent returns the data to acknowledge the event with
+func (h *mentoringHandler) handleChatEvent(ctx context.Context, userID, chatID uuid.UUID,
+	event *dto.ChatEventRequest) (interface{}, error) {
+	switch event.Type {
+	case enum.ChatEventMessage:
+		var req dto.SendMessageRequest
+		if err := sonic.Unmarshal(event.Data, &req); err != nil {
+			return nil, errorpkg.ErrFailParseRequest()
 		}
+
+		if err := h.val.ValidateStruct(req); err != nil {
+			return nil, err
+		}
+
+		return h.svc.SendMessage(ctx, userID, chatID, req)
+	case enum.ChatEventRead:
+		var req dto.MarkChatReadRequest
+		if err := sonic.Unmarshal(event.Data, &req); err != nil {
+			return nil, errorpkg.ErrFailParseRequest()
+		}
+
+		if err := h.val.ValidateStruct(req); err != nil {
+			return nil, err
+		}
+
+		return nil, h.svc.MarkChatRead(ctx, userID, chatID, req.MessageID)
+	case enum.ChatEventTyping:
+		var req dto.TypingRequest
+		if err := sonic.Unmarshal(event.Data, &req); err != nil {
+			return nil, errorpkg.ErrFailParseRequest()
+		}
+
+		return nil, h.svc.SetTyping(ctx, userID, chatID, req.IsTyping)
+	default:
+		return nil, errorpkg.ErrValidation().WithDetail("Unknown event type")
 	}
 }
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/contract"
+	"github.com/nathakusuma/elevateu-backend/domain/ctxkey"
+	"github.com/nathakusuma/elevateu-backend/domain/dto"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
+	"github.com/nathakusuma/elevateu-backend/domain/errorpkg"
+	"github.com/nathakusuma/elevateu-backend/internal/middleware"
+	"github.com/nathakusuma/elevateu-backend/pkg/log"
+	"github.com/nathakusuma/elevateu-backend/pkg/validator"
+)
+
+type mentoringScheduleHandler struct {
+	svc contract.IMentoringScheduleService
+	val validator.IValidator
+}
+
+func InitMentoringScheduleHandler(
+	router fiber.Router,
+	midw *middleware.Middleware,
+	scheduleSvc contract.IMentoringScheduleService,
+	validator validator.IValidator,
+) {
+	handler := mentoringScheduleHandler{
+		svc: scheduleSvc,
+		val: validator,
+	}
+
+	mentoringsGroup := router.Group("/mentorings")
+
+	mentoringsGroup.Put("/availability",
+		midw.RequireAuthenticated,
+		midw.RequireOneOfRoles(enum.UserRoleMentor),
+		handler.setAvailability)
+	mentoringsGroup.Post("/availability/exceptions",
+		midw.RequireAuthenticated,
+		midw.RequireOneOfRoles(enum.UserRoleMentor),
+		handler.createAvailabilityException)
+	mentoringsGroup.Delete("/availability/exceptions/:exceptionId",
+		midw.RequireAuthenticated,
+		midw.RequireOneOfRoles(enum.UserRoleMentor),
+		handler.deleteAvailabilityException)
+
+	mentoringsGroup.Get("/mentors/:mentorId/availability",
+		midw.RequireAuthenticated,
+		handler.getMentorAvailability)
+	mentoringsGroup.Get("/mentors/:mentorId/slots",
+		midw.RequireAuthenticated,
+		handler.getAvailableSlots)
+
+	mentoringsGroup.Get("/sessions/my",
+		midw.RequireAuthenticated,
+		handler.getMySessions)
+	mentoringsGroup.Get("/sessions/my/calendar.ics",
+		midw.RequireAuthenticated,
+		handler.exportMyCalendar)
+}
+
+func (h *mentoringScheduleHandler) setAvailability(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	var req dto.SetAvailabilityRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return errorpkg.ErrFailParseRequest()
+	}
+
+	if err := h.val.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	if err := h.svc.SetAvailability(ctx.Context(), userID, req); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *mentoringScheduleHandler) createAvailabilityException(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	var req dto.CreateAvailabilityExceptionRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		return errorpkg.ErrFailParseRequest()
+	}
+
+	if err := h.val.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.CreateAvailabilityException(ctx.Context(), userID, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(map[string]interface{}{
+		"exception": resp,
+	})
+}
+
+func (h *mentoringScheduleHandler) deleteAvailabilityException(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	exceptionID, err := uuid.Parse(ctx.Params("exceptionId"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("Invalid exception ID")
+	}
+
+	if err = h.svc.DeleteAvailabilityException(ctx.Context(), userID, exceptionID); err != nil {
+		return err
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *mentoringScheduleHandler) getMentorAvailability(ctx *fiber.Ctx) error {
+	mentorID, err := uuid.Parse(ctx.Params("mentorId"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("Invalid mentor ID")
+	}
+
+	resp, err := h.svc.GetMentorAvailability(ctx.Context(), mentorID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(map[string]interface{}{
+		"availability": resp,
+	})
+}
+
+func (h *mentoringScheduleHandler) getAvailableSlots(ctx *fiber.Ctx) error {
+	mentorID, err := uuid.Parse(ctx.Params("mentorId"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("Invalid mentor ID")
+	}
+
+	var req dto.AvailableSlotsRequest
+	if err = ctx.QueryParser(&req); err != nil {
+		return errorpkg.ErrFailParseRequest()
+	}
+
+	if err = h.val.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	slots, err := h.svc.GetAvailableSlots(ctx.Context(), mentorID, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(map[string]interface{}{
+		"slots": slots,
+	})
+}
+
+func (h *mentoringScheduleHandler) getMySessions(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	sessions, err := h.svc.GetSessionsByUserID(ctx.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(map[string]interface{}{
+		"sessions": sessions,
+	})
+}
+
+func (h *mentoringScheduleHandler) exportMyCalendar(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	calendar, err := h.svc.ExportCalendar(ctx.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	ctx.Attachment("elevateu-mentoring-sessions.ics")
+	return ctx.Send(calendar)
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/contract"
+	"github.com/nathakusuma/elevateu-backend/domain/ctxkey"
+	"github.com/nathakusuma/elevateu-backend/domain/dto"
+	"github.com/nathakusuma/elevateu-backend/domain/errorpkg"
+	"github.com/nathakusuma/elevateu-backend/internal/middleware"
+	"github.com/nathakusuma/elevateu-backend/pkg/log"
+	"github.com/nathakusuma/elevateu-backend/pkg/validator"
+)
+
+type mentoringTranscriptHandler struct {
+	svc contract.IMentoringTranscriptService
+	val validator.IValidator
+}
+
+func InitMentoringTranscriptHandler(
+	router fiber.Router,
+	midw *middleware.Middleware,
+	transcriptSvc contract.IMentoringTranscriptService,
+	validator validator.IValidator,
+) {
+	handler := mentoringTranscriptHandler{
+		svc: transcriptSvc,
+		val: validator,
+	}
+
+	mentoringsGroup := router.Group("/mentorings")
+
+	mentoringsGroup.Post("/chats/:chatId/transcripts",
+		midw.RequireAuthenticated,
+		handler.createTranscript)
+	mentoringsGroup.Get("/transcripts/:transcriptId",
+		midw.RequireAuthenticated,
+		handler.getTranscript)
+}
+
+func (h *mentoringTranscriptHandler) createTranscript(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	chatID, err := uuid.Parse(ctx.Params("chatId"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("Invalid chat ID")
+	}
+
+	var req dto.CreateTranscriptRequest
+	if err = ctx.BodyParser(&req); err != nil {
+		return errorpkg.ErrFailParseRequest()
+	}
+
+	if err = h.val.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	resp, err := h.svc.CreateTranscript(ctx.Context(), userID, chatID, req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusAccepted).JSON(map[string]interface{}{
+		"transcript": resp,
+	})
+}
+
+func (h *mentoringTranscriptHandler) getTranscript(ctx *fiber.Ctx) error {
+	userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	transcriptID, err := uuid.Parse(ctx.Params("transcriptId"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("Invalid transcript ID")
+	}
+
+	resp, err := h.svc.GetTranscript(ctx.Context(), userID, transcriptID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(map[string]interface{}{
+		"transcript": resp,
+	})
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/nathakusuma/elevateu-backend/domain/contract"
 	"github.com/nathakusuma/elevateu-backend/domain/dto"
 	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
+	"github.com/nathakusuma/elevateu-backend/internal/infra/database"
 )
 
 type mentoringRepository struct {
@@ -26,8 +28,9 @@ func NewMentoringRepository(conn *sqlx.DB) contract.IMentoringRepository {
 	}
 }
 
-func (r *mentoringRepository) CreateChat(ctx context.Context, chat *entity.MentoringChat) error {
-	return r.createChat(ctx, r.db, chat)
+func (r *mentoringRepository) CreateChat(ctx context.Context, txWrapper database.ITransaction,
+	chat *entity.MentoringChat) error {
+	return r.createChat(ctx, txWrapper.GetTx(), chat)
 }
 
 func (r *mentoringRepository) createChat(ctx context.Context, tx sqlx.ExtContext, chat *entity.MentoringChat) error {
@@ -38,7 +41,8 @@ func (r *mentoringRepository) createChat(ctx context.Context, tx sqlx.ExtContext
           :id, :mentor_id, :student_id, :expires_at, :is_trial
        )
        ON CONFLICT (student_id, mentor_id)
-       DO UPDATE SET expires_at = :expires_at
+       DO UPDATE SET expires_at = :expires_at, is_trial = :is_trial,
+                     expiry_warning_sent_at = NULL, expiry_closed_at = NULL
     `
 
 	_, err := sqlx.NamedExecContext(ctx, tx, query, chat)
@@ -49,15 +53,32 @@ func (r *mentoringRepository) createChat(ctx context.Context, tx sqlx.ExtContext
 	return nil
 }
 
-func (r *mentoringRepository) CreateTrialChat(ctx context.Context, chat *entity.MentoringChat) error {
+// CreateTrialChat records the trial and creates its chat, unless the student has used maxTrials trials.
+// The student row is locked so concurrent trials cannot exceed the limit.
+func (r *mentoringRepository) CreateTrialChat(ctx context.Context, chat *entity.MentoringChat, maxTrials int) error {
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("failed to start transaction: %w", err)
 	}
 	defer tx.Rollback()
 
+	if _, err = tx.ExecContext(ctx, `SELECT 1 FROM students WHERE user_id = $1 FOR UPDATE`,
+		chat.StudentID); err != nil {
+		return fmt.Errorf("failed to lock student: %w", err)
+	}
+
+	var trialsUsed int
+	if err = tx.GetContext(ctx, &trialsUsed, `SELECT COUNT(*) FROM mentoring_trials WHERE student_id = $1`,
+		chat.StudentID); err != nil {
+		return fmt.Errorf("failed to count trials: %w", err)
+	}
+
+	if trialsUsed >= maxTrials {
+		return errors.New("trial limit reached")
+	}
+
 	query1 := `
-		INSERT INTO mentoring_trials (student_id) VALUES (:student_id)
+		INSERT INTO mentoring_trials (student_id, mentor_id) VALUES (:student_id, :mentor_id)
 	`
 	_, err = tx.NamedExecContext(ctx, query1, chat)
 	if err != nil {
@@ -80,9 +101,20 @@ func (r *mentoringRepository) CreateTrialChat(ctx context.Context, chat *entity.
 	return nil
 }
 
+func (r *mentoringRepository) CountTrialsByStudent(ctx context.Context, studentID uuid.UUID) (int, error) {
+	var count int
+	err := r.db.GetContext(ctx, &count, `SELECT COUNT(*) FROM mentoring_trials WHERE student_id = $1`, studentID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count trials: %w", err)
+	}
+
+	return count, nil
+}
+
 func (r *mentoringRepository) GetChatByID(ctx context.Context, chatID uuid.UUID) (*entity.MentoringChat, error) {
 	query := `
-       SELECT id, student_id, mentor_id, expires_at, is_trial
+       SELECT id, student_id, mentor_id, expires_at, is_trial,
+              student_last_read_message_id, mentor_last_read_message_id
        FROM mentoring_chats
        WHERE id = $1
     `
@@ -107,6 +139,10 @@ func (r *mentoringRepository) GetChatsByUserID(ctx context.Context, userID uuid.
 		ExpiresAt time.Time `db:"expires_at"`
 		IsTrial   bool      `db:"is_trial"`
 
+		StudentLastReadMessageID *uuid.UUID `db:"student_last_read_message_id"`
+		MentorLastReadMessageID  *uuid.UUID `db:"mentor_last_read_message_id"`
+		UnreadCount              int        `db:"unread_count"`
+
 		LastMessageID        sql.NullString `db:"last_message.id"`
 		LastMessageChatID    sql.NullString `db:"last_message.chat_id"`
 		LastMessageSenderID  sql.NullString `db:"last_message.sender_id"`
@@ -116,6 +152,18 @@ func (r *mentoringRepository) GetChatsByUserID(ctx context.Context, userID uuid.
 
 	query := `
         SELECT mc.id, mc.student_id, mc.mentor_id, mc.expires_at, mc.is_trial,
+               mc.student_last_read_message_id, mc.mentor_last_read_message_id,
+               (
+                   SELECT COUNT(*)
+                   FROM mentoring_messages um
+                   WHERE um.chat_id = mc.id
+                     AND um.sender_id <> $1
+                     AND um.deleted_at IS NULL
+                     AND (
+                         lr.last_read_message_id IS NULL
+                         OR um.id > lr.last_read_message_id
+                     )
+               ) AS unread_count,
                mm.id as "last_message.id",
                mm.chat_id as "last_message.chat_id",
                mm.sender_id as "last_message.sender_id",
@@ -126,9 +174,16 @@ func (r *mentoringRepository) GetChatsByUserID(ctx context.Context, userID uuid.
             SELECT *
             FROM mentoring_messages
             WHERE chat_id = mc.id
+              AND deleted_at IS NULL
             ORDER BY created_at DESC
             LIMIT 1
         ) mm ON true
+        CROSS JOIN LATERAL (
+            SELECT CASE
+                       WHEN mc.student_id = $1 THEN mc.student_last_read_message_id
+                       ELSE mc.mentor_last_read_message_id
+                   END AS last_read_message_id
+        ) lr
         WHERE mc.student_id = $1 OR mc.mentor_id = $1
         ORDER BY mm.created_at DESC NULLS LAST
     `
@@ -152,6 +207,10 @@ func (r *mentoringRepository) GetChatsByUserID(ctx context.Context, userID uuid.
 			MentorID:  cj.MentorID,
 			ExpiresAt: cj.ExpiresAt,
 			IsTrial:   cj.IsTrial,
+
+			StudentLastReadMessageID: cj.StudentLastReadMessageID,
+			MentorLastReadMessageID:  cj.MentorLastReadMessageID,
+			UnreadCount:              cj.UnreadCount,
 		}
 
 		if cj.LastMessageID.Valid {
@@ -181,7 +240,8 @@ func (r *mentoringRepository) GetChatsByUserID(ctx context.Context, userID uuid.
 func (r *mentoringRepository) GetChatByMentorAndStudent(ctx context.Context, mentorID,
 	studentID uuid.UUID) (*entity.MentoringChat, error) {
 	query := `
-	   SELECT id, student_id, mentor_id, expires_at, is_trial
+	   SELECT id, student_id, mentor_id, expires_at, is_trial,
+	          student_last_read_message_id, mentor_last_read_message_id
 	   FROM mentoring_chats
 	   WHERE mentor_id = $1
 	     AND student_id = $2
@@ -200,29 +260,286 @@ func (r *mentoringRepository) GetChatByMentorAndStudent(ctx context.Context, men
 }
 
 func (r *mentoringRepository) SendMessage(ctx context.Context, message *entity.MentoringMessage) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	query := `
 		INSERT INTO mentoring_messages (
 			id, chat_id, sender_id, message
 		) VALUES (
-			:id, :chat_id, :sender_id, :message
+			$1, $2, $3, $4
 		)
+		RETURNING created_at
 	`
 
-	_, err := r.db.NamedExecContext(ctx, query, message)
+	err = tx.QueryRowxContext(ctx, query, message.ID, message.ChatID, message.SenderID, message.Message).
+		Scan(&message.CreatedAt)
 	if err != nil {
 		return fmt.Errorf("failed to send message: %w", err)
 	}
 
+	if message.Attachment != nil {
+		query = `
+			UPDATE mentoring_attachments
+			SET message_id = $1,
+			    content_type = $2,
+			    size = $3
+			WHERE id = $4
+			  AND message_id IS NULL
+		`
+
+		result, err2 := tx.ExecContext(ctx, query, message.ID, message.Attachment.ContentType,
+			message.Attachment.Size, message.Attachment.ID)
+		if err2 != nil {
+			return fmt.Errorf("failed to attach file: %w", err2)
+		}
+
+		rowsAffected, err2 := result.RowsAffected()
+		if err2 != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err2)
+		}
+
+		if rowsAffected == 0 {
+			return errors.New("attachment already sent")
+		}
+
+		message.Attachment.MessageID = &message.ID
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *mentoringRepository) CreateAttachment(ctx context.Context, attachment *entity.MentoringAttachment) error {
+	query := `
+		INSERT INTO mentoring_attachments (
+			id, chat_id, uploader_id, file_name, content_type, size
+		) VALUES (
+			:id, :chat_id, :uploader_id, :file_name, :content_type, :size
+		)
+	`
+
+	_, err := r.db.NamedExecContext(ctx, query, attachment)
+	if err != nil {
+		return fmt.Errorf("failed to create attachment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *mentoringRepository) GetAttachmentByID(ctx context.Context,
+	attachmentID uuid.UUID) (*entity.MentoringAttachment, error) {
+	query := `
+		SELECT id, chat_id, uploader_id, message_id, file_name, content_type, size, created_at
+		FROM mentoring_attachments
+		WHERE id = $1
+	`
+
+	var attachment entity.MentoringAttachment
+	err := r.db.GetContext(ctx, &attachment, query, attachmentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("attachment not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+
+	return &attachment, nil
+}
+
+// UpdateMessage replaces the message text and keeps the previous text as edit history
+func (r *mentoringRepository) UpdateMessage(ctx context.Context, message *entity.MentoringMessage) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO mentoring_message_edits (message_id, message)
+		SELECT id, message
+		FROM mentoring_messages
+		WHERE id = $1
+		  AND deleted_at IS NULL
+	`
+
+	result, err := tx.ExecContext(ctx, query, message.ID)
+	if err != nil {
+		return fmt.Errorf("failed to save message history: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("message not found")
+	}
+
+	query = `
+		UPDATE mentoring_messages
+		SET message = $2,
+			edited_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		RETURNING edited_at
+	`
+
+	if err = tx.QueryRowxContext(ctx, query, message.ID, message.Message).Scan(&message.EditedAt); err != nil {
+		return fmt.Errorf("failed to update message: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
+func (r *mentoringRepository) DeleteMessage(ctx context.Context, message *entity.MentoringMessage) error {
+	query := `
+		UPDATE mentoring_messages
+		SET deleted_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+		  AND deleted_at IS NULL
+		RETURNING deleted_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, query, message.ID).Scan(&message.DeletedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("message not found: %w", err)
+		}
+		return fmt.Errorf("failed to delete message: %w", err)
+	}
+
+	return nil
+}
+
+// messageJoin is a message row joined with its optional attachment
+type messageJoin struct {
+	ID        uuid.UUID  `db:"id"`
+	ChatID    uuid.UUID  `db:"chat_id"`
+	SenderID  uuid.UUID  `db:"sender_id"`
+	Message   string     `db:"message"`
+	CreatedAt time.Time  `db:"created_at"`
+	EditedAt  *time.Time `db:"edited_at"`
+	DeletedAt *time.Time `db:"deleted_at"`
+
+	AttachmentID          *uuid.UUID     `db:"attachment.id"`
+	AttachmentUploaderID  *uuid.UUID     `db:"attachment.uploader_id"`
+	AttachmentFileName    sql.NullString `db:"attachment.file_name"`
+	AttachmentContentType sql.NullString `db:"attachment.content_type"`
+	AttachmentSize        sql.NullInt64  `db:"attachment.size"`
+	AttachmentCreatedAt   sql.NullTime   `db:"attachment.created_at"`
+}
+
+const messageJoinColumns = `
+	m.id, m.chat_id, m.sender_id, m.message, m.created_at, m.edited_at, m.deleted_at,
+	a.id AS "attachment.id",
+	a.uploader_id AS "attachment.uploader_id",
+	a.file_name AS "attachment.file_name",
+	a.content_type AS "attachment.content_type",
+	a.size AS "attachment.size",
+	a.created_at AS "attachment.created_at"
+`
+
+func (mj *messageJoin) toEntity() *entity.MentoringMessage {
+	message := &entity.MentoringMessage{
+		ID:        mj.ID,
+		ChatID:    mj.ChatID,
+		SenderID:  mj.SenderID,
+		Message:   mj.Message,
+		CreatedAt: mj.CreatedAt,
+		EditedAt:  mj.EditedAt,
+		DeletedAt: mj.DeletedAt,
+	}
+
+	if mj.AttachmentID != nil {
+		message.Attachment = &entity.MentoringAttachment{
+			ID:          *mj.AttachmentID,
+			ChatID:      mj.ChatID,
+			UploaderID:  *mj.AttachmentUploaderID,
+			MessageID:   &message.ID,
+			FileName:    mj.AttachmentFileName.String,
+			ContentType: mj.AttachmentContentType.String,
+			Size:        mj.AttachmentSize.Int64,
+			CreatedAt:   mj.AttachmentCreatedAt.Time,
+		}
+	}
+
+	return message
+}
+
+func (r *mentoringRepository) GetMessageByID(ctx context.Context,
+	messageID uuid.UUID) (*entity.MentoringMessage, error) {
+	query := `
+		SELECT ` + messageJoinColumns + `
+		FROM mentoring_messages m
+		LEFT JOIN mentoring_attachments a ON a.message_id = m.id
+		WHERE m.id = $1
+	`
+
+	var mj messageJoin
+	err := r.db.GetContext(ctx, &mj, query, messageID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("message not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	return mj.toEntity(), nil
+}
+
+// MarkChatRead moves the participant's read marker forward. It never moves it back,
+// so it returns false when the marker is already at or past the message.
+func (r *mentoringRepository) MarkChatRead(ctx context.Context, chatID, userID,
+	messageID uuid.UUID) (bool, error) {
+	query := `
+		UPDATE mentoring_chats
+		SET student_last_read_message_id = CASE
+				WHEN student_id = $2 THEN $3
+				ELSE student_last_read_message_id
+			END,
+			mentor_last_read_message_id = CASE
+				WHEN mentor_id = $2 THEN $3
+				ELSE mentor_last_read_message_id
+			END
+		WHERE id = $1
+		  AND (
+			  (student_id = $2 AND (student_last_read_message_id IS NULL OR student_last_read_message_id < $3))
+			  OR (mentor_id = $2 AND (mentor_last_read_message_id IS NULL OR mentor_last_read_message_id < $3))
+		  )
+	`
+
+	result, err := r.db.ExecContext(ctx, query, chatID, userID, messageID)
+	if err != nil {
+		return false, fmt.Errorf("failed to mark chat read: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
 func (r *mentoringRepository) GetMessages(ctx context.Context, chatID uuid.UUID,
 	pageReq dto.PaginationRequest) ([]*entity.MentoringMessage, dto.PaginationResponse, error) {
 
 	baseQuery := `
-		SELECT id, chat_id, sender_id, message, created_at
-		FROM mentoring_messages
-		WHERE chat_id = $1
+		SELECT ` + messageJoinColumns + `
+		FROM mentoring_messages m
+		LEFT JOIN mentoring_attachments a ON a.message_id = m.id
+		WHERE m.chat_id = $1
 	`
 
 	var sqlQuery string
@@ -241,10 +558,10 @@ func (r *mentoringRepository) GetMessages(ctx context.Context, chatID uuid.UUID,
 			orderDirection = "ASC"
 		}
 
-		sqlQuery = baseQuery + fmt.Sprintf(" AND id %s $2 ORDER BY id %s LIMIT $3", operator, orderDirection)
+		sqlQuery = baseQuery + fmt.Sprintf(" AND m.id %s $2 ORDER BY m.id %s LIMIT $3", operator, orderDirection)
 		args = append(args, pageReq.Cursor, pageReq.Limit+1)
 	} else {
-		sqlQuery = baseQuery + " ORDER BY id DESC LIMIT $2"
+		sqlQuery = baseQuery + " ORDER BY m.id DESC LIMIT $2"
 		args = append(args, pageReq.Limit+1)
 	}
 
@@ -256,11 +573,11 @@ func (r *mentoringRepository) GetMessages(ctx context.Context, chatID uuid.UUID,
 
 	var messages []*entity.MentoringMessage
 	for rows.Next() {
-		message := &entity.MentoringMessage{}
-		if err := rows.StructScan(message); err != nil {
+		var mj messageJoin
+		if err := rows.StructScan(&mj); err != nil {
 			return nil, dto.PaginationResponse{}, fmt.Errorf("failed to scan message row: %w", err)
 		}
-		messages = append(messages, message)
+		messages = append(messages, mj.toEntity())
 	}
 
 	if err := rows.Err(); err != nil {
@@ -280,3 +597,113 @@ func (r *mentoringRepository) GetMessages(ctx context.Context, chatID uuid.UUID,
 
 	return messages, dto.PaginationResponse{HasMore: hasMore}, nil
 }
+
+// CountMessagesBySender counts the messages the user sent in the chat, including deleted ones
+func (r *mentoringRepository) CountMessagesBySender(ctx context.Context, chatID, senderID uuid.UUID) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM mentoring_messages
+		WHERE chat_id = $1
+		  AND sender_id = $2
+	`
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, chatID, senderID); err != nil {
+		return 0, fmt.Errorf("failed to count messages: %w", err)
+	}
+
+	return count, nil
+}
+
+// ClaimChatsForExpiryWarning marks the chats expiring before the given time as warned and returns them,
+// so each warning is sent once even with several API instances
+func (r *mentoringRepository) ClaimChatsForExpiryWarning(ctx context.Context,
+	expiresBefore time.Time) ([]*entity.MentoringChat, error) {
+	query := `
+		UPDATE mentoring_chats
+		SET expiry_warning_sent_at = CURRENT_TIMESTAMP
+		WHERE expiry_warning_sent_at IS NULL
+		  AND expires_at > CURRENT_TIMESTAMP
+		  AND expires_at <= $1
+		RETURNING id, student_id, mentor_id, expires_at, is_trial
+	`
+
+	var chats []*entity.MentoringChat
+	if err := r.db.SelectContext(ctx, &chats, query, expiresBefore); err != nil {
+		return nil, fmt.Errorf("failed to claim chats for expiry warning: %w", err)
+	}
+
+	return chats, nil
+}
+
+// ClaimExpiredChats marks the expired chats as closed and returns them
+func (r *mentoringRepository) ClaimExpiredChats(ctx context.Context) ([]*entity.MentoringChat, error) {
+	query := `
+		UPDATE mentoring_chats
+		SET expiry_closed_at = CURRENT_TIMESTAMP
+		WHERE expiry_closed_at IS NULL
+		  AND expires_at <= CURRENT_TIMESTAMP
+		RETURNING id, student_id, mentor_id, expires_at, is_trial
+	`
+
+	var chats []*entity.MentoringChat
+	if err := r.db.SelectContext(ctx, &chats, query); err != nil {
+		return nil, fmt.Errorf("failed to claim expired chats: %w", err)
+	}
+
+	return chats, nil
+}
+
+func (r *mentoringRepository) CreateTranscript(ctx context.Context, transcript *entity.MentoringTranscript) error {
+	query := `
+		INSERT INTO mentoring_transcripts (
+			id, chat_id, requester_id, format, status
+		) VALUES (
+			$1, $2, $3, $4, $5
+		)
+		RETURNING created_at
+	`
+
+	err := r.db.QueryRowxContext(ctx, query, transcript.ID, transcript.ChatID, transcript.RequesterID,
+		transcript.Format, transcript.Status).Scan(&transcript.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create transcript: %w", err)
+	}
+
+	return nil
+}
+
+func (r *mentoringRepository) GetTranscriptByID(ctx context.Context,
+	transcriptID uuid.UUID) (*entity.MentoringTranscript, error) {
+	query := `
+		SELECT id, chat_id, requester_id, format, status, created_at, completed_at
+		FROM mentoring_transcripts
+		WHERE id = $1
+	`
+
+	var transcript entity.MentoringTranscript
+	if err := r.db.GetContext(ctx, &transcript, query, transcriptID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("transcript not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get transcript: %w", err)
+	}
+
+	return &transcript, nil
+}
+
+func (r *mentoringRepository) UpdateTranscriptStatus(ctx context.Context, transcriptID uuid.UUID,
+	status enum.TranscriptStatus) error {
+	query := `
+		UPDATE mentoring_transcripts
+		SET status = $2,
+		    completed_at = CURRENT_TIMESTAMP
+		WHERE id = $1
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, transcriptID, status); err != nil {
+		return fmt.Errorf("failed to update transcript status: %w", err)
+	}
+
+	return nil
+}
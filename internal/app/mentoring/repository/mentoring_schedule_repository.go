@@ -0,0 +1,383 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/nathakusuma/elevateu-backend/domain/contract"
+	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
+	"github.com/nathakusuma/elevateu-backend/internal/infra/database"
+)
+
+type mentoringScheduleRepository struct {
+	db *sqlx.DB
+}
+
+func NewMentoringScheduleRepository(conn *sqlx.DB) contract.IMentoringScheduleRepository {
+	return &mentoringScheduleRepository{
+		db: conn,
+	}
+}
+
+func (r *mentoringScheduleRepository) ReplaceAvailabilities(ctx context.Context, mentorID uuid.UUID,
+	availabilities []*entity.MentorAvailability) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, `DELETE FROM mentor_availabilities WHERE mentor_id = $1`, mentorID); err != nil {
+		return fmt.Errorf("failed to delete availabilities: %w", err)
+	}
+
+	if len(availabilities) > 0 {
+		query := `
+			INSERT INTO mentor_availabilities (
+				id, mentor_id, weekday, start_minute, end_minute
+			) VALUES (
+				:id, :mentor_id, :weekday, :start_minute, :end_minute
+			)
+		`
+
+		if _, err = tx.NamedExecContext(ctx, query, availabilities); err != nil {
+			return fmt.Errorf("failed to create availabilities: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *mentoringScheduleRepository) GetAvailabilities(ctx context.Context,
+	mentorID uuid.UUID) ([]*entity.MentorAvailability, error) {
+	query := `
+		SELECT id, mentor_id, weekday, start_minute, end_minute
+		FROM mentor_availabilities
+		WHERE mentor_id = $1
+		ORDER BY weekday, start_minute
+	`
+
+	var availabilities []*entity.MentorAvailability
+	if err := r.db.SelectContext(ctx, &availabilities, query, mentorID); err != nil {
+		return nil, fmt.Errorf("failed to get availabilities: %w", err)
+	}
+
+	return availabilities, nil
+}
+
+func (r *mentoringScheduleRepository) CreateAvailabilityException(ctx context.Context,
+	exception *entity.MentorAvailabilityException) error {
+	query := `
+		INSERT INTO mentor_availability_exceptions (
+			id, mentor_id, starts_at, ends_at, reason
+		) VALUES (
+			:id, :mentor_id, :starts_at, :ends_at, :reason
+		)
+	`
+
+	if _, err := r.db.NamedExecContext(ctx, query, exception); err != nil {
+		return fmt.Errorf("failed to create availability exception: %w", err)
+	}
+
+	return nil
+}
+
+// GetAvailabilityExceptions returns the exceptions overlapping the period
+func (r *mentoringScheduleRepository) GetAvailabilityExceptions(ctx context.Context, mentorID uuid.UUID,
+	from, to time.Time) ([]*entity.MentorAvailabilityException, error) {
+	query := `
+		SELECT id, mentor_id, starts_at, ends_at, reason
+		FROM mentor_availability_exceptions
+		WHERE mentor_id = $1
+		  AND starts_at < $3
+		  AND ends_at > $2
+		ORDER BY starts_at
+	`
+
+	var exceptions []*entity.MentorAvailabilityException
+	if err := r.db.SelectContext(ctx, &exceptions, query, mentorID, from, to); err != nil {
+		return nil, fmt.Errorf("failed to get availability exceptions: %w", err)
+	}
+
+	return exceptions, nil
+}
+
+func (r *mentoringScheduleRepository) DeleteAvailabilityException(ctx context.Context,
+	mentorID, exceptionID uuid.UUID) error {
+	query := `
+		DELETE FROM mentor_availability_exceptions
+		WHERE id = $1
+		  AND mentor_id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, exceptionID, mentorID)
+	if err != nil {
+		return fmt.Errorf("failed to delete availability exception: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("availability exception not found")
+	}
+
+	return nil
+}
+
+// CreateSession releases the expired holds of both participants before claiming the slot.
+// Overlapping sessions are rejected by the database, so concurrent bookings of a slot cannot both succeed.
+func (r *mentoringScheduleRepository) CreateSession(ctx context.Context, session *entity.MentoringSession) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	releaseQuery := `
+		UPDATE mentoring_sessions
+		SET status = $3
+		WHERE (mentor_id = $1 OR student_id = $2)
+		  AND status = $4
+		  AND hold_until < CURRENT_TIMESTAMP
+	`
+
+	_, err = tx.ExecContext(ctx, releaseQuery, session.MentorID, session.StudentID,
+		enum.MentoringSessionStatusCancelled, enum.MentoringSessionStatusPending)
+	if err != nil {
+		return fmt.Errorf("failed to release expired holds: %w", err)
+	}
+
+	query := `
+		INSERT INTO mentoring_sessions (
+			id, mentor_id, student_id, starts_at, ends_at, status, hold_until
+		) VALUES (
+			:id, :mentor_id, :student_id, :starts_at, :ends_at, :status, :hold_until
+		)
+	`
+
+	if _, err = tx.NamedExecContext(ctx, query, session); err != nil {
+		if isSessionOverlapError(err) {
+			return fmt.Errorf("session slot taken: %w", err)
+		}
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func isSessionOverlapError(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23P01" // exclusion_violation
+}
+
+const sessionColumns = `
+	ms.id, ms.mentor_id, ms.student_id, ms.starts_at, ms.ends_at, ms.status, ms.hold_until,
+	ms.reminder_sent_at, ms.created_at, mu.name AS mentor_name, su.name AS student_name
+`
+
+const sessionJoins = `
+	JOIN users mu ON mu.id = ms.mentor_id
+	JOIN users su ON su.id = ms.student_id
+`
+
+func (r *mentoringScheduleRepository) GetSessionByID(ctx context.Context,
+	sessionID uuid.UUID) (*entity.MentoringSession, error) {
+	return r.getSessionByID(ctx, r.db, sessionID)
+}
+
+func (r *mentoringScheduleRepository) getSessionByID(ctx context.Context, tx sqlx.QueryerContext,
+	sessionID uuid.UUID) (*entity.MentoringSession, error) {
+	query := `SELECT ` + sessionColumns + ` FROM mentoring_sessions ms ` + sessionJoins + ` WHERE ms.id = $1`
+
+	var session entity.MentoringSession
+	if err := sqlx.GetContext(ctx, tx, &session, query, sessionID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("session not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return &session, nil
+}
+
+func (r *mentoringScheduleRepository) GetPendingSession(ctx context.Context, studentID, mentorID uuid.UUID,
+	startsAt time.Time) (*entity.MentoringSession, error) {
+	query := `SELECT ` + sessionColumns + ` FROM mentoring_sessions ms ` + sessionJoins + `
+		WHERE ms.student_id = $1
+		  AND ms.mentor_id = $2
+		  AND ms.starts_at = $3
+		  AND ms.status = $4
+		  AND ms.hold_until > CURRENT_TIMESTAMP
+	`
+
+	var session entity.MentoringSession
+	err := r.db.GetContext(ctx, &session, query, studentID, mentorID, startsAt, enum.MentoringSessionStatusPending)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("session not found: %w", err)
+		}
+		return nil, fmt.Errorf("failed to get pending session: %w", err)
+	}
+
+	return &session, nil
+}
+
+// GetActiveSessionsByMentor returns the booked sessions and unexpired holds overlapping the period
+func (r *mentoringScheduleRepository) GetActiveSessionsByMentor(ctx context.Context, mentorID uuid.UUID,
+	from, to time.Time) ([]*entity.MentoringSession, error) {
+	query := `SELECT ` + sessionColumns + ` FROM mentoring_sessions ms ` + sessionJoins + `
+		WHERE ms.mentor_id = $1
+		  AND ms.starts_at < $3
+		  AND ms.ends_at > $2
+		  AND (
+			  ms.status = $4
+			  OR (ms.status = $5 AND ms.hold_until > CURRENT_TIMESTAMP)
+		  )
+		ORDER BY ms.starts_at
+	`
+
+	var sessions []*entity.MentoringSession
+	err := r.db.SelectContext(ctx, &sessions, query, mentorID, from, to,
+		enum.MentoringSessionStatusBooked, enum.MentoringSessionStatusPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (r *mentoringScheduleRepository) GetBookedSessionsByUserID(ctx context.Context,
+	userID uuid.UUID) ([]*entity.MentoringSession, error) {
+	query := `SELECT ` + sessionColumns + ` FROM mentoring_sessions ms ` + sessionJoins + `
+		WHERE (ms.mentor_id = $1 OR ms.student_id = $1)
+		  AND ms.status = $2
+		ORDER BY ms.starts_at DESC
+	`
+
+	var sessions []*entity.MentoringSession
+	if err := r.db.SelectContext(ctx, &sessions, query, userID, enum.MentoringSessionStatusBooked); err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+func (r *mentoringScheduleRepository) ExtendSessionHold(ctx context.Context, sessionID uuid.UUID,
+	holdUntil time.Time) error {
+	query := `
+		UPDATE mentoring_sessions
+		SET hold_until = GREATEST(hold_until, $2)
+		WHERE id = $1
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, sessionID, holdUntil); err != nil {
+		return fmt.Errorf("failed to extend session hold: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateSessionStatus moves the session to the given status if it is currently in one of the from statuses
+func (r *mentoringScheduleRepository) UpdateSessionStatus(ctx context.Context, sessionID uuid.UUID,
+	from []enum.MentoringSessionStatus, to enum.MentoringSessionStatus) error {
+	return r.updateSessionStatus(ctx, r.db, sessionID, from, to)
+}
+
+func (r *mentoringScheduleRepository) updateSessionStatus(ctx context.Context, tx sqlx.ExtContext,
+	sessionID uuid.UUID, from []enum.MentoringSessionStatus, to enum.MentoringSessionStatus) error {
+	query, args, err := sqlx.In(`
+		UPDATE mentoring_sessions
+		SET status = ?,
+			hold_until = CASE WHEN ? = 'pending' THEN hold_until END
+		WHERE id = ?
+		  AND status IN (?)
+	`, to, to, sessionID, from)
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, tx.Rebind(query), args...)
+	if err != nil {
+		if isSessionOverlapError(err) {
+			return fmt.Errorf("session slot taken: %w", err)
+		}
+		return fmt.Errorf("failed to update session status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return errors.New("session not found")
+	}
+
+	return nil
+}
+
+// BookSession books a pending or cancelled session in the transaction and returns it.
+// When the slot is taken, the transaction is rolled back to before the booking so it can go on without it.
+func (r *mentoringScheduleRepository) BookSession(ctx context.Context, txWrapper database.ITransaction,
+	sessionID uuid.UUID) (*entity.MentoringSession, error) {
+	tx := txWrapper.GetTx()
+
+	if _, err := tx.ExecContext(ctx, `SAVEPOINT book_session`); err != nil {
+		return nil, fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	err := r.updateSessionStatus(ctx, tx, sessionID,
+		[]enum.MentoringSessionStatus{enum.MentoringSessionStatusPending, enum.MentoringSessionStatusCancelled},
+		enum.MentoringSessionStatusBooked)
+	if err != nil {
+		if _, rbErr := tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT book_session`); rbErr != nil {
+			return nil, fmt.Errorf("failed to roll back to savepoint: %w", rbErr)
+		}
+		return nil, err
+	}
+
+	return r.getSessionByID(ctx, tx, sessionID)
+}
+
+// ClaimSessionsForReminder marks the upcoming booked sessions as reminded and returns them,
+// so each reminder is sent once even with several API instances
+func (r *mentoringScheduleRepository) ClaimSessionsForReminder(ctx context.Context,
+	startsBefore time.Time) ([]*entity.MentoringSession, error) {
+	query := `
+		WITH claimed AS (
+			UPDATE mentoring_sessions
+			SET reminder_sent_at = CURRENT_TIMESTAMP
+			WHERE status = $1
+			  AND reminder_sent_at IS NULL
+			  AND starts_at > CURRENT_TIMESTAMP
+			  AND starts_at <= $2
+			RETURNING *
+		)
+		SELECT ` + sessionColumns + ` FROM claimed ms ` + sessionJoins
+
+	var sessions []*entity.MentoringSession
+	if err := r.db.SelectContext(ctx, &sessions, query, enum.MentoringSessionStatusBooked, startsBefore); err != nil {
+		return nil, fmt.Errorf("failed to claim sessions for reminder: %w", err)
+	}
+
+	return sessions, nil
+}
@@ -0,0 +1,583 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/contract"
+	"github.com/nathakusuma/elevateu-backend/domain/dto"
+	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
+	"github.com/nathakusuma/elevateu-backend/domain/errorpkg"
+	"github.com/nathakusuma/elevateu-backend/internal/infra/database"
+	"github.com/nathakusuma/elevateu-backend/pkg/ics"
+	"github.com/nathakusuma/elevateu-backend/pkg/log"
+	"github.com/nathakusuma/elevateu-backend/pkg/mail"
+	"github.com/nathakusuma/elevateu-backend/pkg/timeutil"
+	"github.com/nathakusuma/elevateu-backend/pkg/uuidpkg"
+)
+
+const (
+	sessionDuration        = time.Hour
+	maxSlotsRange          = 31 * 24 * time.Hour
+	reminderInterval       = time.Minute
+	reminderLeadTime       = time.Hour
+	calendarProductID      = "-//ElevateU//Mentoring Sessions//EN"
+	sessionReminderSubject = "[ElevateU] Upcoming Mentoring Session"
+)
+
+type mentoringScheduleService struct {
+	repo     contract.IMentoringScheduleRepository
+	userRepo contract.IUserRepository
+	mailer   mail.IMailer
+	uuid     uuidpkg.IUUID
+}
+
+func NewMentoringScheduleService(
+	repo contract.IMentoringScheduleRepository,
+	userRepo contract.IUserRepository,
+	mailer mail.IMailer,
+	uuid uuidpkg.IUUID,
+) contract.IMentoringScheduleService {
+	return &mentoringScheduleService{
+		repo:     repo,
+		userRepo: userRepo,
+		mailer:   mailer,
+		uuid:     uuid,
+	}
+}
+
+func (s *mentoringScheduleService) SetAvailability(ctx context.Context, mentorID uuid.UUID,
+	req dto.SetAvailabilityRequest) error {
+	availabilities := make([]*entity.MentorAvailability, 0, len(req.Slots))
+	for i := range req.Slots {
+		startMinute, endMinute, err := req.Slots[i].Minutes()
+		if err != nil {
+			return errorpkg.ErrValidation().WithDetail("Invalid slot time format. Use HH:MM.")
+		}
+
+		if startMinute >= endMinute {
+			return errorpkg.ErrValidation().WithDetail("Slot start time must be before its end time")
+		}
+
+		id, err := s.uuid.NewV7()
+		if err != nil {
+			traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+				"error": err,
+			}, "Failed to generate availability ID")
+			return errorpkg.ErrInternalServer().WithTraceID(traceID)
+		}
+
+		availabilities = append(availabilities, &entity.MentorAvailability{
+			ID:          id,
+			MentorID:    mentorID,
+			Weekday:     req.Slots[i].Weekday,
+			StartMinute: startMinute,
+			EndMinute:   endMinute,
+		})
+	}
+
+	sort.Slice(availabilities, func(i, j int) bool {
+		if availabilities[i].Weekday != availabilities[j].Weekday {
+			return availabilities[i].Weekday < availabilities[j].Weekday
+		}
+		return availabilities[i].StartMinute < availabilities[j].StartMinute
+	})
+	for i := 1; i < len(availabilities); i++ {
+		prev := availabilities[i-1]
+		if availabilities[i].Weekday == prev.Weekday && availabilities[i].StartMinute < prev.EndMinute {
+			return errorpkg.ErrValidation().WithDetail("Availability slots on the same day must not overlap")
+		}
+	}
+
+	if err := s.repo.ReplaceAvailabilities(ctx, mentorID, availabilities); err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":     err,
+			"mentor.id": mentorID,
+		}, "Failed to set availability")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"mentor.id":   mentorID,
+		"slots.count": len(availabilities),
+	}, "Mentor availability updated")
+
+	return nil
+}
+
+func (s *mentoringScheduleService) GetMentorAvailability(ctx context.Context,
+	mentorID uuid.UUID) (*dto.MentorAvailabilityResponse, error) {
+	availabilities, err := s.repo.GetAvailabilities(ctx, mentorID)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":     err,
+			"mentor.id": mentorID,
+		}, "Failed to get availability")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	now := time.Now()
+	exceptions, err := s.repo.GetAvailabilityExceptions(ctx, mentorID, now, now.Add(365*24*time.Hour))
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":     err,
+			"mentor.id": mentorID,
+		}, "Failed to get availability exceptions")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	resp := &dto.MentorAvailabilityResponse{
+		Timezone:   timeutil.Location.String(),
+		Slots:      make([]*dto.AvailabilitySlot, len(availabilities)),
+		Exceptions: make([]*dto.AvailabilityExceptionResponse, len(exceptions)),
+	}
+	for i, availability := range availabilities {
+		resp.Slots[i] = &dto.AvailabilitySlot{}
+		resp.Slots[i].PopulateFromEntity(availability)
+	}
+	for i, exception := range exceptions {
+		resp.Exceptions[i] = &dto.AvailabilityExceptionResponse{}
+		resp.Exceptions[i].PopulateFromEntity(exception)
+	}
+
+	return resp, nil
+}
+
+func (s *mentoringScheduleService) CreateAvailabilityException(ctx context.Context, mentorID uuid.UUID,
+	req dto.CreateAvailabilityExceptionRequest) (*dto.AvailabilityExceptionResponse, error) {
+	id, err := s.uuid.NewV7()
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+		}, "Failed to generate availability exception ID")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	exception := &entity.MentorAvailabilityException{
+		ID:       id,
+		MentorID: mentorID,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Reason:   req.Reason,
+	}
+
+	if err = s.repo.CreateAvailabilityException(ctx, exception); err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":     err,
+			"mentor.id": mentorID,
+		}, "Failed to create availability exception")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	resp := &dto.AvailabilityExceptionResponse{}
+	resp.PopulateFromEntity(exception)
+
+	return resp, nil
+}
+
+func (s *mentoringScheduleService) DeleteAvailabilityException(ctx context.Context,
+	mentorID, exceptionID uuid.UUID) error {
+	if err := s.repo.DeleteAvailabilityException(ctx, mentorID, exceptionID); err != nil {
+		if strings.HasPrefix(err.Error(), "availability exception not found") {
+			return errorpkg.ErrNotFound().WithDetail("Availability exception not found")
+		}
+
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":        err,
+			"mentor.id":    mentorID,
+			"exception.id": exceptionID,
+		}, "Failed to delete availability exception")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	return nil
+}
+
+func (s *mentoringScheduleService) GetAvailableSlots(ctx context.Context, mentorID uuid.UUID,
+	req dto.AvailableSlotsRequest) ([]*dto.AvailableSlotResponse, error) {
+	from, err := time.ParseInLocation(time.DateOnly, req.From, timeutil.Location)
+	if err != nil {
+		return nil, errorpkg.ErrValidation().WithDetail("Invalid from date")
+	}
+
+	to, err := time.ParseInLocation(time.DateOnly, req.To, timeutil.Location)
+	if err != nil {
+		return nil, errorpkg.ErrValidation().WithDetail("Invalid to date")
+	}
+
+	// the to date is inclusive
+	to = to.AddDate(0, 0, 1)
+	if !to.After(from) {
+		return nil, errorpkg.ErrValidation().WithDetail("The to date must not be before the from date")
+	}
+	if to.Sub(from) > maxSlotsRange {
+		return nil, errorpkg.ErrValidation().WithDetail("Date range must not exceed 31 days")
+	}
+
+	slots, err := s.computeSlots(ctx, mentorID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := make([]*dto.AvailableSlotResponse, len(slots))
+	for i, start := range slots {
+		resp[i] = &dto.AvailableSlotResponse{
+			StartsAt: start,
+			EndsAt:   start.Add(sessionDuration),
+		}
+	}
+
+	return resp, nil
+}
+
+// computeSlots returns the start times of the bookable sessions between from and to
+func (s *mentoringScheduleService) computeSlots(ctx context.Context, mentorID uuid.UUID,
+	from, to time.Time) ([]time.Time, error) {
+	availabilities, err := s.repo.GetAvailabilities(ctx, mentorID)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":     err,
+			"mentor.id": mentorID,
+		}, "Failed to get availability")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	exceptions, err := s.repo.GetAvailabilityExceptions(ctx, mentorID, from, to)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":     err,
+			"mentor.id": mentorID,
+		}, "Failed to get availability exceptions")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	sessions, err := s.repo.GetActiveSessionsByMentor(ctx, mentorID, from, to)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":     err,
+			"mentor.id": mentorID,
+		}, "Failed to get mentor sessions")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	now := time.Now()
+	slots := make([]time.Time, 0)
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		for _, availability := range availabilities {
+			if availability.Weekday != day.Weekday() {
+				continue
+			}
+
+			windowEnd := day.Add(time.Duration(availability.EndMinute) * time.Minute)
+			start := day.Add(time.Duration(availability.StartMinute) * time.Minute)
+			for ; !start.Add(sessionDuration).After(windowEnd); start = start.Add(sessionDuration) {
+				end := start.Add(sessionDuration)
+				if !start.After(now) || end.After(to) {
+					continue
+				}
+
+				if isBlocked(start, end, exceptions, sessions) {
+					continue
+				}
+
+				slots = append(slots, start)
+			}
+		}
+	}
+
+	sort.Slice(slots, func(i, j int) bool {
+		return slots[i].Before(slots[j])
+	})
+
+	return slots, nil
+}
+
+func isBlocked(start, end time.Time, exceptions []*entity.MentorAvailabilityException,
+	sessions []*entity.MentoringSession) bool {
+	for _, exception := range exceptions {
+		if start.Before(exception.EndsAt) && end.After(exception.StartsAt) {
+			return true
+		}
+	}
+
+	for _, session := range sessions {
+		if start.Before(session.EndsAt) && end.After(session.StartsAt) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *mentoringScheduleService) isSlotAvailable(ctx context.Context, mentorID uuid.UUID,
+	startsAt time.Time) (bool, error) {
+	day := startsAt.In(timeutil.Location)
+	day = time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, timeutil.Location)
+
+	slots, err := s.computeSlots(ctx, mentorID, day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return false, err
+	}
+
+	for _, slot := range slots {
+		if slot.Equal(startsAt) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// HoldSession reserves the slot for the student until the payment completes.
+// Retrying the payment for the same slot reuses the student's existing hold.
+func (s *mentoringScheduleService) HoldSession(ctx context.Context, studentID, mentorID uuid.UUID,
+	startsAt, holdUntil time.Time) (*dto.MentoringSessionResponse, error) {
+	session, err := s.repo.GetPendingSession(ctx, studentID, mentorID, startsAt)
+	if err == nil {
+		if err = s.repo.ExtendSessionHold(ctx, session.ID, holdUntil); err != nil {
+			traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+				"error":      err,
+				"session.id": session.ID,
+			}, "Failed to extend session hold")
+			return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+		}
+
+		resp := &dto.MentoringSessionResponse{}
+		resp.PopulateFromEntity(session)
+		return resp, nil
+	}
+	if !strings.HasPrefix(err.Error(), "session not found") {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"student.id": studentID,
+			"mentor.id":  mentorID,
+		}, "Failed to get pending session")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	available, err := s.isSlotAvailable(ctx, mentorID, startsAt)
+	if err != nil {
+		return nil, err
+	}
+	if !available {
+		return nil, errorpkg.ErrSessionSlotUnavailable()
+	}
+
+	id, err := s.uuid.NewV7()
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+		}, "Failed to generate session ID")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	session = &entity.MentoringSession{
+		ID:        id,
+		MentorID:  mentorID,
+		StudentID: studentID,
+		StartsAt:  startsAt,
+		EndsAt:    startsAt.Add(sessionDuration),
+		Status:    enum.MentoringSessionStatusPending,
+		HoldUntil: &holdUntil,
+	}
+
+	if err = s.repo.CreateSession(ctx, session); err != nil {
+		if strings.HasPrefix(err.Error(), "session slot taken") {
+			return nil, errorpkg.ErrSessionSlotUnavailable()
+		}
+
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"student.id": studentID,
+			"mentor.id":  mentorID,
+		}, "Failed to create session")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"session.id": session.ID,
+		"student.id": studentID,
+		"mentor.id":  mentorID,
+		"starts_at":  startsAt,
+	}, "Mentoring session held")
+
+	resp := &dto.MentoringSessionResponse{}
+	resp.PopulateFromEntity(session)
+
+	return resp, nil
+}
+
+// ConfirmSession books a held session once its payment succeeds.
+// A hold released by an expired payment is booked again if the slot is still free.
+// The session is booked in txWrapper, the transaction of the payment.
+func (s *mentoringScheduleService) ConfirmSession(ctx context.Context, txWrapper database.ITransaction,
+	sessionID uuid.UUID) (*dto.MentoringSessionResponse, error) {
+	session, err := s.repo.BookSession(ctx, txWrapper, sessionID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "session slot taken") {
+			return nil, errorpkg.ErrSessionSlotUnavailable()
+		}
+		if strings.HasPrefix(err.Error(), "session not found") {
+			return nil, errorpkg.ErrNotFound().WithDetail("Session not found")
+		}
+
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"session.id": sessionID,
+		}, "Failed to confirm session")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"session.id": sessionID,
+	}, "Mentoring session booked")
+
+	resp := &dto.MentoringSessionResponse{}
+	resp.PopulateFromEntity(session)
+
+	return resp, nil
+}
+
+func (s *mentoringScheduleService) ReleaseSession(ctx context.Context, sessionID uuid.UUID) error {
+	err := s.repo.UpdateSessionStatus(ctx, sessionID,
+		[]enum.MentoringSessionStatus{enum.MentoringSessionStatusPending},
+		enum.MentoringSessionStatusCancelled)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "session not found") {
+			return errorpkg.ErrNotFound().WithDetail("Session not found")
+		}
+
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"session.id": sessionID,
+		}, "Failed to release session")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"session.id": sessionID,
+	}, "Mentoring session released")
+
+	return nil
+}
+
+func (s *mentoringScheduleService) GetSessionsByUserID(ctx context.Context,
+	userID uuid.UUID) ([]*dto.MentoringSessionResponse, error) {
+	sessions, err := s.repo.GetBookedSessionsByUserID(ctx, userID)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"user.id": userID,
+		}, "Failed to get sessions")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	resp := make([]*dto.MentoringSessionResponse, len(sessions))
+	for i, session := range sessions {
+		resp[i] = &dto.MentoringSessionResponse{}
+		resp[i].PopulateFromEntity(session)
+	}
+
+	return resp, nil
+}
+
+func (s *mentoringScheduleService) ExportCalendar(ctx context.Context, userID uuid.UUID) ([]byte, error) {
+	sessions, err := s.repo.GetBookedSessionsByUserID(ctx, userID)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"user.id": userID,
+		}, "Failed to get sessions")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	events := make([]ics.Event, len(sessions))
+	for i, session := range sessions {
+		counterpart := session.MentorName
+		if session.MentorID == userID {
+			counterpart = session.StudentName
+		}
+
+		events[i] = ics.Event{
+			UID:         session.ID.String() + "@elevateu",
+			Start:       session.StartsAt,
+			End:         session.EndsAt,
+			Summary:     "ElevateU Mentoring Session with " + counterpart,
+			Description: "Skill Guidance session between " + session.MentorName + " and " + session.StudentName,
+		}
+	}
+
+	return ics.Generate(calendarProductID, events), nil
+}
+
+// StartReminderWorker periodically emails both participants of the sessions starting within the lead time
+func (s *mentoringScheduleService) StartReminderWorker() {
+	go func() {
+		ticker := time.NewTicker(reminderInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			s.sendReminders(context.Background())
+		}
+	}()
+}
+
+func (s *mentoringScheduleService) sendReminders(ctx context.Context) {
+	sessions, err := s.repo.ClaimSessionsForReminder(ctx, time.Now().Add(reminderLeadTime))
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error": err,
+		}, "Failed to claim sessions for reminder")
+		return
+	}
+
+	for _, session := range sessions {
+		s.sendReminder(ctx, session, session.StudentID, session.MentorName)
+		s.sendReminder(ctx, session, session.MentorID, session.StudentName)
+	}
+}
+
+func (s *mentoringScheduleService) sendReminder(ctx context.Context, session *entity.MentoringSession,
+	recipientID uuid.UUID, counterpart string) {
+	recipient, err := s.userRepo.GetUserByField(ctx, "id", recipientID)
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error":      err,
+			"session.id": session.ID,
+			"user.id":    recipientID,
+		}, "Failed to get session reminder recipient")
+		return
+	}
+
+	startsAt := session.StartsAt.In(timeutil.Location)
+	err = s.mailer.Send(
+		recipient.Email,
+		sessionReminderSubject,
+		"mentoring_session_reminder.html",
+		map[string]interface{}{
+			"name":        recipient.Name,
+			"counterpart": counterpart,
+			"date":        startsAt.Format("Monday, 2 January 2006"),
+			"time": fmt.Sprintf("%s - %s WIB", startsAt.Format("15:04"),
+				session.EndsAt.In(timeutil.Location).Format("15:04")),
+		})
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error":      err,
+			"session.id": session.ID,
+			"user.id":    recipientID,
+		}, "Failed to send session reminder email")
+		return
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"session.id": session.ID,
+		"user.id":    recipientID,
+	}, "Session reminder sent")
+}
@@ -2,49 +2,124 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/gofiber/contrib/websocket"
+	"github.com/bytedance/sonic"
 	"github.com/google/uuid"
 
 	"github.com/nathakusuma/elevateu-backend/domain/contract"
+	"github.com/nathakusuma/elevateu-backend/domain/ctxkey"
 	"github.com/nathakusuma/elevateu-backend/domain/dto"
 	"github.com/nathakusuma/elevateu-backend/domain/entity"
 	"github.com/nathakusuma/elevateu-backend/domain/enum"
 	"github.com/nathakusuma/elevateu-backend/domain/errorpkg"
+	"github.com/nathakusuma/elevateu-backend/internal/infra/cache"
+	"github.com/nathakusuma/elevateu-backend/internal/infra/database"
+	"github.com/nathakusuma/elevateu-backend/internal/infra/env"
 	"github.com/nathakusuma/elevateu-backend/pkg/fileutil"
 	"github.com/nathakusuma/elevateu-backend/pkg/log"
+	"github.com/nathakusuma/elevateu-backend/pkg/mail"
+	"github.com/nathakusuma/elevateu-backend/pkg/randgen"
+	"github.com/nathakusuma/elevateu-backend/pkg/timeutil"
 	"github.com/nathakusuma/elevateu-backend/pkg/uuidpkg"
+	"github.com/nathakusuma/elevateu-backend/pkg/wsconn"
+)
+
+type chatClient struct {
+	userID uuid.UUID
+	conn   *wsconn.Conn
+}
+
+// publishedMessage is the payload sent through the chat channel
+type publishedMessage struct {
+	OriginConnID   uuid.UUID      `json:"origin_conn_id"`
+	ExcludedUserID uuid.UUID      `json:"excluded_user_id"`
+	Event          *dto.ChatEvent `json:"event"`
+}
+
+var (
+	maxAttachmentSize      = 10 * fileutil.MegaByte
+	attachmentContentTypes = slices.Concat(fileutil.ImageContentTypes, fileutil.DocumentContentTypes)
+)
+
+const messageEditWindow = 15 * time.Minute
+
+// presenceTTL must outlast the websocket ping interval, as every ping refreshes it
+const presenceTTL = 90 * time.Second
+
+const (
+	expiryCheckInterval = time.Minute
+	expiryWarningTime   = 5 * time.Minute
+	extensionTokenTTL   = 24 * time.Hour
+	// expiredCloseWait gives clients time to answer the close frame before the connection is dropped
+	expiredCloseWait = 5 * time.Second
 )
 
 type mentoringService struct {
 	repo         contract.IMentoringRepository
 	userRepo     contract.IUserRepository
+	cache        cache.ICache
 	fileUtil     fileutil.IFileUtil
+	mailer       mail.IMailer
+	randGen      randgen.IRandGen
 	uuid         uuidpkg.IUUID
-	clients      map[string]map[uuid.UUID]*websocket.Conn
+	clients      map[string]map[uuid.UUID]*chatClient // chat ID -> connection ID -> client
 	clientsMutex sync.RWMutex
+
+	// chatSubscriptions holds the subscription of every chat channel with local clients,
+	// including the ones still being subscribed to
+	chatSubscriptions map[string]*chatSubscription
+}
+
+// chatSubscription is the pub/sub subscription of a chat channel. ready is closed once the
+// subscribe attempt finished, so other connections of the chat wait for it instead of subscribing again.
+type chatSubscription struct {
+	ready       chan struct{}
+	unsubscribe func() error
 }
 
 func NewMentoringService(
 	mentoringRepo contract.IMentoringRepository,
 	userRepo contract.IUserRepository,
+	cache cache.ICache,
 	fileUtil fileutil.IFileUtil,
+	mailer mail.IMailer,
+	randGen randgen.IRandGen,
 	uuidGen uuidpkg.IUUID,
 ) contract.IMentoringService {
 	return &mentoringService{
-		repo:     mentoringRepo,
-		userRepo: userRepo,
-		fileUtil: fileUtil,
-		uuid:     uuidGen,
-		clients:  make(map[string]map[uuid.UUID]*websocket.Conn),
+		repo:              mentoringRepo,
+		userRepo:          userRepo,
+		cache:             cache,
+		fileUtil:          fileUtil,
+		mailer:            mailer,
+		randGen:           randGen,
+		uuid:              uuidGen,
+		clients:           make(map[string]map[uuid.UUID]*chatClient),
+		chatSubscriptions: make(map[string]*chatSubscription),
 	}
 }
 
-func (s *mentoringService) CreateChat(ctx context.Context, mentorID,
+// CreateChat opens a chat. Paid chats are created in txWrapper, the transaction of their payment.
+// Trial chats record the trial in a transaction of their own, so txWrapper is nil for them.
+func (s *mentoringService) CreateChat(ctx context.Context, txWrapper database.ITransaction, mentorID,
 	studentID uuid.UUID, isTrial bool) (*dto.ChatResponse, error) {
+	return s.createChat(ctx, txWrapper, mentorID, studentID, isTrial, nil)
+}
+
+// CreateSessionChat opens the chat of a scheduled session until the given time,
+// keeping a later expiry the participants already have
+func (s *mentoringService) CreateSessionChat(ctx context.Context, txWrapper database.ITransaction, mentorID,
+	studentID uuid.UUID, expiresAt time.Time) (*dto.ChatResponse, error) {
+	return s.createChat(ctx, txWrapper, mentorID, studentID, false, &expiresAt)
+}
+
+func (s *mentoringService) createChat(ctx context.Context, txWrapper database.ITransaction, mentorID,
+	studentID uuid.UUID, isTrial bool, sessionExpiresAt *time.Time) (*dto.ChatResponse, error) {
 	mentor, err := s.userRepo.GetUserByField(ctx, "id", mentorID)
 	if err != nil {
 		if strings.HasPrefix(err.Error(), "user not found") {
@@ -62,6 +137,10 @@ func (s *mentoringService) CreateChat(ctx context.Context, mentorID,
 		return nil, errorpkg.ErrValidation().WithDetail("User is not a mentor")
 	}
 
+	if isTrial && (mentor.Mentor == nil || !mentor.Mentor.AcceptsTrial) {
+		return nil, errorpkg.ErrTrialNotOffered()
+	}
+
 	student, err := s.userRepo.GetUserByField(ctx, "id", studentID)
 	if err != nil {
 		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
@@ -101,13 +180,19 @@ func (s *mentoringService) CreateChat(ctx context.Context, mentorID,
 
 	expireDuration := 24 * time.Hour
 	if isTrial {
-		expireDuration = 15 * time.Minute
+		expireDuration = env.GetEnv().MentoringTrialDuration
 	}
 
 	var expiresAt time.Time
-	if currentChat != nil && currentChat.ExpiresAt.After(time.Now()) {
+	switch {
+	case sessionExpiresAt != nil:
+		expiresAt = *sessionExpiresAt
+		if currentChat != nil && currentChat.ExpiresAt.After(expiresAt) {
+			expiresAt = currentChat.ExpiresAt
+		}
+	case currentChat != nil && currentChat.ExpiresAt.After(time.Now()):
 		expiresAt = currentChat.ExpiresAt.Add(expireDuration)
-	} else {
+	default:
 		expiresAt = time.Now().Add(expireDuration)
 	}
 
@@ -121,13 +206,14 @@ func (s *mentoringService) CreateChat(ctx context.Context, mentorID,
 
 	var repoErr error
 	if isTrial {
-		repoErr = s.repo.CreateTrialChat(ctx, chat)
+		repoErr = s.repo.CreateTrialChat(ctx, chat, env.GetEnv().MentoringTrialsPerStudent)
 	} else {
-		repoErr = s.repo.CreateChat(ctx, chat)
+		repoErr = s.repo.CreateChat(ctx, txWrapper, chat)
 	}
 
 	if repoErr != nil {
-		if strings.HasPrefix(repoErr.Error(), "trial chat already exists") {
+		if strings.HasPrefix(repoErr.Error(), "trial chat already exists") ||
+			strings.HasPrefix(repoErr.Error(), "trial limit reached") {
 			return nil, errorpkg.ErrTrialUsed()
 		}
 		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
@@ -154,6 +240,28 @@ func (s *mentoringService) CreateChat(ctx context.Context, mentorID,
 	return response, nil
 }
 
+func (s *mentoringService) GetTrialAllowance(ctx context.Context,
+	studentID uuid.UUID) (*dto.TrialAllowanceResponse, error) {
+	trialsUsed, err := s.repo.CountTrialsByStudent(ctx, studentID)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"student.id": studentID,
+		}, "Failed to count trials")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	trialsPerStudent := env.GetEnv().MentoringTrialsPerStudent
+
+	return &dto.TrialAllowanceResponse{
+		TrialsPerStudent: trialsPerStudent,
+		TrialsUsed:       trialsUsed,
+		TrialsRemaining:  max(trialsPerStudent-trialsUsed, 0),
+		DurationSeconds:  int(env.GetEnv().MentoringTrialDuration.Seconds()),
+		MessageLimit:     env.GetEnv().MentoringTrialMessageLimit,
+	}, nil
+}
+
 func (s *mentoringService) GetChatsByUserID(ctx context.Context, userID uuid.UUID) ([]*dto.ChatResponse, error) {
 	chats, err := s.repo.GetChatsByUserID(ctx, userID)
 	if err != nil {
@@ -183,26 +291,32 @@ func (s *mentoringService) GetChatsByUserID(ctx context.Context, userID uuid.UUI
 }
 
 func (s *mentoringService) SendMessage(ctx context.Context, userID, chatID uuid.UUID,
-	message string) error {
+	req dto.SendMessageRequest) (*dto.MessageResponse, error) {
 	chat, err := s.repo.GetChatByID(ctx, chatID)
 	if err != nil {
 		if strings.HasPrefix(err.Error(), "chat not found") {
-			return errorpkg.ErrValidation().WithDetail("Chat not found")
+			return nil, errorpkg.ErrValidation().WithDetail("Chat not found")
 		}
 		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
 			"error":   err,
 			"chat.id": chatID,
 		}, "Failed to verify chat access")
-		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
 	}
 
 	isParticipant := chat.MentorID == userID || chat.StudentID == userID
 	if !isParticipant {
-		return errorpkg.ErrForbiddenUser().WithDetail("You don't have access to this chat")
+		return nil, errorpkg.ErrForbiddenUser().WithDetail("You don't have access to this chat")
 	}
 
 	if time.Now().After(chat.ExpiresAt) {
-		return errorpkg.ErrChatExpired()
+		return nil, errorpkg.ErrChatExpired()
+	}
+
+	if chat.IsTrial && userID == chat.StudentID {
+		if err = s.checkTrialMessageLimit(ctx, chat); err != nil {
+			return nil, err
+		}
 	}
 
 	messageID, err := s.uuid.NewV7()
@@ -211,31 +325,468 @@ func (s *mentoringService) SendMessage(ctx context.Context, userID, chatID uuid.
 			"error":   err,
 			"chat.id": chatID,
 		}, "Failed to generate message ID")
-		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
 	}
 
 	messageEntity := &entity.MentoringMessage{
 		ID:       messageID,
 		ChatID:   chatID,
 		SenderID: userID,
-		Message:  message,
+		Message:  req.Message,
+	}
+
+	if req.AttachmentID != nil {
+		attachment, err2 := s.repo.GetAttachmentByID(ctx, *req.AttachmentID)
+		if err2 != nil {
+			if strings.HasPrefix(err2.Error(), "attachment not found") {
+				return nil, errorpkg.ErrValidation().WithDetail("Attachment not found")
+			}
+			traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+				"error":         err2,
+				"attachment.id": *req.AttachmentID,
+			}, "Failed to get attachment")
+			return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+		}
+
+		// attachments can only be sent by their uploader, in the chat they were uploaded for
+		if attachment.ChatID != chatID || attachment.UploaderID != userID {
+			return nil, errorpkg.ErrValidation().WithDetail("Attachment not found")
+		}
+
+		if attachment.MessageID != nil {
+			return nil, errorpkg.ErrValidation().WithDetail("Attachment already sent")
+		}
+
+		if err = s.verifyAttachment(ctx, attachment); err != nil {
+			return nil, err
+		}
+
+		messageEntity.Attachment = attachment
 	}
 
 	if err = s.repo.SendMessage(ctx, messageEntity); err != nil {
+		if strings.HasPrefix(err.Error(), "attachment already sent") {
+			return nil, errorpkg.ErrValidation().WithDetail("Attachment already sent")
+		}
 		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
 			"error": err,
 		}, "Failed to send message")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	response := &dto.MessageResponse{}
+	if err = response.PopulateFromEntity(messageEntity, s.fileUtil.GetSignedURL); err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"message.id": messageID,
+		}, "Failed to populate message response")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	// the connection the message was sent through already has it
+	originConnID, _ := ctx.Value(ctxkey.ConnectionID).(uuid.UUID)
+
+	s.publish(ctx, chatID, publishedMessage{
+		OriginConnID: originConnID,
+		Event: &dto.ChatEvent{
+			Type: enum.ChatEventMessage,
+			Data: response,
+		},
+	})
+
+	return response, nil
+}
+
+// verifyAttachment checks the uploaded file itself, as the size and type in CreateAttachment are only
+// declared by the client. The attachment is updated with the real size and type.
+func (s *mentoringService) verifyAttachment(ctx context.Context, attachment *entity.MentoringAttachment) error {
+	path := fmt.Sprintf("mentoring_attachments/%s/%s", attachment.ChatID, attachment.ID)
+	attrs, err := s.fileUtil.GetAttributes(ctx, path)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+			"path":  path,
+		}, "Failed to get attachment attributes")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	if attrs == nil {
+		return errorpkg.ErrValidation().WithDetail("Attachment has not been uploaded")
+	}
+
+	if attrs.Size > maxAttachmentSize {
+		return errorpkg.ErrFileTooLarge().WithDetail(
+			fmt.Sprintf("File size is too large (%s). Please upload a file less than %s",
+				fileutil.ByteToAppropriateUnit(attrs.Size), fileutil.ByteToAppropriateUnit(maxAttachmentSize)))
+	}
+
+	contentType, _, _ := strings.Cut(attrs.ContentType, ";")
+	contentType = strings.TrimSpace(contentType)
+	if !slices.Contains(attachmentContentTypes, contentType) {
+		return errorpkg.ErrInvalidFileFormat().WithDetail(
+			fmt.Sprintf("File type %s is not allowed. Please upload a valid file", contentType))
+	}
+
+	attachment.Size = attrs.Size
+	attachment.ContentType = contentType
+
+	return nil
+}
+
+func (s *mentoringService) checkTrialMessageLimit(ctx context.Context, chat *entity.MentoringChat) error {
+	messageLimit := env.GetEnv().MentoringTrialMessageLimit
+	if messageLimit <= 0 {
+		return nil
+	}
+
+	sent, err := s.repo.CountMessagesBySender(ctx, chat.ID, chat.StudentID)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"chat.id": chat.ID,
+		}, "Failed to count trial messages")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	if sent >= messageLimit {
+		return errorpkg.ErrTrialMessageLimitReached()
+	}
+
+	return nil
+}
+
+func (s *mentoringService) CreateAttachment(ctx context.Context, userID, chatID uuid.UUID,
+	req dto.CreateAttachmentRequest) (*dto.CreateAttachmentResponse, error) {
+	chat, err := s.getParticipatedChat(ctx, userID, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if time.Now().After(chat.ExpiresAt) {
+		return nil, errorpkg.ErrChatExpired()
+	}
+
+	if req.Size > maxAttachmentSize {
+		return nil, errorpkg.ErrFileTooLarge().WithDetail(
+			fmt.Sprintf("File size is too large (%s). Please upload a file less than %s",
+				fileutil.ByteToAppropriateUnit(req.Size), fileutil.ByteToAppropriateUnit(maxAttachmentSize)))
+	}
+
+	if !slices.Contains(attachmentContentTypes, req.ContentType) {
+		return nil, errorpkg.ErrInvalidFileFormat().WithDetail(
+			fmt.Sprintf("File type %s is not allowed. Please upload a valid file", req.ContentType))
+	}
+
+	attachmentID, err := s.uuid.NewV7()
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"chat.id": chatID,
+		}, "Failed to generate attachment ID")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	attachment := &entity.MentoringAttachment{
+		ID:          attachmentID,
+		ChatID:      chatID,
+		UploaderID:  userID,
+		FileName:    req.FileName,
+		ContentType: req.ContentType,
+		Size:        req.Size,
+	}
+
+	if err = s.repo.CreateAttachment(ctx, attachment); err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"attachment": attachment,
+		}, "Failed to create attachment")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	uploadURL, err := s.fileUtil.GetUploadSignedURL(
+		fmt.Sprintf("mentoring_attachments/%s/%s", chatID, attachmentID), req.ContentType)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":         err,
+			"attachment.id": attachmentID,
+		}, "Failed to get attachment upload URL")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"attachment": attachment,
+	}, "Attachment created")
+
+	return &dto.CreateAttachmentResponse{
+		AttachmentID: attachmentID,
+		UploadURL:    uploadURL,
+	}, nil
+}
+
+func (s *mentoringService) EditMessage(ctx context.Context, userID, chatID, messageID uuid.UUID,
+	req dto.EditMessageRequest) (*dto.MessageResponse, error) {
+	message, err := s.getOwnMessage(ctx, userID, chatID, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	if time.Since(message.CreatedAt) > messageEditWindow {
+		return nil, errorpkg.ErrMessageEditWindowPassed()
+	}
+
+	message.Message = req.Message
+
+	if err = s.repo.UpdateMessage(ctx, message); err != nil {
+		if strings.HasPrefix(err.Error(), "message not found") {
+			return nil, errorpkg.ErrValidation().WithDetail("Message not found")
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"message.id": messageID,
+		}, "Failed to update message")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	response := &dto.MessageResponse{}
+	if err = response.PopulateFromEntity(message, s.fileUtil.GetSignedURL); err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"message.id": messageID,
+		}, "Failed to populate message response")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	originConnID, _ := ctx.Value(ctxkey.ConnectionID).(uuid.UUID)
+
+	s.publish(ctx, chatID, publishedMessage{
+		OriginConnID: originConnID,
+		Event: &dto.ChatEvent{
+			Type: enum.ChatEventMessageUpdated,
+			Data: response,
+		},
+	})
+
+	log.Info(ctx, map[string]interface{}{
+		"message.id": messageID,
+		"chat.id":    chatID,
+	}, "Message edited")
+
+	return response, nil
+}
+
+func (s *mentoringService) DeleteMessage(ctx context.Context, userID, chatID, messageID uuid.UUID) error {
+	message, err := s.getOwnMessage(ctx, userID, chatID, messageID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.repo.DeleteMessage(ctx, message); err != nil {
+		if strings.HasPrefix(err.Error(), "message not found") {
+			return errorpkg.ErrValidation().WithDetail("Message not found")
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"message.id": messageID,
+		}, "Failed to delete message")
 		return errorpkg.ErrInternalServer().WithTraceID(traceID)
 	}
 
 	response := &dto.MessageResponse{}
-	response.PopulateFromEntity(messageEntity)
+	if err = response.PopulateFromEntity(message, s.fileUtil.GetSignedURL); err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"message.id": messageID,
+		}, "Failed to populate message response")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	originConnID, _ := ctx.Value(ctxkey.ConnectionID).(uuid.UUID)
+
+	s.publish(ctx, chatID, publishedMessage{
+		OriginConnID: originConnID,
+		Event: &dto.ChatEvent{
+			Type: enum.ChatEventMessageDeleted,
+			Data: response,
+		},
+	})
+
+	log.Info(ctx, map[string]interface{}{
+		"message.id": messageID,
+		"chat.id":    chatID,
+	}, "Message deleted")
+
+	return nil
+}
+
+// getOwnMessage returns a message of the chat that was sent by the user and is not deleted
+func (s *mentoringService) getOwnMessage(ctx context.Context, userID, chatID,
+	messageID uuid.UUID) (*entity.MentoringMessage, error) {
+	if _, err := s.getParticipatedChat(ctx, userID, chatID); err != nil {
+		return nil, err
+	}
+
+	message, err := s.repo.GetMessageByID(ctx, messageID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "message not found") {
+			return nil, errorpkg.ErrValidation().WithDetail("Message not found")
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"message.id": messageID,
+		}, "Failed to get message")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	if message.ChatID != chatID || message.DeletedAt != nil {
+		return nil, errorpkg.ErrValidation().WithDetail("Message not found")
+	}
+
+	if message.SenderID != userID {
+		return nil, errorpkg.ErrForbiddenUser().WithDetail("You can only change your own messages")
+	}
+
+	return message, nil
+}
+
+func (s *mentoringService) MarkChatRead(ctx context.Context, userID, chatID, messageID uuid.UUID) error {
+	if _, err := s.getParticipatedChat(ctx, userID, chatID); err != nil {
+		return err
+	}
+
+	message, err := s.repo.GetMessageByID(ctx, messageID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "message not found") {
+			return errorpkg.ErrValidation().WithDetail("Message not found")
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"message.id": messageID,
+		}, "Failed to get message")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	if message.ChatID != chatID {
+		return errorpkg.ErrValidation().WithDetail("Message not found")
+	}
+
+	updated, err := s.repo.MarkChatRead(ctx, chatID, userID, messageID)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"chat.id":    chatID,
+			"message.id": messageID,
+		}, "Failed to mark chat read")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	// already read up to this message or a later one, nothing new to tell the other participant
+	if !updated {
+		return nil
+	}
+
+	originConnID, _ := ctx.Value(ctxkey.ConnectionID).(uuid.UUID)
+
+	s.publish(ctx, chatID, publishedMessage{
+		OriginConnID: originConnID,
+		Event: &dto.ChatEvent{
+			Type: enum.ChatEventRead,
+			Data: &dto.ReadReceiptResponse{
+				ChatID:    chatID,
+				UserID:    userID,
+				MessageID: messageID,
+				ReadAt:    time.Now(),
+			},
+		},
+	})
+
+	return nil
+}
 
-	s.BroadcastMessage(response, chatID)
+// SetTyping tells the other participant whether the user is typing.
+// It is meant for registered websocket clients, whose chat access was checked on registration.
+func (s *mentoringService) SetTyping(ctx context.Context, userID, chatID uuid.UUID, isTyping bool) error {
+	s.publish(ctx, chatID, publishedMessage{
+		ExcludedUserID: userID,
+		Event: &dto.ChatEvent{
+			Type: enum.ChatEventTyping,
+			Data: &dto.TypingResponse{
+				UserID:   userID,
+				IsTyping: isTyping,
+			},
+		},
+	})
 
 	return nil
 }
 
+func (s *mentoringService) getParticipatedChat(ctx context.Context, userID,
+	chatID uuid.UUID) (*entity.MentoringChat, error) {
+	chat, err := s.repo.GetChatByID(ctx, chatID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "chat not found") {
+			return nil, errorpkg.ErrValidation().WithDetail("Chat not found")
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"chat.id": chatID,
+		}, "Failed to verify chat access")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	isParticipant := chat.MentorID == userID || chat.StudentID == userID
+	if !isParticipant {
+		return nil, errorpkg.ErrForbiddenUser().WithDetail("You don't have access to this chat")
+	}
+
+	return chat, nil
+}
+
+func chatChannel(chatID uuid.UUID) string {
+	return "mentoring-chat:" + chatID.String()
+}
+
+// publish fans the payload out to every instance holding clients of the chat.
+// The change is already stored, so on failure it is at least delivered to local clients.
+func (s *mentoringService) publish(ctx context.Context, chatID uuid.UUID, published publishedMessage) {
+	publishedJSON, err := sonic.Marshal(published)
+	if err == nil {
+		err = s.cache.Publish(ctx, chatChannel(chatID), string(publishedJSON))
+	}
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error":   err,
+			"chat.id": chatID,
+		}, "Failed to publish message")
+		s.BroadcastEvent(published.Event, chatID, published.OriginConnID, published.ExcludedUserID)
+	}
+}
+
+// subscribeChat subscribes to the chat channel and broadcasts its messages to the local clients.
+// It must not be called with clientsMutex held, as it waits for Redis.
+func (s *mentoringService) subscribeChat(chatID uuid.UUID) (func() error, error) {
+	messages, unsubscribe, err := s.cache.Subscribe(context.Background(), chatChannel(chatID))
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		for messageJSON := range messages {
+			var published publishedMessage
+			if err2 := sonic.Unmarshal([]byte(messageJSON), &published); err2 != nil || published.Event == nil {
+				log.Error(context.Background(), map[string]interface{}{
+					"error":   err2,
+					"chat.id": chatID,
+				}, "Failed to unmarshal published message")
+				continue
+			}
+
+			s.BroadcastEvent(published.Event, chatID, published.OriginConnID, published.ExcludedUserID)
+		}
+	}()
+
+	return unsubscribe, nil
+}
+
 func (s *mentoringService) GetMessages(ctx context.Context, userID uuid.UUID, chatID uuid.UUID,
 	pageReq dto.PaginationRequest) ([]*dto.MessageResponse, dto.PaginationResponse, error) {
 
@@ -270,72 +821,435 @@ func (s *mentoringService) GetMessages(ctx context.Context, userID uuid.UUID, ch
 	responses := make([]*dto.MessageResponse, len(messages))
 	for i, message := range messages {
 		responses[i] = &dto.MessageResponse{}
-		responses[i].PopulateFromEntity(message)
+		if err = responses[i].PopulateFromEntity(message, s.fileUtil.GetSignedURL); err != nil {
+			traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+				"error":      err,
+				"message.id": message.ID,
+			}, "Failed to populate message response")
+			return nil, dto.PaginationResponse{}, errorpkg.ErrInternalServer().WithTraceID(traceID)
+		}
 	}
 
 	return responses, pageResp, nil
 }
 
-func (s *mentoringService) RegisterClient(userID uuid.UUID, chatID uuid.UUID, conn *websocket.Conn) {
-	chatIDStr := chatID.String()
+func (s *mentoringService) RegisterClient(userID uuid.UUID, chatID uuid.UUID, conn *wsconn.Conn) error {
+	ctx := context.Background()
 
-	s.clientsMutex.Lock()
-	defer s.clientsMutex.Unlock()
+	chat, err := s.getParticipatedChat(ctx, userID, chatID)
+	if err != nil {
+		return err
+	}
 
-	if _, ok := s.clients[chatIDStr]; !ok {
-		s.clients[chatIDStr] = make(map[uuid.UUID]*websocket.Conn)
+	if time.Now().After(chat.ExpiresAt) {
+		return errorpkg.ErrChatExpired()
 	}
 
-	s.clients[chatIDStr][userID] = conn
+	if err = s.addClient(userID, chatID, conn); err != nil {
+		return err
+	}
 
-	log.Info(context.Background(), map[string]interface{}{
+	log.Info(ctx, map[string]interface{}{
 		"user.id": userID,
 		"chat.id": chatID,
+		"conn.id": conn.ID,
 	}, "Client registered for chat")
+
+	s.updatePresence(ctx, userID, chatID, 1)
+
+	// let the new connection know whether the other participant is already here
+	otherUserID := chat.MentorID
+	if userID == chat.MentorID {
+		otherUserID = chat.StudentID
+	}
+
+	if err = conn.WriteJSON(&dto.ChatEvent{
+		Type: enum.ChatEventPresence,
+		Data: &dto.PresenceResponse{
+			UserID:   otherUserID,
+			IsOnline: s.isOnline(ctx, otherUserID, chatID),
+		},
+	}); err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error":   err,
+			"user.id": userID,
+			"chat.id": chatID,
+			"conn.id": conn.ID,
+		}, "Failed to send presence to client")
+	}
+
+	return nil
 }
 
-func (s *mentoringService) UnregisterClient(userID uuid.UUID, chatID uuid.UUID) {
+func (s *mentoringService) addClient(userID uuid.UUID, chatID uuid.UUID, conn *wsconn.Conn) error {
 	chatIDStr := chatID.String()
+	client := &chatClient{
+		userID: userID,
+		conn:   conn,
+	}
 
-	s.clientsMutex.Lock()
-	defer s.clientsMutex.Unlock()
+	for {
+		s.clientsMutex.Lock()
 
-	if chatClients, ok := s.clients[chatIDStr]; ok {
-		delete(chatClients, userID)
+		if chatClients, ok := s.clients[chatIDStr]; ok {
+			chatClients[conn.ID] = client
+			s.clientsMutex.Unlock()
+			return nil
+		}
 
-		if len(chatClients) == 0 {
-			delete(s.clients, chatIDStr)
+		// another connection of the chat is subscribing, check again once it is done
+		if sub, ok := s.chatSubscriptions[chatIDStr]; ok {
+			s.clientsMutex.Unlock()
+			<-sub.ready
+			continue
 		}
 
-		log.Info(context.Background(), map[string]interface{}{
+		sub := &chatSubscription{ready: make(chan struct{})}
+		s.chatSubscriptions[chatIDStr] = sub
+		s.clientsMutex.Unlock()
+
+		unsubscribe, err := s.subscribeChat(chatID)
+
+		s.clientsMutex.Lock()
+		if err != nil {
+			delete(s.chatSubscriptions, chatIDStr)
+		} else {
+			sub.unsubscribe = unsubscribe
+			s.clients[chatIDStr] = map[uuid.UUID]*chatClient{conn.ID: client}
+		}
+		close(sub.ready)
+		s.clientsMutex.Unlock()
+
+		if err != nil {
+			traceID := log.ErrorWithTraceID(context.Background(), map[string]interface{}{
+				"error":   err,
+				"user.id": userID,
+				"chat.id": chatID,
+			}, "Failed to subscribe to chat channel")
+			return errorpkg.ErrInternalServer().WithTraceID(traceID)
+		}
+
+		return nil
+	}
+}
+
+func (s *mentoringService) UnregisterClient(userID uuid.UUID, chatID uuid.UUID, conn *wsconn.Conn) {
+	if s.removeClient(userID, chatID, conn) {
+		s.updatePresence(context.Background(), userID, chatID, -1)
+	}
+}
+
+// KeepAlive keeps the user's presence in the chat from expiring while the connection is alive
+func (s *mentoringService) KeepAlive(userID uuid.UUID, chatID uuid.UUID) {
+	if _, err := s.cache.IncrBy(context.Background(), presenceKey(userID, chatID), 0, presenceTTL); err != nil {
+		log.Error(context.Background(), map[string]interface{}{
+			"error":   err,
+			"user.id": userID,
+			"chat.id": chatID,
+		}, "Failed to refresh presence")
+	}
+}
+
+func presenceKey(userID, chatID uuid.UUID) string {
+	return "mentoring-presence:" + chatID.String() + ":" + userID.String()
+}
+
+// updatePresence counts the user's connections to the chat across instances
+// and tells the other participant when the user comes online or goes offline
+func (s *mentoringService) updatePresence(ctx context.Context, userID, chatID uuid.UUID, delta int64) {
+	count, err := s.cache.IncrBy(ctx, presenceKey(userID, chatID), delta, presenceTTL)
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error":   err,
 			"user.id": userID,
 			"chat.id": chatID,
-		}, "Client unregistered from chat")
+		}, "Failed to update presence")
+		return
+	}
+
+	var isOnline bool
+	switch {
+	case delta > 0 && count == delta:
+		isOnline = true
+	case delta < 0 && count <= 0:
+		if err = s.cache.Del(ctx, presenceKey(userID, chatID)); err != nil {
+			log.Error(ctx, map[string]interface{}{
+				"error":   err,
+				"user.id": userID,
+				"chat.id": chatID,
+			}, "Failed to delete presence")
+		}
+	default:
+		// other connections of the user keep the status unchanged
+		return
+	}
+
+	s.publish(ctx, chatID, publishedMessage{
+		ExcludedUserID: userID,
+		Event: &dto.ChatEvent{
+			Type: enum.ChatEventPresence,
+			Data: &dto.PresenceResponse{
+				UserID:   userID,
+				IsOnline: isOnline,
+			},
+		},
+	})
+}
+
+func (s *mentoringService) isOnline(ctx context.Context, userID, chatID uuid.UUID) bool {
+	var count int64
+	if err := s.cache.Get(ctx, presenceKey(userID, chatID), &count); err != nil {
+		if !strings.HasPrefix(err.Error(), "not found") {
+			log.Error(ctx, map[string]interface{}{
+				"error":   err,
+				"user.id": userID,
+				"chat.id": chatID,
+			}, "Failed to get presence")
+		}
+		return false
 	}
+
+	return count > 0
 }
 
-func (s *mentoringService) BroadcastMessage(message *dto.MessageResponse, chatID uuid.UUID) {
+// removeClient reports whether the connection was registered
+func (s *mentoringService) removeClient(userID uuid.UUID, chatID uuid.UUID, conn *wsconn.Conn) bool {
 	chatIDStr := chatID.String()
 
-	s.clientsMutex.RLock()
-	defer s.clientsMutex.RUnlock()
+	s.clientsMutex.Lock()
 
 	chatClients, ok := s.clients[chatIDStr]
 	if !ok {
-		return
+		s.clientsMutex.Unlock()
+		return false
 	}
 
-	for userID, conn := range chatClients {
-		if userID == message.SenderID {
+	if _, ok = chatClients[conn.ID]; !ok {
+		s.clientsMutex.Unlock()
+		return false
+	}
+
+	delete(chatClients, conn.ID)
+
+	var sub *chatSubscription
+	if len(chatClients) == 0 {
+		delete(s.clients, chatIDStr)
+		sub = s.chatSubscriptions[chatIDStr]
+		delete(s.chatSubscriptions, chatIDStr)
+	}
+
+	s.clientsMutex.Unlock()
+
+	// unsubscribe outside the lock, a new connection of the chat subscribes again on its own
+	if sub != nil {
+		if err := sub.unsubscribe(); err != nil {
+			log.Error(context.Background(), map[string]interface{}{
+				"error":   err,
+				"chat.id": chatID,
+			}, "Failed to unsubscribe from chat channel")
+		}
+	}
+
+	log.Info(context.Background(), map[string]interface{}{
+		"user.id": userID,
+		"chat.id": chatID,
+		"conn.id": conn.ID,
+	}, "Client unregistered from chat")
+
+	return true
+}
+
+// BroadcastEvent delivers the event to every local connection of the chat except the one it came from
+// and, if excludedUserID is set, except the connections of that user
+func (s *mentoringService) BroadcastEvent(event *dto.ChatEvent, chatID, originConnID, excludedUserID uuid.UUID) {
+	chatIDStr := chatID.String()
+
+	// copy the targets, so a slow client does not hold the lock while it is written to
+	s.clientsMutex.RLock()
+	targets := make([]*chatClient, 0, len(s.clients[chatIDStr]))
+	for connID, client := range s.clients[chatIDStr] {
+		if connID == originConnID || (excludedUserID != uuid.Nil && client.userID == excludedUserID) {
 			continue
 		}
+		targets = append(targets, client)
+	}
+	s.clientsMutex.RUnlock()
 
-		if err := conn.WriteJSON(message); err != nil {
+	for _, client := range targets {
+		if err := client.conn.WriteJSON(event); err != nil {
 			log.Error(context.Background(), map[string]interface{}{
 				"error":   err,
-				"user.id": userID,
+				"user.id": client.userID,
 				"chat.id": chatID,
-			}, "Failed to send message to client")
+				"conn.id": client.conn.ID,
+			}, "Failed to send event to client")
+		}
+
+		if event.Type == enum.ChatEventExpired {
+			client.conn.Shutdown("chat expired", expiredCloseWait)
 		}
 	}
 }
+
+// StartExpiryWorker periodically warns the participants of chats about to expire,
+// and closes the connections of chats that expired
+func (s *mentoringService) StartExpiryWorker() {
+	go func() {
+		ticker := time.NewTicker(expiryCheckInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			ctx := context.Background()
+			s.warnExpiringChats(ctx)
+			s.closeExpiredChats(ctx)
+		}
+	}()
+}
+
+func (s *mentoringService) warnExpiringChats(ctx context.Context) {
+	chats, err := s.repo.ClaimChatsForExpiryWarning(ctx, time.Now().Add(expiryWarningTime))
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error": err,
+		}, "Failed to claim chats for expiry warning")
+		return
+	}
+
+	for _, chat := range chats {
+		s.publish(ctx, chat.ID, publishedMessage{
+			Event: &dto.ChatEvent{
+				Type: enum.ChatEventExpiring,
+				Data: &dto.ChatExpiryResponse{
+					ChatID:    chat.ID,
+					ExpiresAt: chat.ExpiresAt,
+				},
+			},
+		})
+
+		// a trial chat is not offered an extension, the student buys Skill Guidance on their own
+		if !chat.IsTrial {
+			s.sendExtensionEmail(ctx, chat)
+		}
+	}
+}
+
+func (s *mentoringService) closeExpiredChats(ctx context.Context) {
+	chats, err := s.repo.ClaimExpiredChats(ctx)
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error": err,
+		}, "Failed to claim expired chats")
+		return
+	}
+
+	for _, chat := range chats {
+		s.publish(ctx, chat.ID, publishedMessage{
+			Event: &dto.ChatEvent{
+				Type: enum.ChatEventExpired,
+				Data: &dto.ChatExpiryResponse{
+					ChatID:    chat.ID,
+					ExpiresAt: chat.ExpiresAt,
+				},
+			},
+		})
+	}
+}
+
+func extensionTokenKey(token string) string {
+	return "mentoring-chat-extension:" + token
+}
+
+// sendExtensionEmail emails the student a link that starts a payment for another 24 hours of the chat
+func (s *mentoringService) sendExtensionEmail(ctx context.Context, chat *entity.MentoringChat) {
+	student, err := s.userRepo.GetUserByField(ctx, "id", chat.StudentID)
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error":   err,
+			"chat.id": chat.ID,
+		}, "Failed to get student for expiry email")
+		return
+	}
+
+	mentor, err := s.userRepo.GetUserByField(ctx, "id", chat.MentorID)
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error":   err,
+			"chat.id": chat.ID,
+		}, "Failed to get mentor for expiry email")
+		return
+	}
+
+	token, err := s.randGen.RandomString(32)
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error":   err,
+			"chat.id": chat.ID,
+		}, "Failed to generate extension token")
+		return
+	}
+
+	extensionJSON, err := sonic.Marshal(&dto.ChatExtension{
+		ChatID:    chat.ID,
+		StudentID: chat.StudentID,
+		MentorID:  chat.MentorID,
+	})
+	if err == nil {
+		err = s.cache.Set(ctx, extensionTokenKey(token), string(extensionJSON), extensionTokenTTL)
+	}
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error":   err,
+			"chat.id": chat.ID,
+		}, "Failed to store extension token")
+		return
+	}
+
+	err = s.mailer.Send(
+		student.Email,
+		"[ElevateU] Your Chat with "+mentor.Name+" Is About to End",
+		"mentoring_chat_expiring.html",
+		map[string]interface{}{
+			"name":       student.Name,
+			"mentor":     mentor.Name,
+			"expires_at": chat.ExpiresAt.In(timeutil.Location).Format("2 January 2006 15:04 WIB"),
+			"extend_url": env.GetEnv().AppURL + "/api/v1/payments/skill-guidance/extend?token=" + token,
+		})
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error":   err,
+			"chat.id": chat.ID,
+		}, "Failed to send expiry email")
+		return
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"chat.id":    chat.ID,
+		"student.id": chat.StudentID,
+	}, "Chat expiry email sent")
+}
+
+// ResolveExtensionToken returns the chat an extension link was sent for.
+// The token stays valid until it expires, so the link can be opened again if the payment is abandoned.
+func (s *mentoringService) ResolveExtensionToken(ctx context.Context, token string) (*dto.ChatExtension, error) {
+	var extensionJSON string
+	if err := s.cache.Get(ctx, extensionTokenKey(token), &extensionJSON); err != nil {
+		if strings.HasPrefix(err.Error(), "not found") {
+			return nil, errorpkg.ErrNotFound().WithDetail("Extension link is invalid or has expired")
+		}
+
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+		}, "Failed to get extension token")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	var extension dto.ChatExtension
+	if err := sonic.Unmarshal([]byte(extensionJSON), &extension); err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+		}, "Failed to unmarshal extension token")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	return &extension, nil
+}
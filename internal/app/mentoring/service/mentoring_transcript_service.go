@@ -0,0 +1,312 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/contract"
+	"github.com/nathakusuma/elevateu-backend/domain/dto"
+	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
+	"github.com/nathakusuma/elevateu-backend/domain/errorpkg"
+	"github.com/nathakusuma/elevateu-backend/pkg/fileutil"
+	"github.com/nathakusuma/elevateu-backend/pkg/log"
+	"github.com/nathakusuma/elevateu-backend/pkg/pdf"
+	"github.com/nathakusuma/elevateu-backend/pkg/timeutil"
+	"github.com/nathakusuma/elevateu-backend/pkg/uuidpkg"
+)
+
+const (
+	transcriptPageSize = 100
+	transcriptTitle    = "Mentoring Chat Transcript"
+)
+
+type mentoringTranscriptService struct {
+	repo     contract.IMentoringRepository
+	userRepo contract.IUserRepository
+	fileUtil fileutil.IFileUtil
+	pdf      pdf.IPDF
+	uuid     uuidpkg.IUUID
+}
+
+func NewMentoringTranscriptService(
+	repo contract.IMentoringRepository,
+	userRepo contract.IUserRepository,
+	fileUtil fileutil.IFileUtil,
+	pdf pdf.IPDF,
+	uuid uuidpkg.IUUID,
+) contract.IMentoringTranscriptService {
+	return &mentoringTranscriptService{
+		repo:     repo,
+		userRepo: userRepo,
+		fileUtil: fileUtil,
+		pdf:      pdf,
+		uuid:     uuid,
+	}
+}
+
+// CreateTranscript queues the export of the whole chat history. The file is generated in the background;
+// poll GetTranscript until the status is ready to get the download URL.
+func (s *mentoringTranscriptService) CreateTranscript(ctx context.Context, userID, chatID uuid.UUID,
+	req dto.CreateTranscriptRequest) (*dto.TranscriptResponse, error) {
+	chat, err := s.repo.GetChatByID(ctx, chatID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "chat not found") {
+			return nil, errorpkg.ErrNotFound().WithDetail("Chat not found")
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"chat.id": chatID,
+		}, "Failed to verify chat access")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	isParticipant := chat.MentorID == userID || chat.StudentID == userID
+	if !isParticipant {
+		return nil, errorpkg.ErrForbiddenUser().WithDetail("You don't have access to this chat")
+	}
+
+	transcriptID, err := s.uuid.NewV7()
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error": err,
+		}, "Failed to generate transcript ID")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	transcript := &entity.MentoringTranscript{
+		ID:          transcriptID,
+		ChatID:      chatID,
+		RequesterID: userID,
+		Format:      req.Format,
+		Status:      enum.TranscriptStatusPending,
+	}
+
+	if err = s.repo.CreateTranscript(ctx, transcript); err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"chat.id": chatID,
+		}, "Failed to create transcript")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	go s.generateTranscript(context.Background(), transcript, chat)
+
+	log.Info(ctx, map[string]interface{}{
+		"transcript.id": transcript.ID,
+		"chat.id":       chatID,
+		"format":        transcript.Format,
+	}, "Transcript requested")
+
+	return &dto.TranscriptResponse{
+		ID:        transcript.ID,
+		ChatID:    transcript.ChatID,
+		Format:    transcript.Format,
+		Status:    transcript.Status,
+		CreatedAt: transcript.CreatedAt,
+	}, nil
+}
+
+func (s *mentoringTranscriptService) GetTranscript(ctx context.Context, userID,
+	transcriptID uuid.UUID) (*dto.TranscriptResponse, error) {
+	transcript, err := s.repo.GetTranscriptByID(ctx, transcriptID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "transcript not found") {
+			return nil, errorpkg.ErrNotFound().WithDetail("Transcript not found")
+		}
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":         err,
+			"transcript.id": transcriptID,
+		}, "Failed to get transcript")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	if transcript.RequesterID != userID {
+		return nil, errorpkg.ErrForbiddenUser().WithDetail("You don't have access to this transcript")
+	}
+
+	resp := &dto.TranscriptResponse{
+		ID:          transcript.ID,
+		ChatID:      transcript.ChatID,
+		Format:      transcript.Format,
+		Status:      transcript.Status,
+		CreatedAt:   transcript.CreatedAt,
+		CompletedAt: transcript.CompletedAt,
+	}
+
+	if transcript.Status == enum.TranscriptStatusReady {
+		resp.URL, err = s.fileUtil.GetSignedURL(transcriptPath(transcript))
+		if err != nil {
+			traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+				"error":         err,
+				"transcript.id": transcriptID,
+			}, "Failed to get transcript URL")
+			return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *mentoringTranscriptService) generateTranscript(ctx context.Context, transcript *entity.MentoringTranscript,
+	chat *entity.MentoringChat) {
+	status := enum.TranscriptStatusReady
+	if err := s.renderAndUpload(ctx, transcript, chat); err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error":         err,
+			"transcript.id": transcript.ID,
+			"chat.id":       chat.ID,
+		}, "Failed to generate transcript")
+		status = enum.TranscriptStatusFailed
+	}
+
+	if err := s.repo.UpdateTranscriptStatus(ctx, transcript.ID, status); err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error":         err,
+			"transcript.id": transcript.ID,
+		}, "Failed to update transcript status")
+	}
+}
+
+func (s *mentoringTranscriptService) renderAndUpload(ctx context.Context, transcript *entity.MentoringTranscript,
+	chat *entity.MentoringChat) error {
+	mentor, err := s.userRepo.GetUserByField(ctx, "id", chat.MentorID)
+	if err != nil {
+		return fmt.Errorf("failed to get mentor: %w", err)
+	}
+
+	student, err := s.userRepo.GetUserByField(ctx, "id", chat.StudentID)
+	if err != nil {
+		return fmt.Errorf("failed to get student: %w", err)
+	}
+
+	messages, err := s.getAllMessages(ctx, chat.ID)
+	if err != nil {
+		return err
+	}
+
+	names := map[uuid.UUID]string{
+		mentor.ID:  mentor.Name,
+		student.ID: student.Name,
+	}
+
+	content := pdf.Transcript{
+		Title:       transcriptTitle,
+		MentorName:  mentor.Name,
+		StudentName: student.Name,
+		GeneratedAt: time.Now().In(timeutil.Location),
+		Messages:    make([]pdf.TranscriptMessage, len(messages)),
+	}
+	for i, message := range messages {
+		content.Messages[i] = pdf.TranscriptMessage{
+			SenderName: names[message.SenderID],
+			SentAt:     message.CreatedAt.In(timeutil.Location),
+			Text:       transcriptText(message),
+		}
+	}
+
+	var file []byte
+	switch transcript.Format {
+	case enum.TranscriptFormatPDF:
+		file, err = s.pdf.GenerateTranscript(content)
+		if err != nil {
+			return err
+		}
+	case enum.TranscriptFormatMarkdown:
+		file = renderMarkdownTranscript(content)
+	default:
+		return fmt.Errorf("unknown transcript format %q", transcript.Format)
+	}
+
+	if _, err = s.fileUtil.Upload(ctx, bytes.NewReader(file), transcriptPath(transcript), ""); err != nil {
+		return fmt.Errorf("failed to upload transcript: %w", err)
+	}
+
+	return nil
+}
+
+// getAllMessages walks every page of the chat history and returns the messages oldest first
+func (s *mentoringTranscriptService) getAllMessages(ctx context.Context,
+	chatID uuid.UUID) ([]*entity.MentoringMessage, error) {
+	var messages []*entity.MentoringMessage
+	pageReq := dto.PaginationRequest{
+		Limit:     transcriptPageSize,
+		Direction: "next",
+	}
+
+	for {
+		page, pageResp, err := s.repo.GetMessages(ctx, chatID, pageReq)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, page...)
+		if !pageResp.HasMore || len(page) == 0 {
+			break
+		}
+
+		pageReq.Cursor = page[len(page)-1].ID
+	}
+
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
+
+	return messages, nil
+}
+
+func transcriptText(message *entity.MentoringMessage) string {
+	if message.DeletedAt != nil {
+		return "[message deleted]"
+	}
+
+	var lines []string
+	if message.Message != "" {
+		lines = append(lines, message.Message)
+	}
+	if message.Attachment != nil {
+		lines = append(lines, fmt.Sprintf("[attachment: %s]", message.Attachment.FileName))
+	}
+	if message.EditedAt != nil {
+		lines = append(lines, "(edited)")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func renderMarkdownTranscript(transcript pdf.Transcript) []byte {
+	var sb strings.Builder
+
+	sb.WriteString("# " + transcript.Title + "\n\n")
+	sb.WriteString("- **Mentor:** " + transcript.MentorName + "\n")
+	sb.WriteString("- **Student:** " + transcript.StudentName + "\n")
+	sb.WriteString("- **Generated At:** " + transcript.GeneratedAt.Format("02 January 2006 15:04 MST") + "\n\n")
+	sb.WriteString("---\n")
+
+	if len(transcript.Messages) == 0 {
+		sb.WriteString("\n_No messages in this chat._\n")
+	}
+
+	for _, message := range transcript.Messages {
+		sb.WriteString(fmt.Sprintf("\n**%s** · %s\n\n", message.SenderName,
+			message.SentAt.Format("02 Jan 2006 15:04 MST")))
+		// Keep line breaks inside a message as hard breaks
+		sb.WriteString(strings.ReplaceAll(message.Text, "\n", "  \n") + "\n")
+	}
+
+	return []byte(sb.String())
+}
+
+func transcriptPath(transcript *entity.MentoringTranscript) string {
+	extension := ".pdf"
+	if transcript.Format == enum.TranscriptFormatMarkdown {
+		extension = ".md"
+	}
+
+	return fmt.Sprintf("mentoring_transcripts/%s/%s%s", transcript.ChatID, transcript.ID, extension)
+}
@@ -1,6 +1,13 @@
 package handler
 
 import (
+	"bufio"
+	"context"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/bytedance/sonic"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 
@@ -14,6 +21,31 @@ import (
 	"github.com/nathakusuma/elevateu-backend/pkg/validator"
 )
 
+const (
+	statusStreamHeartbeatInterval = 15 * time.Second
+	statusStreamMaxDuration       = 30 * time.Minute
+)
+
+// extendConfirmPage is opened by the chat expiry email link. The payment only starts once the form is submitted,
+// so email link scanners opening the link do not start one.
+var extendConfirmPage = template.Must(template.New("extend").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>Extend Skill Guidance - ElevateU</title>
+</head>
+<body>
+  <h1>Extend your Skill Guidance chat</h1>
+  <p>Continue to the payment page to chat with your mentor for another 24 hours.</p>
+  <form method="post" action="extend">
+    <input type="hidden" name="token" value="{{.}}">
+    <button type="submit">Continue to payment</button>
+  </form>
+</body>
+</html>
+`))
+
 type paymentHandler struct {
 	svc contract.IPaymentService
 	val validator.IValidator
@@ -33,23 +65,44 @@ func InitPaymentHandler(
 	paymentGroup := router.Group("/payments")
 	paymentGroup.Post("/midtrans/notifications", handler.midtransNotification)
 
+	idempotent := midw.Idempotent(24 * time.Hour)
+
 	paymentGroup.Post("/skill-boost",
 		midw.RequireAuthenticated,
 		midw.RequireOneOfRoles(enum.UserRoleStudent),
+		idempotent,
 		handler.paySkillBoost)
 	paymentGroup.Post("/skill-challenge",
 		midw.RequireAuthenticated,
 		midw.RequireOneOfRoles(enum.UserRoleStudent),
+		idempotent,
 		handler.paySkillChallenge)
 	paymentGroup.Post("/skill-guidance",
 		midw.RequireAuthenticated,
 		midw.RequireOneOfRoles(enum.UserRoleStudent),
+		idempotent,
 		handler.paySkillGuidance)
+	// opened from the chat expiry email, the token identifies the student
+	paymentGroup.Get("/skill-guidance/extend", handler.confirmExtendSkillGuidance)
+	paymentGroup.Post("/skill-guidance/extend", handler.extendSkillGuidance)
+	paymentGroup.Post("/course",
+		midw.RequireAuthenticated,
+		midw.RequireOneOfRoles(enum.UserRoleStudent),
+		idempotent,
+		handler.payCourse)
 
 	paymentGroup.Get("/my",
 		midw.RequireAuthenticated,
 		midw.RequireOneOfRoles(enum.UserRoleStudent),
 		handler.getPayments)
+	paymentGroup.Get("/:id/receipt",
+		midw.RequireAuthenticated,
+		midw.RequireOneOfRoles(enum.UserRoleStudent),
+		handler.getReceipt)
+	paymentGroup.Get("/:id/status-stream",
+		midw.RequireAuthenticated,
+		midw.RequireOneOfRoles(enum.UserRoleStudent),
+		handler.streamPaymentStatus)
 	paymentGroup.Get("/mentor/transaction-histories",
 		midw.RequireAuthenticated,
 		midw.RequireOneOfRoles(enum.UserRoleMentor),
@@ -111,7 +164,8 @@ func (h *paymentHandler) paySkillGuidance(ctx *fiber.Ctx) error {
 	}
 
 	var req struct {
-		MentorID uuid.UUID `json:"mentor_id" validate:"required"`
+		MentorID uuid.UUID  `json:"mentor_id" validate:"required"`
+		StartsAt *time.Time `json:"starts_at"`
 	}
 	if err := ctx.BodyParser(&req); err != nil {
 		return errorpkg.ErrFailParseRequest()
@@ -121,7 +175,59 @@ func (h *paymentHandler) paySkillGuidance(ctx *fiber.Ctx) error {
 		return err
 	}
 
-	paymentToken, err := h.svc.PaySkillGuidance(ctx.Context(), studentID, req.MentorID)
+	paymentToken, err := h.svc.PaySkillGuidance(ctx.Context(), studentID, req.MentorID, req.StartsAt)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(map[string]any{
+		"payment_token": paymentToken,
+	})
+}
+
+func (h *paymentHandler) confirmExtendSkillGuidance(ctx *fiber.Ctx) error {
+	token := ctx.Query("token")
+	if token == "" {
+		return errorpkg.ErrValidation().WithDetail("Token is required")
+	}
+
+	ctx.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+	return extendConfirmPage.Execute(ctx, token)
+}
+
+func (h *paymentHandler) extendSkillGuidance(ctx *fiber.Ctx) error {
+	token := ctx.FormValue("token")
+	if token == "" {
+		return errorpkg.ErrValidation().WithDetail("Token is required")
+	}
+
+	redirectURL, err := h.svc.ExtendSkillGuidance(ctx.Context(), token)
+	if err != nil {
+		return err
+	}
+
+	return ctx.Redirect(redirectURL, fiber.StatusSeeOther)
+}
+
+func (h *paymentHandler) payCourse(ctx *fiber.Ctx) error {
+	studentID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	var req struct {
+		CourseID uuid.UUID `json:"course_id" validate:"required"`
+	}
+	if err := ctx.BodyParser(&req); err != nil {
+		return errorpkg.ErrFailParseRequest()
+	}
+
+	if err := h.val.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	paymentToken, err := h.svc.PayCourse(ctx.Context(), studentID, req.CourseID)
 	if err != nil {
 		return err
 	}
@@ -184,3 +290,96 @@ func (h *paymentHandler) getMentorTransactionHistories(ctx *fiber.Ctx) error {
 		"pagination":                   pageResp,
 	})
 }
+
+func (h *paymentHandler) getReceipt(ctx *fiber.Ctx) error {
+	studentID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	paymentID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("Invalid payment ID")
+	}
+
+	url, err := h.svc.GetReceiptURL(ctx.Context(), studentID, paymentID)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(map[string]any{
+		"receipt_url": url,
+	})
+}
+
+func (h *paymentHandler) streamPaymentStatus(ctx *fiber.Ctx) error {
+	studentID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+	if !ok {
+		traceID := log.ErrorWithTraceID(ctx.Context(), nil, "Failed to get user ID from context")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	paymentID, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return errorpkg.ErrValidation().WithDetail("Invalid payment ID")
+	}
+
+	events, unsubscribe, err := h.svc.SubscribePaymentStatus(ctx.Context(), studentID, paymentID)
+	if err != nil {
+		return err
+	}
+
+	ctx.Set(fiber.HeaderContentType, "text/event-stream")
+	ctx.Set(fiber.HeaderCacheControl, "no-cache")
+	ctx.Set(fiber.HeaderConnection, "keep-alive")
+	ctx.Set("X-Accel-Buffering", "no")
+
+	// The stream writer runs after the handler returns, so the fiber context must not be used inside it
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		heartbeat := time.NewTicker(statusStreamHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		timeout := time.NewTimer(statusStreamMaxDuration)
+		defer timeout.Stop()
+
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+
+				data, err := sonic.Marshal(event)
+				if err != nil {
+					log.Error(context.Background(), map[string]interface{}{
+						"error":      err,
+						"payment.id": paymentID,
+					}, "Failed to marshal payment status event")
+					return
+				}
+
+				fmt.Fprintf(w, "event: status\ndata: %s\n\n", data)
+				if err = w.Flush(); err != nil {
+					// client disconnected
+					return
+				}
+
+				if event.IsFinal() {
+					return
+				}
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": ping\n\n")
+				if err := w.Flush(); err != nil {
+					return
+				}
+			case <-timeout.C:
+				return
+			}
+		}
+	})
+
+	return nil
+}
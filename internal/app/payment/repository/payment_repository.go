@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 	"github.com/nathakusuma/elevateu-backend/domain/contract"
 	"github.com/nathakusuma/elevateu-backend/domain/dto"
 	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
 	"github.com/nathakusuma/elevateu-backend/internal/infra/database"
 )
 
@@ -32,20 +35,28 @@ func (r *paymentRepository) CreatePayment(ctx context.Context, txWrapper databas
 			user_id,
 		    token,
 			amount,
+			type,
+			product_id,
 			title,
 			detail,
 			method,
 			status,
+			discount,
+			badge,
 		    expired_at
 		) VALUES (
 			:id,
 			:user_id,
 			:token,
 			:amount,
+			:type,
+			:product_id,
 			:title,
 			:detail,
 			:method,
 			:status,
+			:discount,
+			:badge,
 			:expired_at
 		)
 	`, payment)
@@ -93,16 +104,27 @@ func (r *paymentRepository) GetPaymentByID(ctx context.Context, txWrapper databa
 			user_id,
 			token,
 			amount,
+			type,
+			product_id,
 			title,
 			detail,
 			method,
 			status,
+			invoice_number,
+			discount,
+			badge,
+			has_receipt,
+			refund_reason,
+			paid_at,
 			expired_at,
 			created_at,
 			updated_at
 		FROM payments
 		WHERE id = $1
 	`, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("payment not found: %w", err)
+		}
 		return nil, fmt.Errorf("failed to get payment by ID: %w", err)
 	}
 
@@ -117,10 +139,18 @@ func (r *paymentRepository) GetPaymentsByStudent(ctx context.Context, studentID
 			user_id,
 			token,
 			amount,
+			type,
+			product_id,
 			title,
 			detail,
 			method,
 			status,
+			invoice_number,
+			discount,
+			badge,
+			has_receipt,
+			refund_reason,
+			paid_at,
 			expired_at,
 			created_at,
 			updated_at
@@ -260,11 +290,13 @@ func (r *paymentRepository) UpdatePayment(ctx context.Context, txWrapper databas
 	payment *entity.Payment) error {
 	tx := txWrapper.GetTx()
 
+	// paid_at keeps the first success, so reports count the payment on the day it was paid
 	_, err := sqlx.NamedExecContext(ctx, tx, `
 		UPDATE payments
 		SET
 			method = :method,
 			status = :status,
+			paid_at = CASE WHEN :status = 'success' THEN COALESCE(paid_at, NOW()) ELSE paid_at END,
 			updated_at = NOW()
 		WHERE id = :id
 	`, payment)
@@ -275,6 +307,51 @@ func (r *paymentRepository) UpdatePayment(ctx context.Context, txWrapper databas
 	return nil
 }
 
+func (r *paymentRepository) AssignInvoiceNumber(ctx context.Context, txWrapper database.ITransaction,
+	id uuid.UUID) (int64, error) {
+	tx := txWrapper.GetTx()
+
+	// COALESCE keeps the number stable when the gateway re-sends a success notification
+	var invoiceNumber int64
+	if err := tx.GetContext(ctx, &invoiceNumber, `
+		UPDATE payments
+		SET invoice_number = COALESCE(invoice_number, nextval('payment_invoice_number_seq'))
+		WHERE id = $1
+		RETURNING invoice_number
+	`, id); err != nil {
+		return 0, fmt.Errorf("failed to assign invoice number: %w", err)
+	}
+
+	return invoiceNumber, nil
+}
+
+func (r *paymentRepository) MarkReceiptGenerated(ctx context.Context, id uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE payments SET has_receipt = TRUE
+		WHERE id = $1
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark receipt generated: %w", err)
+	}
+
+	return nil
+}
+
+func (r *paymentRepository) MarkForRefund(ctx context.Context, txWrapper database.ITransaction,
+	id uuid.UUID, reason string) error {
+	tx := txWrapper.GetTx()
+
+	_, err := tx.ExecContext(ctx, `
+		UPDATE payments SET refund_reason = $1
+		WHERE id = $2
+	`, reason, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark payment for refund: %w", err)
+	}
+
+	return nil
+}
+
 func (r *paymentRepository) AddBoostSubscription(ctx context.Context, txWrapper database.ITransaction,
 	studentID uuid.UUID, subscribedUntil time.Time) error {
 	tx := txWrapper.GetTx()
@@ -322,3 +399,92 @@ func (r *paymentRepository) AddMentorBalance(ctx context.Context, txWrapper data
 
 	return nil
 }
+
+func (r *paymentRepository) IsCoursePurchased(ctx context.Context, courseID, studentID uuid.UUID) (bool, error) {
+	var isPurchased bool
+	err := r.db.GetContext(ctx, &isPurchased, `
+		SELECT EXISTS (
+			SELECT 1 FROM course_enrollments
+			WHERE course_id = $1 AND student_id = $2 AND is_purchased = TRUE
+		)
+	`, courseID, studentID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check course purchase: %w", err)
+	}
+
+	return isPurchased, nil
+}
+
+func (r *paymentRepository) AddCoursePurchase(ctx context.Context, txWrapper database.ITransaction,
+	courseID, studentID uuid.UUID) error {
+	tx := txWrapper.GetTx()
+
+	// Students who enrolled through Skill Boost keep their progress
+	var isInserted bool
+	err := tx.GetContext(ctx, &isInserted, `
+		INSERT INTO course_enrollments (course_id, student_id, is_purchased)
+		VALUES ($1, $2, TRUE)
+		ON CONFLICT (course_id, student_id) DO UPDATE SET is_purchased = TRUE
+		RETURNING (xmax = 0)
+	`, courseID, studentID)
+	if err != nil {
+		return fmt.Errorf("failed to add course purchase: %w", err)
+	}
+
+	if isInserted {
+		_, err = tx.ExecContext(ctx, `
+			UPDATE courses SET enrollment_count = enrollment_count + 1
+			WHERE id = $1
+		`, courseID)
+		if err != nil {
+			return fmt.Errorf("failed to update enrollment count: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *paymentRepository) GetReusablePayment(ctx context.Context, userID uuid.UUID, paymentType enum.PaymentType,
+	productID *uuid.UUID, amount int, validUntil time.Time) (*entity.Payment, error) {
+	var payment entity.Payment
+	err := r.db.GetContext(ctx, &payment, `
+		SELECT
+			id,
+			user_id,
+			token,
+			amount,
+			type,
+			product_id,
+			title,
+			detail,
+			method,
+			status,
+			invoice_number,
+			discount,
+			badge,
+			has_receipt,
+			refund_reason,
+			paid_at,
+			expired_at,
+			created_at,
+			updated_at
+		FROM payments
+		WHERE user_id = $1
+			AND type = $2
+			AND product_id IS NOT DISTINCT FROM $3
+			AND amount = $4
+			AND status = 'pending'
+			AND expired_at > $5
+		ORDER BY expired_at DESC
+		LIMIT 1
+	`, userID, paymentType, productID, amount, validUntil)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("payment not found: %w", err)
+		}
+
+		return nil, fmt.Errorf("failed to get reusable payment: %w", err)
+	}
+
+	return &payment, nil
+}
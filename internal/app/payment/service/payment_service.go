@@ -1,9 +1,12 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/bytedance/sonic"
@@ -16,17 +19,26 @@ import (
 	"github.com/nathakusuma/elevateu-backend/domain/errorpkg"
 	"github.com/nathakusuma/elevateu-backend/internal/infra/cache"
 	"github.com/nathakusuma/elevateu-backend/internal/infra/database"
+	"github.com/nathakusuma/elevateu-backend/pkg/fileutil"
 	"github.com/nathakusuma/elevateu-backend/pkg/log"
+	"github.com/nathakusuma/elevateu-backend/pkg/mail"
 	"github.com/nathakusuma/elevateu-backend/pkg/payment"
+	"github.com/nathakusuma/elevateu-backend/pkg/pdf"
+	"github.com/nathakusuma/elevateu-backend/pkg/timeutil"
 	"github.com/nathakusuma/elevateu-backend/pkg/uuidpkg"
 )
 
 type paymentService struct {
 	repo           contract.IPaymentRepository
 	mentoringSvc   contract.IMentoringService
+	scheduleSvc    contract.IMentoringScheduleService
 	userSvc        contract.IUserService
+	courseSvc      contract.ICourseService
 	cache          cache.ICache
+	fileUtil       fileutil.IFileUtil
+	mailer         mail.IMailer
 	paymentGateway payment.IPaymentGateway
+	pdf            pdf.IPDF
 	txManager      database.ITransactionManager
 	uuid           uuidpkg.IUUID
 }
@@ -34,24 +46,65 @@ type paymentService struct {
 func NewPaymentService(
 	repo contract.IPaymentRepository,
 	mentoringSvc contract.IMentoringService,
+	scheduleSvc contract.IMentoringScheduleService,
 	userSvc contract.IUserService,
+	courseSvc contract.ICourseService,
 	cache cache.ICache,
+	fileUtil fileutil.IFileUtil,
+	mailer mail.IMailer,
 	paymentGateway payment.IPaymentGateway,
+	pdf pdf.IPDF,
 	txManager database.ITransactionManager,
 	uuid uuidpkg.IUUID,
 ) contract.IPaymentService {
 	return &paymentService{
 		repo:           repo,
 		mentoringSvc:   mentoringSvc,
+		scheduleSvc:    scheduleSvc,
 		userSvc:        userSvc,
+		courseSvc:      courseSvc,
 		cache:          cache,
+		fileUtil:       fileUtil,
+		mailer:         mailer,
 		paymentGateway: paymentGateway,
+		pdf:            pdf,
 		txManager:      txManager,
 		uuid:           uuid,
 	}
 }
 
+// pendingPaymentReuseMargin leaves the student enough time to complete a reused payment before it expires
+const pendingPaymentReuseMargin = 10 * time.Minute
+
 func (s *paymentService) createPayment(ctx context.Context, req dto.CreatePaymentRequest) (string, error) {
+	var productID *uuid.UUID
+	switch {
+	case req.Payload.SessionID != uuid.Nil:
+		productID = &req.Payload.SessionID
+	case req.Payload.MentorID != uuid.Nil:
+		productID = &req.Payload.MentorID
+	case req.Payload.CourseID != uuid.Nil:
+		productID = &req.Payload.CourseID
+	}
+
+	// Reuse an unfinished payment for the same product instead of creating another gateway transaction
+	existing, err := s.repo.GetReusablePayment(ctx, req.UserID, req.Payload.Type, productID, req.Amount,
+		time.Now().Add(pendingPaymentReuseMargin))
+	if err == nil {
+		log.Info(ctx, map[string]interface{}{
+			"payment.id": existing.ID,
+			"request":    req,
+		}, "Pending payment reused")
+		return existing.Token, nil
+	}
+	if !strings.HasPrefix(err.Error(), "payment not found") {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"request": req,
+		}, "Failed to get reusable payment")
+		return "", errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
 	tx, err := s.txManager.BeginTx(ctx)
 	if err != nil {
 		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
@@ -85,9 +138,13 @@ func (s *paymentService) createPayment(ctx context.Context, req dto.CreatePaymen
 		UserID:    req.UserID,
 		Token:     token,
 		Amount:    req.Amount,
+		Type:      req.Payload.Type,
+		ProductID: productID,
 		Title:     req.Title,
 		Detail:    req.Detail,
 		Status:    enum.PaymentStatusPending,
+		Discount:  req.Discount,
+		Badge:     req.Badge,
 		ExpiredAt: time.Now().Add(1 * time.Hour),
 	}
 
@@ -184,7 +241,7 @@ func (s *paymentService) UpdatePaymentStatus(ctx context.Context, id uuid.UUID,
 
 	paymentEntity, err := s.repo.GetPaymentByID(ctx, tx, id)
 	if err != nil {
-		if strings.HasPrefix(err.Error(), "payment payload not found") {
+		if strings.HasPrefix(err.Error(), "payment not found") {
 			return errorpkg.ErrNotFound()
 		}
 
@@ -196,6 +253,8 @@ func (s *paymentService) UpdatePaymentStatus(ctx context.Context, id uuid.UUID,
 		return errorpkg.ErrInternalServer().WithTraceID(traceID)
 	}
 
+	isNewlySucceeded := status == enum.PaymentStatusSuccess && paymentEntity.Status != enum.PaymentStatusSuccess
+
 	paymentEntity.Status = status
 	paymentEntity.Method = method
 
@@ -210,6 +269,17 @@ func (s *paymentService) UpdatePaymentStatus(ctx context.Context, id uuid.UUID,
 	}
 
 	if status == enum.PaymentStatusSuccess {
+		invoiceNumber, err2 := s.repo.AssignInvoiceNumber(ctx, tx, id)
+		if err2 != nil {
+			traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+				"error":          err2,
+				"payment.id":     id,
+				"payment.status": status,
+			}, "Failed to assign invoice number")
+			return errorpkg.ErrInternalServer().WithTraceID(traceID)
+		}
+		paymentEntity.InvoiceNumber = &invoiceNumber
+
 		var payloadJSON string
 		if err = s.cache.Get(ctx, "payment:"+id.String(), &payloadJSON); err != nil {
 			traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
@@ -244,6 +314,10 @@ func (s *paymentService) UpdatePaymentStatus(ctx context.Context, id uuid.UUID,
 			if err := s.triggerSkillGuidance(ctx, tx, payload, paymentEntity); err != nil {
 				return err
 			}
+		case enum.PaymentTypeCourse:
+			if err := s.triggerCoursePurchase(ctx, tx, payload, paymentEntity); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -261,9 +335,303 @@ func (s *paymentService) UpdatePaymentStatus(ctx context.Context, id uuid.UUID,
 		"payment.status": status,
 	}, "Payment status updated")
 
+	s.publishPaymentStatus(ctx, paymentEntity)
+
+	if status == enum.PaymentStatusFailure && paymentEntity.Type == enum.PaymentTypeGuidance {
+		s.releaseHeldSession(ctx, id)
+	}
+
+	if isNewlySucceeded {
+		// the request context is recycled once the notification is answered
+		go s.sendReceipt(context.Background(), paymentEntity)
+
+		if paymentEntity.RefundReason != nil {
+			go s.sendSessionUnavailable(context.Background(), paymentEntity)
+		}
+	}
+
 	return nil
 }
 
+func paymentStatusChannel(id uuid.UUID) string {
+	return "payment-status:" + id.String()
+}
+
+// publishPaymentStatus notifies status stream subscribers on every API instance.
+// Failing to publish is not fatal since clients can still fall back to fetching their payments.
+func (s *paymentService) publishPaymentStatus(ctx context.Context, paymentEntity *entity.Payment) {
+	var event dto.PaymentStatusEvent
+	event.PopulateFromEntity(paymentEntity)
+	event.UpdatedAt = time.Now()
+
+	eventJSON, err := sonic.Marshal(event)
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error":      err,
+			"payment.id": paymentEntity.ID,
+		}, "Failed to marshal payment status event")
+		return
+	}
+
+	if err = s.cache.Publish(ctx, paymentStatusChannel(paymentEntity.ID), string(eventJSON)); err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error":      err,
+			"payment.id": paymentEntity.ID,
+		}, "Failed to publish payment status event")
+	}
+}
+
+func (s *paymentService) SubscribePaymentStatus(ctx context.Context, studentID,
+	paymentID uuid.UUID) (<-chan *dto.PaymentStatusEvent, func(), error) {
+	// Subscribe before reading the current status so no transition in between is missed
+	messages, unsubscribe, err := s.cache.Subscribe(ctx, paymentStatusChannel(paymentID))
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"payment.id": paymentID,
+		}, "Failed to subscribe to payment status")
+		return nil, nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	done := make(chan struct{})
+	var closeOnce sync.Once
+	closeSubscription := func() {
+		closeOnce.Do(func() { close(done) })
+		if err2 := unsubscribe(); err2 != nil {
+			log.Error(context.Background(), map[string]interface{}{
+				"error":      err2,
+				"payment.id": paymentID,
+			}, "Failed to unsubscribe from payment status")
+		}
+	}
+
+	current, err := s.getOwnedPayment(ctx, studentID, paymentID)
+	if err != nil {
+		closeSubscription()
+		return nil, nil, err
+	}
+
+	var initialEvent dto.PaymentStatusEvent
+	initialEvent.PopulateFromEntity(current)
+
+	events := make(chan *dto.PaymentStatusEvent, 1)
+	events <- &initialEvent
+
+	go func() {
+		defer close(events)
+		for message := range messages {
+			var event dto.PaymentStatusEvent
+			if err2 := sonic.Unmarshal([]byte(message), &event); err2 != nil {
+				log.Error(context.Background(), map[string]interface{}{
+					"error":      err2,
+					"payment.id": paymentID,
+				}, "Failed to unmarshal payment status event")
+				continue
+			}
+			select {
+			case events <- &event:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return events, closeSubscription, nil
+}
+
+func (s *paymentService) getOwnedPayment(ctx context.Context, studentID,
+	paymentID uuid.UUID) (*entity.Payment, error) {
+	tx, err := s.txManager.BeginTx(ctx)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"payment.id": paymentID,
+		}, "Failed to begin transaction")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+	defer tx.Rollback()
+
+	paymentEntity, err := s.repo.GetPaymentByID(ctx, tx, paymentID)
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "payment not found") {
+			return nil, errorpkg.ErrNotFound()
+		}
+
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"payment.id": paymentID,
+		}, "Failed to get payment by ID")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	if paymentEntity.UserID != studentID {
+		return nil, errorpkg.ErrForbiddenUser().WithDetail("You don't have access to this payment")
+	}
+
+	return paymentEntity, nil
+}
+
+func (s *paymentService) GetReceiptURL(ctx context.Context, studentID, paymentID uuid.UUID) (string, error) {
+	paymentEntity, err := s.getOwnedPayment(ctx, studentID, paymentID)
+	if err != nil {
+		return "", err
+	}
+
+	if paymentEntity.Status != enum.PaymentStatusSuccess || paymentEntity.InvoiceNumber == nil {
+		return "", errorpkg.ErrReceiptNotAvailable()
+	}
+
+	// Receipt generation may have failed right after the payment succeeded, so generate it on demand
+	if !paymentEntity.HasReceipt {
+		if _, _, err = s.generateReceipt(ctx, paymentEntity); err != nil {
+			traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+				"error":      err,
+				"payment.id": paymentID,
+			}, "Failed to generate receipt")
+			return "", errorpkg.ErrInternalServer().WithTraceID(traceID)
+		}
+	}
+
+	url, err := s.fileUtil.GetSignedURL("payments/receipt/" + paymentID.String())
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"payment.id": paymentID,
+		}, "Failed to get receipt signed URL")
+		return "", errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	return url, nil
+}
+
+// generateReceipt renders the receipt PDF, stores it and returns the file content along with the student
+func (s *paymentService) generateReceipt(ctx context.Context,
+	paymentEntity *entity.Payment) ([]byte, *dto.UserResponse, error) {
+	student, err := s.userSvc.GetUserByID(ctx, paymentEntity.UserID, false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get student: %w", err)
+	}
+
+	receipt := pdf.Receipt{
+		InvoiceNumber: dto.FormatInvoiceNumber(*paymentEntity.InvoiceNumber, paymentEntity.CreatedAt),
+		IssuedAt:      paymentEntity.UpdatedAt,
+		CustomerName:  student.Name,
+		CustomerEmail: student.Email,
+		PaymentID:     paymentEntity.ID.String(),
+		Item:          paymentEntity.Title,
+		Method:        strings.ReplaceAll(paymentEntity.Method, "_", " "),
+		Subtotal:      paymentEntity.Amount + paymentEntity.Discount,
+		Discount:      paymentEntity.Discount,
+		Total:         paymentEntity.Amount,
+	}
+	if paymentEntity.Detail != nil {
+		receipt.Detail = *paymentEntity.Detail
+	}
+	if receipt.IssuedAt.IsZero() {
+		receipt.IssuedAt = time.Now()
+	}
+	if paymentEntity.Badge != nil && paymentEntity.Discount > 0 {
+		badge := string(*paymentEntity.Badge)
+		receipt.DiscountLabel = fmt.Sprintf("%s%s badge discount (%d%%)",
+			strings.ToUpper(badge[:1]), badge[1:], enum.GetBadgeDiscount(*paymentEntity.Badge))
+	}
+
+	content, err := s.pdf.GenerateReceipt(receipt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err = s.fileUtil.Upload(ctx, bytes.NewReader(content),
+		"payments/receipt/"+paymentEntity.ID.String(), ""); err != nil {
+		return nil, nil, fmt.Errorf("failed to upload receipt: %w", err)
+	}
+
+	if err = s.repo.MarkReceiptGenerated(ctx, paymentEntity.ID); err != nil {
+		return nil, nil, err
+	}
+
+	return content, student, nil
+}
+
+func (s *paymentService) sendReceipt(ctx context.Context, paymentEntity *entity.Payment) {
+	content, student, err := s.generateReceipt(ctx, paymentEntity)
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error":      err,
+			"payment.id": paymentEntity.ID,
+		}, "Failed to generate receipt")
+		return
+	}
+
+	invoiceNumber := dto.FormatInvoiceNumber(*paymentEntity.InvoiceNumber, paymentEntity.CreatedAt)
+	err = s.mailer.Send(
+		student.Email,
+		"[ElevateU] Payment Receipt "+invoiceNumber,
+		"payment_receipt.html",
+		map[string]interface{}{
+			"name":           student.Name,
+			"invoice_number": invoiceNumber,
+			"item":           paymentEntity.Title,
+			"amount":         pdf.FormatRupiah(paymentEntity.Amount),
+		},
+		mail.Attachment{
+			Filename:    fmt.Sprintf("receipt-%06d.pdf", *paymentEntity.InvoiceNumber),
+			ContentType: "application/pdf",
+			Content:     content,
+		})
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error":      err,
+			"payment.id": paymentEntity.ID,
+		}, "Failed to send receipt email")
+		return
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"payment.id":     paymentEntity.ID,
+		"invoice.number": invoiceNumber,
+	}, "Payment receipt sent")
+}
+
+// sendSessionUnavailable tells the student that the slot they paid for was lost and the payment will be refunded
+func (s *paymentService) sendSessionUnavailable(ctx context.Context, paymentEntity *entity.Payment) {
+	student, err := s.userSvc.GetUserByID(ctx, paymentEntity.UserID, false)
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error":      err,
+			"payment.id": paymentEntity.ID,
+		}, "Failed to get student for session unavailable email")
+		return
+	}
+
+	detail := ""
+	if paymentEntity.Detail != nil {
+		detail = *paymentEntity.Detail
+	}
+
+	err = s.mailer.Send(
+		student.Email,
+		"[ElevateU] Mentoring Session Unavailable",
+		"mentoring_session_unavailable.html",
+		map[string]interface{}{
+			"name":   student.Name,
+			"item":   paymentEntity.Title,
+			"detail": detail,
+			"amount": pdf.FormatRupiah(paymentEntity.Amount),
+		})
+	if err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error":      err,
+			"payment.id": paymentEntity.ID,
+		}, "Failed to send session unavailable email")
+		return
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"payment.id": paymentEntity.ID,
+	}, "Session unavailable email sent")
+}
+
 func (s *paymentService) ProcessNotification(ctx context.Context, notificationPayload map[string]any) error {
 	status, method, err := s.paymentGateway.ProcessNotification(notificationPayload)
 	if err != nil {
@@ -297,25 +665,19 @@ func (s *paymentService) PaySkillBoost(ctx context.Context, studentID uuid.UUID)
 
 	badge := user.Student.Badge
 
+	// 10% discount for Bronze, 20% for Silver, 50% for Gold
 	price := 120000
-	switch badge {
-	case enum.BadgeBronze:
-		// 10% discount
-		price = price - (price * 10 / 100)
-	case enum.BadgeSilver:
-		// 20% discount
-		price = price - (price * 20 / 100)
-	case enum.BadgeGold:
-		// 50% discount
-		price = price - (price * 50 / 100)
-	}
+	discount := price * enum.GetBadgeDiscount(badge) / 100
+	price -= discount
 
 	detail := "Skill Boost Subscription for 30 days"
 	return s.createPayment(ctx, dto.CreatePaymentRequest{
-		UserID: studentID,
-		Amount: price,
-		Title:  "Skill Boost Subscription",
-		Detail: &detail,
+		UserID:   studentID,
+		Amount:   price,
+		Discount: discount,
+		Badge:    &badge,
+		Title:    "Skill Boost Subscription",
+		Detail:   &detail,
 		Payload: entity.PaymentPayload{
 			Type:      enum.PaymentTypeBoost,
 			StudentID: studentID,
@@ -331,25 +693,19 @@ func (s *paymentService) PaySkillChallenge(ctx context.Context, studentID uuid.U
 
 	badge := user.Student.Badge
 
+	// 10% discount for Bronze, 20% for Silver, 50% for Gold
 	price := 120000
-	switch badge {
-	case enum.BadgeBronze:
-		// 10% discount
-		price = price - (price * 10 / 100)
-	case enum.BadgeSilver:
-		// 20% discount
-		price = price - (price * 20 / 100)
-	case enum.BadgeGold:
-		// 50% discount
-		price = price - (price * 50 / 100)
-	}
+	discount := price * enum.GetBadgeDiscount(badge) / 100
+	price -= discount
 
 	detail := "Skill Challenge Subscription for 30 days"
 	return s.createPayment(ctx, dto.CreatePaymentRequest{
-		UserID: studentID,
-		Amount: price,
-		Title:  "Skill Challenge Subscription",
-		Detail: &detail,
+		UserID:   studentID,
+		Amount:   price,
+		Discount: discount,
+		Badge:    &badge,
+		Title:    "Skill Challenge Subscription",
+		Detail:   &detail,
 		Payload: entity.PaymentPayload{
 			Type:      enum.PaymentTypeChallenge,
 			StudentID: studentID,
@@ -357,7 +713,10 @@ func (s *paymentService) PaySkillChallenge(ctx context.Context, studentID uuid.U
 	})
 }
 
-func (s *paymentService) PaySkillGuidance(ctx context.Context, studentID, mentorID uuid.UUID) (string, error) {
+// PaySkillGuidance holds the session slot at startsAt until the payment expires.
+// Without startsAt, the payment opens a 24-hour chat right away.
+func (s *paymentService) PaySkillGuidance(ctx context.Context, studentID, mentorID uuid.UUID,
+	startsAt *time.Time) (string, error) {
 	// check if mentor exists
 	mentor, err := s.userSvc.GetUserByID(ctx, mentorID, false)
 	if err != nil {
@@ -365,15 +724,116 @@ func (s *paymentService) PaySkillGuidance(ctx context.Context, studentID, mentor
 	}
 
 	detail := fmt.Sprintf("Skill Guidance with %s for 24 hours", mentor.Name)
+	payload := entity.PaymentPayload{
+		Type:      enum.PaymentTypeGuidance,
+		StudentID: studentID,
+		MentorID:  mentorID,
+	}
+
+	if startsAt != nil {
+		session, err := s.scheduleSvc.HoldSession(ctx, studentID, mentorID, *startsAt,
+			time.Now().Add(1*time.Hour))
+		if err != nil {
+			return "", err
+		}
+
+		payload.SessionID = session.ID
+		detail = fmt.Sprintf("Skill Guidance session with %s on %s", mentor.Name,
+			session.StartsAt.In(timeutil.Location).Format("2 Jan 2006 15:04 WIB"))
+	}
+
 	return s.createPayment(ctx, dto.CreatePaymentRequest{
-		UserID: studentID,
-		Amount: mentor.Mentor.Price,
-		Title:  "Skill Guidance Subscription",
-		Detail: &detail,
+		UserID:  studentID,
+		Amount:  mentor.Mentor.Price,
+		Title:   "Skill Guidance Subscription",
+		Detail:  &detail,
+		Payload: payload,
+	})
+}
+
+// ExtendSkillGuidance starts the payment of an extension link and returns the URL of its payment page.
+// The payment is kept per link, so submitting the link again leads to the same payment.
+func (s *paymentService) ExtendSkillGuidance(ctx context.Context, token string) (string, error) {
+	extension, err := s.mentoringSvc.ResolveExtensionToken(ctx, token)
+	if err != nil {
+		return "", err
+	}
+
+	var paymentToken string
+	err = s.cache.Get(ctx, "payment-extension:"+token, &paymentToken)
+	if err == nil {
+		return s.paymentGateway.GetRedirectURL(paymentToken), nil
+	}
+	if !strings.HasPrefix(err.Error(), "not found") {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"chat.id": extension.ChatID,
+		}, "Failed to get extension payment")
+		return "", errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	paymentToken, err = s.PaySkillGuidance(ctx, extension.StudentID, extension.MentorID, nil)
+	if err != nil {
+		return "", err
+	}
+
+	// as long as the payment payload is kept
+	if err = s.cache.Set(ctx, "payment-extension:"+token, paymentToken, 1*time.Hour); err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error":   err,
+			"chat.id": extension.ChatID,
+		}, "Failed to store extension payment")
+	}
+
+	return s.paymentGateway.GetRedirectURL(paymentToken), nil
+}
+
+func (s *paymentService) PayCourse(ctx context.Context, studentID, courseID uuid.UUID) (string, error) {
+	course, err := s.courseSvc.GetCourseByID(ctx, courseID)
+	if err != nil {
+		return "", err
+	}
+
+	if course.Price == nil {
+		return "", errorpkg.ErrCourseNotForSale()
+	}
+
+	isPurchased, err := s.repo.IsCoursePurchased(ctx, courseID, studentID)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":      err,
+			"course.id":  courseID,
+			"student.id": studentID,
+		}, "Failed to check course purchase")
+		return "", errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+	if isPurchased {
+		return "", errorpkg.ErrCourseAlreadyPurchased()
+	}
+
+	user, err := s.userSvc.GetUserByID(ctx, studentID, false)
+	if err != nil {
+		return "", err
+	}
+
+	badge := user.Student.Badge
+
+	price := *course.Price
+	discount := price * enum.GetBadgeDiscount(badge) / 100
+	price -= discount
+
+	detail := fmt.Sprintf("Lifetime access to %s", course.Title)
+	return s.createPayment(ctx, dto.CreatePaymentRequest{
+		UserID:   studentID,
+		Amount:   price,
+		Discount: discount,
+		Badge:    &badge,
+		Title:    "Course Purchase",
+		Detail:   &detail,
 		Payload: entity.PaymentPayload{
-			Type:      enum.PaymentTypeGuidance,
+			Type:      enum.PaymentTypeCourse,
 			StudentID: studentID,
-			MentorID:  mentorID,
+			CourseID:  courseID,
 		},
 	})
 }
@@ -476,7 +936,7 @@ func (s *paymentService) triggerSkillGuidance(ctx context.Context, tx database.I
 		return errorpkg.ErrInternalServer().WithTraceID(traceID)
 	}
 
-	if _, err := s.mentoringSvc.CreateChat(ctx, payload.MentorID, payload.StudentID, false); err != nil {
+	if err := s.openSkillGuidanceChat(ctx, tx, payment, payload); err != nil {
 		return err
 	}
 
@@ -487,3 +947,97 @@ func (s *paymentService) triggerSkillGuidance(ctx context.Context, tx database.I
 
 	return nil
 }
+
+// openSkillGuidanceChat books the held session of the payment and opens its chat until a day after it ends.
+// If the slot was taken after the hold lapsed, the student falls back to the regular 24-hour chat
+// and the payment is marked for refund, so support can refund or rebook it.
+// Both run in the transaction of the payment, so a failed notification leaves neither behind and can be retried.
+func (s *paymentService) openSkillGuidanceChat(ctx context.Context, tx database.ITransaction,
+	payment *entity.Payment, payload entity.PaymentPayload) error {
+	if payload.SessionID == uuid.Nil {
+		_, err := s.mentoringSvc.CreateChat(ctx, tx, payload.MentorID, payload.StudentID, false)
+		return err
+	}
+
+	session, err := s.scheduleSvc.ConfirmSession(ctx, tx, payload.SessionID)
+	if err != nil {
+		var respErr *errorpkg.ResponseError
+		if errors.As(err, &respErr) && respErr.Type == errorpkg.ErrSessionSlotUnavailable().Type {
+			log.Warn(ctx, map[string]interface{}{
+				"session.id": payload.SessionID,
+				"student.id": payload.StudentID,
+			}, "Session slot taken before payment completed, opening regular chat")
+
+			reason := "The mentoring session slot was booked by someone else before the payment completed"
+			if err = s.repo.MarkForRefund(ctx, tx, payment.ID, reason); err != nil {
+				traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+					"error":      err,
+					"payment.id": payment.ID,
+				}, "Failed to mark payment for refund")
+				return errorpkg.ErrInternalServer().WithTraceID(traceID)
+			}
+			payment.RefundReason = &reason
+
+			_, err = s.mentoringSvc.CreateChat(ctx, tx, payload.MentorID, payload.StudentID, false)
+		}
+		return err
+	}
+
+	_, err = s.mentoringSvc.CreateSessionChat(ctx, tx, payload.MentorID, payload.StudentID,
+		session.EndsAt.Add(24*time.Hour))
+	return err
+}
+
+// releaseHeldSession frees the slot held by a failed Skill Guidance payment
+func (s *paymentService) releaseHeldSession(ctx context.Context, paymentID uuid.UUID) {
+	var payloadJSON string
+	if err := s.cache.Get(ctx, "payment:"+paymentID.String(), &payloadJSON); err != nil {
+		if !strings.HasPrefix(err.Error(), "not found") {
+			log.Error(ctx, map[string]interface{}{
+				"error":      err,
+				"payment.id": paymentID,
+			}, "Failed to get payment payload")
+		}
+		return
+	}
+
+	var payload entity.PaymentPayload
+	if err := sonic.Unmarshal([]byte(payloadJSON), &payload); err != nil {
+		log.Error(ctx, map[string]interface{}{
+			"error":      err,
+			"payment.id": paymentID,
+		}, "Failed to unmarshal payment payload")
+		return
+	}
+
+	if payload.SessionID == uuid.Nil {
+		return
+	}
+
+	if err := s.scheduleSvc.ReleaseSession(ctx, payload.SessionID); err != nil {
+		log.Warn(ctx, map[string]interface{}{
+			"error":      err,
+			"payment.id": paymentID,
+			"session.id": payload.SessionID,
+		}, "Failed to release held session")
+	}
+}
+
+func (s *paymentService) triggerCoursePurchase(ctx context.Context, tx database.ITransaction,
+	payload entity.PaymentPayload, payment *entity.Payment) error {
+	if err := s.repo.AddCoursePurchase(ctx, tx, payload.CourseID, payload.StudentID); err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":          err,
+			"payment.id":     payment.ID,
+			"payment.status": payment.Status,
+		}, "Failed to add course purchase")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	log.Info(ctx, map[string]interface{}{
+		"student.id": payload.StudentID,
+		"course.id":  payload.CourseID,
+	}, "Course purchased")
+
+	return nil
+}
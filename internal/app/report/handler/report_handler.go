@@ -0,0 +1,185 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/nathakusuma/elevateu-backend/domain/contract"
+	"github.com/nathakusuma/elevateu-backend/domain/dto"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
+	"github.com/nathakusuma/elevateu-backend/domain/errorpkg"
+	"github.com/nathakusuma/elevateu-backend/internal/middleware"
+	"github.com/nathakusuma/elevateu-backend/pkg/log"
+	"github.com/nathakusuma/elevateu-backend/pkg/validator"
+)
+
+type reportHandler struct {
+	svc contract.IReportService
+	val validator.IValidator
+}
+
+func InitReportHandler(
+	router fiber.Router,
+	midw *middleware.Middleware,
+	validator validator.IValidator,
+	svc contract.IReportService,
+) {
+	handler := reportHandler{
+		svc: svc,
+		val: validator,
+	}
+
+	reportGroup := router.Group("/reports")
+	reportGroup.Use(midw.RequireAuthenticated, midw.RequireOneOfRoles(enum.UserRoleAdmin))
+
+	reportGroup.Get("/revenue", handler.getRevenueByPeriod)
+	reportGroup.Get("/revenue/by-type", handler.getRevenueByType)
+	reportGroup.Get("/revenue/by-method", handler.getRevenueByMethod)
+	reportGroup.Get("/conversion", handler.getConversion)
+	reportGroup.Get("/mentor-payouts", handler.getMentorPayouts)
+}
+
+func (h *reportHandler) parseRequest(ctx *fiber.Ctx) (dto.ReportRequest, error) {
+	var req dto.ReportRequest
+	if err := ctx.QueryParser(&req); err != nil {
+		return req, errorpkg.ErrFailParseRequest()
+	}
+
+	if err := h.val.ValidateStruct(req); err != nil {
+		return req, err
+	}
+
+	return req, nil
+}
+
+type csvRecorder interface {
+	CSVRecord() []string
+}
+
+func sendCSV[T csvRecorder](ctx *fiber.Ctx, name string, req dto.ReportRequest, header []string, rows []T) error {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+
+	records := make([][]string, 0, len(rows)+1)
+	records = append(records, header)
+	for _, row := range rows {
+		records = append(records, row.CSVRecord())
+	}
+
+	if err := writer.WriteAll(records); err != nil {
+		traceID := log.ErrorWithTraceID(ctx.Context(), map[string]interface{}{
+			"error":  err,
+			"report": name,
+		}, "Failed to write CSV report")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	ctx.Set(fiber.HeaderContentType, "text/csv")
+	ctx.Attachment(fmt.Sprintf("%s_%s_%s.csv", name, req.StartDate, req.EndDate))
+	return ctx.Send(buf.Bytes())
+}
+
+func (h *reportHandler) getRevenueByPeriod(ctx *fiber.Ctx) error {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		return err
+	}
+
+	reports, err := h.svc.GetRevenueByPeriod(ctx.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	if req.Format == "csv" {
+		return sendCSV(ctx, "revenue", req, []string{"period", "revenue", "discount", "transactions"}, reports)
+	}
+
+	return ctx.JSON(map[string]any{
+		"revenue": reports,
+	})
+}
+
+func (h *reportHandler) getRevenueByType(ctx *fiber.Ctx) error {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		return err
+	}
+
+	reports, err := h.svc.GetRevenueByType(ctx.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	if req.Format == "csv" {
+		return sendCSV(ctx, "revenue_by_type", req, []string{"type", "revenue", "transactions"}, reports)
+	}
+
+	return ctx.JSON(map[string]any{
+		"revenue": reports,
+	})
+}
+
+func (h *reportHandler) getRevenueByMethod(ctx *fiber.Ctx) error {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		return err
+	}
+
+	reports, err := h.svc.GetRevenueByMethod(ctx.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	if req.Format == "csv" {
+		return sendCSV(ctx, "revenue_by_method", req, []string{"method", "revenue", "transactions"}, reports)
+	}
+
+	return ctx.JSON(map[string]any{
+		"revenue": reports,
+	})
+}
+
+func (h *reportHandler) getConversion(ctx *fiber.Ctx) error {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		return err
+	}
+
+	report, err := h.svc.GetConversion(ctx.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	if req.Format == "csv" {
+		return sendCSV(ctx, "conversion", req,
+			[]string{"total", "succeeded", "failed", "expired", "pending", "conversion_rate"},
+			[]*dto.ConversionReport{report})
+	}
+
+	return ctx.JSON(map[string]any{
+		"conversion": report,
+	})
+}
+
+func (h *reportHandler) getMentorPayouts(ctx *fiber.Ctx) error {
+	req, err := h.parseRequest(ctx)
+	if err != nil {
+		return err
+	}
+
+	reports, err := h.svc.GetMentorPayouts(ctx.Context(), req)
+	if err != nil {
+		return err
+	}
+
+	if req.Format == "csv" {
+		return sendCSV(ctx, "mentor_payouts", req, []string{"mentor_id", "name", "email", "earned", "owed"}, reports)
+	}
+
+	return ctx.JSON(map[string]any{
+		"mentor_payouts": reports,
+	})
+}
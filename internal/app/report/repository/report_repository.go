@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/nathakusuma/elevateu-backend/domain/contract"
+	"github.com/nathakusuma/elevateu-backend/domain/dto"
+	"github.com/nathakusuma/elevateu-backend/pkg/timeutil"
+)
+
+type reportRepository struct {
+	db *sqlx.DB
+}
+
+func NewReportRepository(db *sqlx.DB) contract.IReportRepository {
+	return &reportRepository{db: db}
+}
+
+func (r *reportRepository) GetRevenueByPeriod(ctx context.Context,
+	filter dto.ReportFilter) ([]*dto.RevenuePeriodReport, error) {
+	// revenue counts on the day it was paid, and periods start at midnight in the platform timezone, not in UTC
+	var reports []*dto.RevenuePeriodReport
+	if err := r.db.SelectContext(ctx, &reports, `
+		SELECT
+			date_trunc($1, paid_at AT TIME ZONE $4) AT TIME ZONE $4 AS period,
+			COALESCE(SUM(amount), 0) AS revenue,
+			COALESCE(SUM(discount), 0) AS discount,
+			COUNT(*) AS transactions
+		FROM payments
+		WHERE status = 'success'
+			AND paid_at >= $2
+			AND paid_at < $3
+		GROUP BY period
+		ORDER BY period
+	`, filter.Granularity, filter.StartAt, filter.EndAt, timeutil.LocationName); err != nil {
+		return nil, fmt.Errorf("failed to get revenue by period: %w", err)
+	}
+
+	return reports, nil
+}
+
+func (r *reportRepository) GetRevenueByType(ctx context.Context,
+	filter dto.ReportFilter) ([]*dto.RevenueByTypeReport, error) {
+	var reports []*dto.RevenueByTypeReport
+	if err := r.db.SelectContext(ctx, &reports, `
+		SELECT
+			type,
+			COALESCE(SUM(amount), 0) AS revenue,
+			COUNT(*) AS transactions
+		FROM payments
+		WHERE status = 'success'
+			AND paid_at >= $1
+			AND paid_at < $2
+		GROUP BY type
+		ORDER BY revenue DESC
+	`, filter.StartAt, filter.EndAt); err != nil {
+		return nil, fmt.Errorf("failed to get revenue by type: %w", err)
+	}
+
+	return reports, nil
+}
+
+func (r *reportRepository) GetRevenueByMethod(ctx context.Context,
+	filter dto.ReportFilter) ([]*dto.RevenueByMethodReport, error) {
+	var reports []*dto.RevenueByMethodReport
+	if err := r.db.SelectContext(ctx, &reports, `
+		SELECT
+			method,
+			COALESCE(SUM(amount), 0) AS revenue,
+			COUNT(*) AS transactions
+		FROM payments
+		WHERE status = 'success'
+			AND paid_at >= $1
+			AND paid_at < $2
+		GROUP BY method
+		ORDER BY revenue DESC
+	`, filter.StartAt, filter.EndAt); err != nil {
+		return nil, fmt.Errorf("failed to get revenue by method: %w", err)
+	}
+
+	return reports, nil
+}
+
+func (r *reportRepository) GetConversion(ctx context.Context, filter dto.ReportFilter) (*dto.ConversionReport, error) {
+	var report dto.ConversionReport
+	if err := r.db.GetContext(ctx, &report, `
+		SELECT
+			COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE status = 'success') AS succeeded,
+			COUNT(*) FILTER (WHERE status = 'failure') AS failed,
+			COUNT(*) FILTER (WHERE status IN ('pending', 'challenge') AND expired_at < NOW()) AS expired,
+			COUNT(*) FILTER (WHERE status IN ('pending', 'challenge') AND expired_at >= NOW()) AS pending
+		FROM payments
+		WHERE created_at >= $1
+			AND created_at < $2
+	`, filter.StartAt, filter.EndAt); err != nil {
+		return nil, fmt.Errorf("failed to get conversion: %w", err)
+	}
+
+	return &report, nil
+}
+
+func (r *reportRepository) GetMentorPayouts(ctx context.Context,
+	filter dto.ReportFilter) ([]*dto.MentorPayoutReport, error) {
+	var reports []*dto.MentorPayoutReport
+	if err := r.db.SelectContext(ctx, &reports, `
+		SELECT
+			m.user_id AS mentor_id,
+			u.name,
+			u.email,
+			COALESCE(h.earned, 0) AS earned,
+			m.balance AS owed
+		FROM mentors m
+		JOIN users u ON u.id = m.user_id
+		LEFT JOIN (
+			SELECT mentor_id, SUM(amount) AS earned
+			FROM mentor_transaction_histories
+			WHERE created_at >= $1
+				AND created_at < $2
+			GROUP BY mentor_id
+		) h ON h.mentor_id = m.user_id
+		WHERE m.balance > 0 OR h.earned > 0
+		ORDER BY owed DESC, earned DESC
+	`, filter.StartAt, filter.EndAt); err != nil {
+		return nil, fmt.Errorf("failed to get mentor payouts: %w", err)
+	}
+
+	return reports, nil
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/nathakusuma/elevateu-backend/domain/contract"
+	"github.com/nathakusuma/elevateu-backend/domain/dto"
+	"github.com/nathakusuma/elevateu-backend/domain/errorpkg"
+	"github.com/nathakusuma/elevateu-backend/pkg/log"
+	"github.com/nathakusuma/elevateu-backend/pkg/timeutil"
+)
+
+const maxReportRange = 366 * 24 * time.Hour
+
+type reportService struct {
+	repo contract.IReportRepository
+}
+
+func NewReportService(repo contract.IReportRepository) contract.IReportService {
+	return &reportService{
+		repo: repo,
+	}
+}
+
+func (s *reportService) parseFilter(req dto.ReportRequest) (dto.ReportFilter, error) {
+	// dates are calendar days in the platform timezone
+	startAt, err := time.ParseInLocation(time.DateOnly, req.StartDate, timeutil.Location)
+	if err != nil {
+		return dto.ReportFilter{}, errorpkg.ErrValidation().WithDetail("Invalid start date")
+	}
+
+	endDate, err := time.ParseInLocation(time.DateOnly, req.EndDate, timeutil.Location)
+	if err != nil {
+		return dto.ReportFilter{}, errorpkg.ErrValidation().WithDetail("Invalid end date")
+	}
+
+	if endDate.Before(startAt) {
+		return dto.ReportFilter{}, errorpkg.ErrValidation().WithDetail("End date must not be before start date")
+	}
+
+	// end date is inclusive
+	endAt := endDate.AddDate(0, 0, 1)
+	if endAt.Sub(startAt) > maxReportRange {
+		return dto.ReportFilter{}, errorpkg.ErrValidation().WithDetail("Date range must not exceed one year")
+	}
+
+	granularity := req.Granularity
+	if granularity == "" {
+		granularity = "day"
+	}
+
+	return dto.ReportFilter{
+		StartAt:     startAt,
+		EndAt:       endAt,
+		Granularity: granularity,
+	}, nil
+}
+
+func (s *reportService) GetRevenueByPeriod(ctx context.Context,
+	req dto.ReportRequest) ([]*dto.RevenuePeriodReport, error) {
+	filter, err := s.parseFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := s.repo.GetRevenueByPeriod(ctx, filter)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"request": req,
+		}, "Failed to get revenue by period")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	// the driver returns UTC, which would shift the period dates back by a day
+	for _, report := range reports {
+		report.Period = report.Period.In(timeutil.Location)
+	}
+
+	return reports, nil
+}
+
+func (s *reportService) GetRevenueByType(ctx context.Context,
+	req dto.ReportRequest) ([]*dto.RevenueByTypeReport, error) {
+	filter, err := s.parseFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := s.repo.GetRevenueByType(ctx, filter)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"request": req,
+		}, "Failed to get revenue by type")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	return reports, nil
+}
+
+func (s *reportService) GetRevenueByMethod(ctx context.Context,
+	req dto.ReportRequest) ([]*dto.RevenueByMethodReport, error) {
+	filter, err := s.parseFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := s.repo.GetRevenueByMethod(ctx, filter)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"request": req,
+		}, "Failed to get revenue by method")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	return reports, nil
+}
+
+func (s *reportService) GetConversion(ctx context.Context, req dto.ReportRequest) (*dto.ConversionReport, error) {
+	filter, err := s.parseFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := s.repo.GetConversion(ctx, filter)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"request": req,
+		}, "Failed to get conversion")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	if report.Total > 0 {
+		report.ConversionRate = float64(report.Succeeded) / float64(report.Total)
+	}
+
+	return report, nil
+}
+
+func (s *reportService) GetMentorPayouts(ctx context.Context,
+	req dto.ReportRequest) ([]*dto.MentorPayoutReport, error) {
+	filter, err := s.parseFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	reports, err := s.repo.GetMentorPayouts(ctx, filter)
+	if err != nil {
+		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
+			"error":   err,
+			"request": req,
+		}, "Failed to get mentor payouts")
+		return nil, errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	return reports, nil
+}
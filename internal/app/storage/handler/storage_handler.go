@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/nathakusuma/elevateu-backend/domain/errorpkg"
+	"github.com/nathakusuma/elevateu-backend/pkg/fileutil"
+	"github.com/nathakusuma/elevateu-backend/pkg/log"
+)
+
+// maxUploadSize is the size of the largest file the app accepts, a course video
+var maxUploadSize = 2 * fileutil.GigaByte
+
+var errUploadTooLarge = errors.New("upload is too large")
+
+type storageHandler struct {
+	storage fileutil.ILocalStorage
+}
+
+// InitStorageHandler serves the signed URLs of the local storage. The signature is the only authorization,
+// like the signed URLs of Google Cloud Storage.
+func InitStorageHandler(
+	router fiber.Router,
+	storage fileutil.ILocalStorage,
+) {
+	handler := storageHandler{
+		storage: storage,
+	}
+
+	storageGroup := router.Group("/storage")
+
+	storageGroup.Get("/*", handler.getFile)
+	storageGroup.Put("/*", handler.putFile)
+}
+
+func (h *storageHandler) getFile(ctx *fiber.Ctx) error {
+	path, err := url.PathUnescape(ctx.Params("*"))
+	if err != nil {
+		return errorpkg.ErrNotFound()
+	}
+
+	if err = h.verifySignature(ctx, path, ""); err != nil {
+		return err
+	}
+
+	file, attrs, err := h.storage.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return errorpkg.ErrNotFound()
+		}
+		traceID := log.ErrorWithTraceID(ctx.Context(), map[string]interface{}{
+			"error": err,
+			"path":  path,
+		}, "Failed to open file")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	ctx.Set(fiber.HeaderContentType, attrs.ContentType)
+	// the stream is closed once sent
+	return ctx.SendStream(file, int(attrs.Size))
+}
+
+func (h *storageHandler) putFile(ctx *fiber.Ctx) error {
+	path, err := url.PathUnescape(ctx.Params("*"))
+	if err != nil {
+		return errorpkg.ErrNotFound()
+	}
+
+	contentType := ctx.Get(fiber.HeaderContentType)
+	if err = h.verifySignature(ctx, path, contentType); err != nil {
+		return err
+	}
+
+	if ctx.Request().Header.ContentLength() > int(maxUploadSize) {
+		return errorpkg.ErrFileTooLarge()
+	}
+
+	// With StreamRequestBody, the body is streamed instead of read into memory.
+	// The stream has to be taken before calling Body, which would drain it.
+	body := ctx.Context().RequestBodyStream()
+	if body == nil {
+		body = bytes.NewReader(ctx.Body())
+	}
+
+	// the length of a chunked body is unknown until it is read
+	body = &limitedReader{reader: body, remaining: maxUploadSize}
+
+	// the content type is covered by the signature, as with Google Cloud Storage
+	if _, err = h.storage.Upload(ctx.Context(), body, path, contentType); err != nil {
+		if errors.Is(err, errUploadTooLarge) {
+			return errorpkg.ErrFileTooLarge()
+		}
+
+		traceID := log.ErrorWithTraceID(ctx.Context(), map[string]interface{}{
+			"error": err,
+			"path":  path,
+		}, "Failed to store file")
+		return errorpkg.ErrInternalServer().WithTraceID(traceID)
+	}
+
+	return ctx.SendStatus(fiber.StatusOK)
+}
+
+// verifySignature checks the signature against the method, the path and, for uploads,
+// the content type the URL was signed for
+func (h *storageHandler) verifySignature(ctx *fiber.Ctx, path, contentType string) error {
+	expires, err := strconv.ParseInt(ctx.Query("expires"), 10, 64)
+	if err != nil {
+		return errorpkg.ErrInvalidSignedURL()
+	}
+
+	if !h.storage.VerifySignature(ctx.Method(), path, contentType, expires, ctx.Query("signature")) {
+		return errorpkg.ErrInvalidSignedURL()
+	}
+
+	return nil
+}
+
+// limitedReader fails with errUploadTooLarge once more than the remaining bytes are read,
+// so the upload is discarded instead of being stored truncated like with io.LimitReader
+type limitedReader struct {
+	reader    io.Reader
+	remaining int64
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > r.remaining+1 {
+		p = p[:r.remaining+1]
+	}
+
+	n, err := r.reader.Read(p)
+	r.remaining -= int64(n)
+	if r.remaining < 0 {
+		return n, errUploadTooLarge
+	}
+
+	return n, err
+}
@@ -150,7 +150,8 @@ func (r *userRepository) getUserByCondition(ctx context.Context, whereClause str
 		m.rating_count,
 		m.rating_total,
 		m.price,
-		m.balance
+		m.balance,
+		m.accepts_trial
 	FROM users u
 	LEFT JOIN students s ON u.id = s.user_id AND u.role = 'student'
 	LEFT JOIN mentors m ON u.id = m.user_id AND u.role = 'mentor'
@@ -194,6 +195,7 @@ func (r *userRepository) getUserByCondition(ctx context.Context, whereClause str
 		RatingTotal              sql.NullFloat64 `db:"rating_total"`
 		Price                    sql.NullInt64   `db:"price"`
 		Balance                  sql.NullInt64   `db:"balance"`
+		AcceptsTrial             sql.NullBool    `db:"accepts_trial"`
 	}
 
 	var userJoin UserJoin
@@ -240,6 +242,7 @@ func (r *userRepository) getUserByCondition(ctx context.Context, whereClause str
 			RatingTotal:    userJoin.RatingTotal.Float64,
 			Price:          int(userJoin.Price.Int64),
 			Balance:        int(userJoin.Balance.Int64),
+			AcceptsTrial:   userJoin.AcceptsTrial.Bool,
 		}
 	}
 
@@ -473,7 +476,8 @@ func (r *userRepository) GetMentors(ctx context.Context,
 			m.rating_count AS "mentor.rating_count",
 			m.rating_total AS "mentor.rating_total",
 			m.price AS "mentor.price",
-			m.balance AS "mentor.balance"
+			m.balance AS "mentor.balance",
+			m.accepts_trial AS "mentor.accepts_trial"
 		FROM users u
 		JOIN mentors m ON u.id = m.user_id
 		WHERE u.role = 'mentor'
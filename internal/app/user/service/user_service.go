@@ -219,6 +219,7 @@ func (s *userService) UpdateUser(ctx context.Context, id uuid.UUID, req dto.Upda
 			Company:        req.Mentor.Company,
 			Bio:            req.Mentor.Bio,
 			Gender:         req.Mentor.Gender,
+			AcceptsTrial:   req.Mentor.AcceptsTrial,
 		}
 	}
 
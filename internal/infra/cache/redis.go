@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/nathakusuma/elevateu-backend/pkg/log"
@@ -12,8 +13,12 @@ import (
 
 type ICache interface {
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
 	Get(ctx context.Context, key string, val interface{}) error
 	Del(ctx context.Context, key string) error
+	IncrBy(ctx context.Context, key string, value int64, expiration time.Duration) (int64, error)
+	Publish(ctx context.Context, channel string, message interface{}) error
+	Subscribe(ctx context.Context, channel string) (<-chan string, func() error, error)
 	Close() error
 }
 
@@ -48,6 +53,12 @@ func (r *redisImpl) Set(ctx context.Context, key string, value interface{}, expi
 	return r.client.Set(ctx, key, value, expiration).Err()
 }
 
+// SetNX sets the key only if it does not exist yet and reports whether it was set
+func (r *redisImpl) SetNX(ctx context.Context, key string, value interface{},
+	expiration time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, key, value, expiration).Result()
+}
+
 func (r *redisImpl) Get(ctx context.Context, key string, val interface{}) error {
 	err := r.client.Get(ctx, key).Scan(val)
 	if err != nil {
@@ -64,6 +75,62 @@ func (r *redisImpl) Del(ctx context.Context, key string) error {
 	return r.client.Del(ctx, key).Err()
 }
 
+// IncrBy adds value to the integer stored at key and resets the key's expiration
+func (r *redisImpl) IncrBy(ctx context.Context, key string, value int64,
+	expiration time.Duration) (int64, error) {
+	var incr *redis.IntCmd
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		incr = pipe.IncrBy(ctx, key, value)
+		pipe.Expire(ctx, key, expiration)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return incr.Val(), nil
+}
+
+func (r *redisImpl) Publish(ctx context.Context, channel string, message interface{}) error {
+	return r.client.Publish(ctx, channel, message).Err()
+}
+
+// Subscribe listens to a pub/sub channel. The returned channel is closed once the unsubscribe function is called.
+func (r *redisImpl) Subscribe(ctx context.Context, channel string) (<-chan string, func() error, error) {
+	pubsub := r.client.Subscribe(ctx, channel)
+
+	// Wait for the subscription confirmation so messages published afterward are not missed
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, nil, err
+	}
+
+	messages := make(chan string)
+	done := make(chan struct{})
+	go func() {
+		defer close(messages)
+		for msg := range pubsub.Channel() {
+			select {
+			case messages <- msg.Payload:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	unsubscribe := func() error {
+		var err error
+		once.Do(func() {
+			close(done)
+			err = pubsub.Close()
+		})
+		return err
+	}
+
+	return messages, unsubscribe, nil
+}
+
 func (r *redisImpl) Close() error {
 	return r.client.Close()
 }
@@ -42,8 +42,14 @@ type Env struct {
 	GoogleApplicationCredentials string        `mapstructure:"GOOGLE_APPLICATION_CREDENTIALS"`
 	GCPProjectID                 string        `mapstructure:"GCP_PROJECT_ID"`
 	GCPStorageBucketName         string        `mapstructure:"GCP_STORAGE_BUCKET_NAME"`
+	StorageBackend               string        `mapstructure:"STORAGE_BACKEND"`
+	LocalStorageDir              string        `mapstructure:"LOCAL_STORAGE_DIR"`
+	LocalStorageSigningKey       string        `mapstructure:"LOCAL_STORAGE_SIGNING_KEY"`
 	MidtransServerKey            string        `mapstructure:"MIDTRANS_SERVER_KEY"`
 	MidtransEnvironment          midtrans.EnvironmentType
+	MentoringTrialDuration       time.Duration // MENTORING_TRIAL_DURATION
+	MentoringTrialsPerStudent    int           `mapstructure:"MENTORING_TRIALS_PER_STUDENT"`
+	MentoringTrialMessageLimit   int           `mapstructure:"MENTORING_TRIAL_MESSAGE_LIMIT"`
 }
 
 var (
@@ -60,6 +66,7 @@ func NewEnv() *Env {
 
 		// Enable environment variables first
 		viperInstance.AutomaticEnv()
+		setDefaults(viperInstance)
 
 		// Check if APP_ENV is set in environment variables
 		if appEnv := os.Getenv("APP_ENV"); appEnv != "" {
@@ -86,6 +93,15 @@ func NewEnv() *Env {
 	return env
 }
 
+// setDefaults sets the values of the optional variables
+func setDefaults(viperInstance *viper.Viper) {
+	viperInstance.SetDefault("STORAGE_BACKEND", "gcs")
+	viperInstance.SetDefault("LOCAL_STORAGE_DIR", "storage/files")
+	viperInstance.SetDefault("MENTORING_TRIAL_DURATION", "15m")
+	viperInstance.SetDefault("MENTORING_TRIALS_PER_STUDENT", 1)
+	viperInstance.SetDefault("MENTORING_TRIAL_MESSAGE_LIMIT", 0)
+}
+
 // handleEnvVariables handles the logic when APP_ENV is set in environment variables
 func handleEnvVariables(appEnv string, viperInstance *viper.Viper, env *Env) {
 	log.Info().Msgf("[ENV] Using %s environment variables", appEnv)
@@ -145,5 +161,10 @@ func parseDurations(env *Env) error {
 		return fmt.Errorf("invalid JWT_REFRESH_EXPIRE_DURATION: %w", err)
 	}
 
+	env.MentoringTrialDuration, err = time.ParseDuration(viperInstance.GetString("MENTORING_TRIAL_DURATION"))
+	if err != nil {
+		return fmt.Errorf("invalid MENTORING_TRIAL_DURATION: %w", err)
+	}
+
 	return nil
 }
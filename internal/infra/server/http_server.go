@@ -25,6 +25,10 @@ import (
 	paymenthnd "github.com/nathakusuma/elevateu-backend/internal/app/payment/handler"
 	paymentrepo "github.com/nathakusuma/elevateu-backend/internal/app/payment/repository"
 	paymentsvc "github.com/nathakusuma/elevateu-backend/internal/app/payment/service"
+	reporthnd "github.com/nathakusuma/elevateu-backend/internal/app/report/handler"
+	reportrepo "github.com/nathakusuma/elevateu-backend/internal/app/report/repository"
+	reportsvc "github.com/nathakusuma/elevateu-backend/internal/app/report/service"
+	storagehnd "github.com/nathakusuma/elevateu-backend/internal/app/storage/handler"
 	userhnd "github.com/nathakusuma/elevateu-backend/internal/app/user/handler"
 	userrepo "github.com/nathakusuma/elevateu-backend/internal/app/user/repository"
 	usersvc "github.com/nathakusuma/elevateu-backend/internal/app/user/service"
@@ -39,6 +43,7 @@ import (
 	"github.com/nathakusuma/elevateu-backend/pkg/log"
 	"github.com/nathakusuma/elevateu-backend/pkg/mail"
 	"github.com/nathakusuma/elevateu-backend/pkg/payment"
+	"github.com/nathakusuma/elevateu-backend/pkg/pdf"
 	"github.com/nathakusuma/elevateu-backend/pkg/randgen"
 	"github.com/nathakusuma/elevateu-backend/pkg/uuidpkg"
 	"github.com/nathakusuma/elevateu-backend/pkg/validator"
@@ -61,6 +66,9 @@ func NewHTTPServer() HTTPServer {
 		JSONEncoder:  sonic.Marshal,
 		JSONDecoder:  sonic.Unmarshal,
 		ErrorHandler: errorHandler,
+		// uploads to the local storage go through this app, so they are streamed instead of being limited
+		// by the body limit. The other routes keep the limit with the BodyLimit middleware.
+		StreamRequestBody: env.GetEnv().StorageBackend == "local",
 	}
 
 	app := fiber.New(config)
@@ -94,20 +102,25 @@ func (s *httpServer) MountMiddlewares() {
 	s.app.Use(middleware.Compress())
 	s.app.Use(middleware.Cors())
 	s.app.Use(middleware.RecoverConfig())
+
+	if s.app.Config().StreamRequestBody {
+		s.app.Use(middleware.BodyLimit(s.app.Config().BodyLimit, "/api/v1/storage/"))
+	}
 }
 
 func (s *httpServer) MountRoutes(db *sqlx.DB, cache cache.ICache) {
-	gcpClient := gcp.NewStorageClient()
+	storageBackend, localStorage := newStorageBackend()
 	bcryptInstance := bcrypt.GetBcrypt()
-	fileUtil := fileutil.NewFileUtil(gcpClient)
+	fileUtil := fileutil.NewFileUtil(storageBackend)
 	jwtAccess := jwt.NewJwt(env.GetEnv().JwtAccessExpireDuration, env.GetEnv().JwtAccessSecretKey)
 	mailer := mail.NewMailDialer()
 	randomGenerator := randgen.GetRandGen()
 	txManager := database.NewTransactionManager(db)
 	uuidInstance := uuidpkg.GetUUID()
 	validatorInstance := validator.NewValidator()
-	middlewareInstance := middleware.NewMiddleware(jwtAccess)
+	middlewareInstance := middleware.NewMiddleware(jwtAccess, cache)
 	midtransPayment := payment.NewMidtrans()
+	pdfGenerator := pdf.GetPDF()
 
 	s.app.Get("/", func(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusOK).SendString("ElevateU Healthy")
@@ -123,29 +136,49 @@ func (s *httpServer) MountRoutes(db *sqlx.DB, cache cache.ICache) {
 	courseContentRepository := courserepo.NewCourseContentRepository(db)
 	courseProgressRepository := courserepo.NewCourseProgressRepository(db)
 	courseFeedbackRepository := courserepo.NewCourseFeedbackRepository(db)
+	courseArticleRepository := courserepo.NewCourseArticleRepository(db)
+	courseQuizRepository := courserepo.NewCourseQuizRepository(db)
+	courseNoteRepository := courserepo.NewCourseNoteRepository(db)
 	challengeGroupRepository := challengerepo.NewChallengeGroupRepository(db)
 	challengeRepository := challengerepo.NewChallengeRepository(db)
 	challengeSubmissionRepository := challengerepo.NewChallengeSubmissionRepository(db)
 	mentoringRepository := mentoringrepo.NewMentoringRepository(db)
+	mentoringScheduleRepository := mentoringrepo.NewMentoringScheduleRepository(db)
 	paymentRepository := paymentrepo.NewPaymentRepository(db)
+	reportRepository := reportrepo.NewReportRepository(db)
 
 	userService := usersvc.NewUserService(userRepository, bcryptInstance, fileUtil, uuidInstance)
 	authService := authsvc.NewAuthService(authRepository, userService, bcryptInstance, cache, fileUtil, jwtAccess,
 		mailer, randomGenerator, uuidInstance)
 	categoryService := categorysvc.NewCategoryService(categoryRepository, uuidInstance)
 	courseService := coursesvc.NewCourseService(courseRepository, fileUtil, txManager, uuidInstance)
-	courseContentService := coursesvc.NewCourseContentService(courseContentRepository, courseRepository, fileUtil,
-		uuidInstance)
+	courseContentService := coursesvc.NewCourseContentService(courseContentRepository, courseRepository,
+		courseProgressRepository, courseArticleRepository, courseQuizRepository, fileUtil, uuidInstance)
 	courseProgressService := coursesvc.NewCourseProgressService(courseProgressRepository, txManager, userRepository)
 	courseFeedbackService := coursesvc.NewCourseFeedbackService(courseFeedbackRepository, courseRepository, fileUtil,
 		txManager, uuidInstance)
+	courseArticleService := coursesvc.NewCourseArticleService(courseArticleRepository, courseContentRepository,
+		courseRepository, uuidInstance)
+	courseQuizService := coursesvc.NewCourseQuizService(courseQuizRepository, courseContentRepository,
+		courseRepository, courseProgressRepository, userRepository, txManager, uuidInstance)
+	courseNoteService := coursesvc.NewCourseNoteService(courseNoteRepository, courseContentRepository,
+		courseRepository, uuidInstance)
 	challengeGroupService := challengesvc.NewChallengeGroupService(challengeGroupRepository, fileUtil, uuidInstance)
 	challengeService := challengesvc.NewChallengeService(challengeRepository, fileUtil, uuidInstance)
 	challengeSubmissionService := challengesvc.NewChallengeSubmissionService(challengeSubmissionRepository,
 		challengeRepository, userRepository, txManager, fileUtil, uuidInstance)
-	mentoringService := mentoringsvc.NewMentoringService(mentoringRepository, userRepository, fileUtil, uuidInstance)
-	paymentService := paymentsvc.NewPaymentService(paymentRepository, mentoringService, userService, cache,
-		midtransPayment, txManager, uuidInstance)
+	mentoringService := mentoringsvc.NewMentoringService(mentoringRepository, userRepository, cache, fileUtil,
+		mailer, randomGenerator, uuidInstance)
+	mentoringScheduleService := mentoringsvc.NewMentoringScheduleService(mentoringScheduleRepository, userRepository,
+		mailer, uuidInstance)
+	mentoringTranscriptService := mentoringsvc.NewMentoringTranscriptService(mentoringRepository, userRepository,
+		fileUtil, pdfGenerator, uuidInstance)
+	paymentService := paymentsvc.NewPaymentService(paymentRepository, mentoringService, mentoringScheduleService,
+		userService, courseService, cache, fileUtil, mailer, midtransPayment, pdfGenerator, txManager, uuidInstance)
+	reportService := reportsvc.NewReportService(reportRepository)
+
+	mentoringService.StartExpiryWorker()
+	mentoringScheduleService.StartReminderWorker()
 
 	userhnd.InitUserHandler(v1, middlewareInstance, validatorInstance, userService)
 	authhnd.InitAuthHandler(v1, middlewareInstance, validatorInstance, authService)
@@ -154,9 +187,41 @@ func (s *httpServer) MountRoutes(db *sqlx.DB, cache cache.ICache) {
 	coursehnd.InitCourseContentHandler(v1, middlewareInstance, validatorInstance, courseContentService)
 	coursehnd.InitCourseProgressHandler(v1, middlewareInstance, validatorInstance, courseProgressService)
 	coursehnd.InitCourseFeedbackHandler(v1, middlewareInstance, validatorInstance, courseFeedbackService)
+	coursehnd.InitCourseArticleHandler(v1, middlewareInstance, validatorInstance, courseArticleService)
+	coursehnd.InitCourseQuizHandler(v1, middlewareInstance, validatorInstance, courseQuizService)
+	coursehnd.InitCourseNoteHandler(v1, middlewareInstance, validatorInstance, courseNoteService)
 	challengehnd.InitChallengeGroupHandler(v1, middlewareInstance, validatorInstance, challengeGroupService)
 	challengehnd.InitChallengeHandler(v1, middlewareInstance, validatorInstance, challengeService)
 	challengehnd.InitChallengeSubmissionHandler(v1, middlewareInstance, validatorInstance, challengeSubmissionService)
 	mentoringhnd.InitMentoringHandler(v1, middlewareInstance, mentoringService, jwtAccess, validatorInstance)
+	mentoringhnd.InitMentoringScheduleHandler(v1, middlewareInstance, mentoringScheduleService, validatorInstance)
+	mentoringhnd.InitMentoringTranscriptHandler(v1, middlewareInstance, mentoringTranscriptService, validatorInstance)
 	paymenthnd.InitPaymentHandler(v1, middlewareInstance, paymentService, validatorInstance)
+	reporthnd.InitReportHandler(v1, middlewareInstance, validatorInstance, reportService)
+
+	if localStorage != nil {
+		storagehnd.InitStorageHandler(v1, localStorage)
+	}
+}
+
+// newStorageBackend returns the storage selected by STORAGE_BACKEND. The local storage is also returned
+// on its own, since its signed URLs are served by this app.
+func newStorageBackend() (fileutil.IStorage, fileutil.ILocalStorage) {
+	switch env.GetEnv().StorageBackend {
+	case "gcs":
+		return fileutil.NewCloudStorage(gcp.NewStorageClient()), nil
+	case "local":
+		if env.GetEnv().LocalStorageSigningKey == "" {
+			log.Fatal(context.Background(), nil, "LOCAL_STORAGE_SIGNING_KEY is required for the local storage")
+		}
+
+		localStorage := fileutil.NewLocalStorage(env.GetEnv().LocalStorageDir, env.GetEnv().AppURL+"/api/v1/storage",
+			[]byte(env.GetEnv().LocalStorageSigningKey))
+		return localStorage, localStorage
+	default:
+		log.Fatal(context.Background(), map[string]interface{}{
+			"storage_backend": env.GetEnv().StorageBackend,
+		}, "Unknown storage backend")
+		return nil, nil
+	}
 }
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"io"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// BodyLimit keeps the body limit on every route except the ones under streamedPrefixes.
+// With StreamRequestBody, bodies over the limit are passed on as a stream instead of being rejected,
+// and reading them with Body would load them whole into memory.
+func BodyLimit(limit int, streamedPrefixes ...string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		for _, prefix := range streamedPrefixes {
+			if strings.HasPrefix(ctx.Path(), prefix) {
+				return ctx.Next()
+			}
+		}
+
+		stream := ctx.Context().RequestBodyStream()
+		contentLength := ctx.Request().Header.ContentLength()
+
+		// a body with a known length within the limit has been read already
+		if stream == nil || (contentLength >= 0 && contentLength <= limit) {
+			return ctx.Next()
+		}
+
+		if contentLength > limit {
+			return fiber.ErrRequestEntityTooLarge
+		}
+
+		// the length is unknown, as for chunked bodies
+		body, err := io.ReadAll(io.LimitReader(stream, int64(limit)+1))
+		if err != nil {
+			return fiber.ErrBadRequest
+		}
+
+		if len(body) > limit {
+			return fiber.ErrRequestEntityTooLarge
+		}
+
+		ctx.Request().SetBody(body)
+
+		return ctx.Next()
+	}
+}
@@ -1,13 +1,18 @@
 package middleware
 
 import (
+	"strings"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/compress"
 )
 
 func Compress() fiber.Handler {
 	config := compress.Config{
-		Next:  nil,
+		// Compressing server-sent events would buffer them instead of delivering them immediately
+		Next: func(ctx *fiber.Ctx) bool {
+			return strings.Contains(ctx.Get(fiber.HeaderAccept), "text/event-stream")
+		},
 		Level: compress.LevelDefault,
 	}
 
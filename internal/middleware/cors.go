@@ -8,8 +8,8 @@ import (
 func Cors() fiber.Handler {
 	config := cors.Config{
 		AllowMethods:  "GET,POST,PUT,DELETE,PATCH,OPTIONS,HEAD",
-		AllowHeaders:  "Content-Type,Authorization,Accept,Origin,X-Requested-With,X-XSRF-Token,X-Cursor,Token-Type",
-		ExposeHeaders: "Content-Length",
+		AllowHeaders:  "Content-Type,Authorization,Accept,Origin,X-Requested-With,X-XSRF-Token,X-Cursor,Token-Type,Idempotency-Key",
+		ExposeHeaders: "Content-Length,Idempotent-Replayed",
 	}
 
 	return cors.New(config)
@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bytedance/sonic"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+
+	"github.com/nathakusuma/elevateu-backend/domain/ctxkey"
+	"github.com/nathakusuma/elevateu-backend/domain/errorpkg"
+	"github.com/nathakusuma/elevateu-backend/pkg/log"
+)
+
+const (
+	idempotencyKeyHeader   = "Idempotency-Key"
+	idempotencyKeyMaxLen   = 255
+	idempotencyLockTimeout = 30 * time.Second
+)
+
+type idempotentResponse struct {
+	Fingerprint string `json:"fingerprint"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// Idempotent replays the first successful response for repeated requests with the same Idempotency-Key header.
+// Requests without the header are passed through. dependency: RequireAuthenticated
+func (m *Middleware) Idempotent(ttl time.Duration) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		key := ctx.Get(idempotencyKeyHeader)
+		if key == "" {
+			return ctx.Next()
+		}
+		if len(key) > idempotencyKeyMaxLen {
+			return errorpkg.ErrValidation().
+				WithDetail(fmt.Sprintf("Idempotency-Key must not exceed %d characters", idempotencyKeyMaxLen))
+		}
+
+		userID, ok := ctx.Locals(ctxkey.UserID).(uuid.UUID)
+		if !ok {
+			return errorpkg.ErrInvalidBearerToken()
+		}
+
+		cacheKey := fmt.Sprintf("idempotency:%s:%s", userID, key)
+
+		hash := sha256.New()
+		hash.Write([]byte(ctx.Method() + " " + ctx.Path() + "\n"))
+		hash.Write(ctx.Body())
+		fingerprint := hex.EncodeToString(hash.Sum(nil))
+
+		var cachedJSON string
+		err := m.cache.Get(ctx.Context(), cacheKey, &cachedJSON)
+		if err == nil {
+			var cached idempotentResponse
+			if err = sonic.Unmarshal([]byte(cachedJSON), &cached); err != nil {
+				traceID := log.ErrorWithTraceID(ctx.Context(), map[string]interface{}{
+					"error": err,
+					"key":   cacheKey,
+				}, "Failed to unmarshal idempotent response")
+				return errorpkg.ErrInternalServer().WithTraceID(traceID)
+			}
+
+			if cached.Fingerprint != fingerprint {
+				return errorpkg.ErrIdempotencyKeyReused()
+			}
+
+			ctx.Set("Idempotent-Replayed", "true")
+			ctx.Set(fiber.HeaderContentType, cached.ContentType)
+			return ctx.Status(cached.Status).Send(cached.Body)
+		}
+		if !strings.HasPrefix(err.Error(), "not found") {
+			traceID := log.ErrorWithTraceID(ctx.Context(), map[string]interface{}{
+				"error": err,
+				"key":   cacheKey,
+			}, "Failed to get idempotent response")
+			return errorpkg.ErrInternalServer().WithTraceID(traceID)
+		}
+
+		// Concurrent duplicates (e.g. double clicks) must not run the handler twice
+		lockKey := cacheKey + ":lock"
+		locked, err := m.cache.SetNX(ctx.Context(), lockKey, fingerprint, idempotencyLockTimeout)
+		if err != nil {
+			traceID := log.ErrorWithTraceID(ctx.Context(), map[string]interface{}{
+				"error": err,
+				"key":   lockKey,
+			}, "Failed to acquire idempotency lock")
+			return errorpkg.ErrInternalServer().WithTraceID(traceID)
+		}
+		if !locked {
+			return errorpkg.ErrIdempotencyKeyInProgress()
+		}
+		defer func() {
+			if err2 := m.cache.Del(context.Background(), lockKey); err2 != nil {
+				log.Error(context.Background(), map[string]interface{}{
+					"error": err2,
+					"key":   lockKey,
+				}, "Failed to release idempotency lock")
+			}
+		}()
+
+		// Errors are not stored so the client can retry with the same key
+		if err = ctx.Next(); err != nil {
+			return err
+		}
+
+		status := ctx.Response().StatusCode()
+		if status < fiber.StatusOK || status >= fiber.StatusMultipleChoices {
+			return nil
+		}
+
+		responseJSON, err := sonic.Marshal(idempotentResponse{
+			Fingerprint: fingerprint,
+			Status:      status,
+			ContentType: string(ctx.Response().Header.ContentType()),
+			Body:        ctx.Response().Body(),
+		})
+		if err != nil {
+			log.Error(ctx.Context(), map[string]interface{}{
+				"error": err,
+				"key":   cacheKey,
+			}, "Failed to marshal idempotent response")
+			return nil
+		}
+
+		if err = m.cache.Set(ctx.Context(), cacheKey, string(responseJSON), ttl); err != nil {
+			log.Error(ctx.Context(), map[string]interface{}{
+				"error": err,
+				"key":   cacheKey,
+			}, "Failed to store idempotent response")
+		}
+
+		return nil
+	}
+}
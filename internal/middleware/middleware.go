@@ -1,15 +1,21 @@
 package middleware
 
-import "github.com/nathakusuma/elevateu-backend/pkg/jwt"
+import (
+	"github.com/nathakusuma/elevateu-backend/internal/infra/cache"
+	"github.com/nathakusuma/elevateu-backend/pkg/jwt"
+)
 
 type Middleware struct {
-	jwt jwt.IJwt
+	jwt   jwt.IJwt
+	cache cache.ICache
 }
 
 func NewMiddleware(
 	jwt jwt.IJwt,
+	cache cache.ICache,
 ) *Middleware {
 	return &Middleware{
-		jwt: jwt,
+		jwt:   jwt,
+		cache: cache,
 	}
 }
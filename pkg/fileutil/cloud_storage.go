@@ -2,6 +2,7 @@ package fileutil
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,7 +13,18 @@ import (
 	"github.com/nathakusuma/elevateu-backend/internal/infra/env"
 )
 
-func (u *fileUtil) Upload(ctx context.Context, file io.Reader, path string) (string, error) {
+type cloudStorage struct {
+	client *storage.Client
+}
+
+// NewCloudStorage stores the files in the Google Cloud Storage bucket set in GCP_STORAGE_BUCKET_NAME
+func NewCloudStorage(client *storage.Client) IStorage {
+	return &cloudStorage{
+		client: client,
+	}
+}
+
+func (u *cloudStorage) Upload(ctx context.Context, file io.Reader, path, contentType string) (string, error) {
 	bucket := env.GetEnv().GCPStorageBucketName
 
 	ctx, cancel := context.WithTimeout(ctx, time.Second*50)
@@ -20,6 +32,7 @@ func (u *fileUtil) Upload(ctx context.Context, file io.Reader, path string) (str
 
 	// Upload an object with storage.Writer.
 	wc := u.client.Bucket(bucket).Object(path).NewWriter(ctx)
+	wc.ContentType = contentType
 
 	if _, err := io.Copy(wc, file); err != nil {
 		return "", fmt.Errorf("io.Copy: %w", err)
@@ -34,17 +47,17 @@ func (u *fileUtil) Upload(ctx context.Context, file io.Reader, path string) (str
 	return url, nil
 }
 
-func (u *fileUtil) GetFullURL(path string) string {
+func (u *cloudStorage) GetFullURL(path string) string {
 	bucket := env.GetEnv().GCPStorageBucketName
 	return fmt.Sprintf("https://storage.googleapis.com/%s/%s", bucket, path)
 }
 
-func (u *fileUtil) GetSignedURL(path string) (string, error) {
+func (u *cloudStorage) GetSignedURL(path string) (string, error) {
 	bucket := env.GetEnv().GCPStorageBucketName
 
 	url, err := u.client.Bucket(bucket).SignedURL(path, &storage.SignedURLOptions{
 		Method:  http.MethodGet,
-		Expires: time.Now().Add(10 * time.Minute),
+		Expires: time.Now().Add(SignedURLLifetime),
 	})
 	if err != nil {
 		return "", fmt.Errorf("client.Bucket(%q).SignedURL: %w", bucket, err)
@@ -53,12 +66,12 @@ func (u *fileUtil) GetSignedURL(path string) (string, error) {
 	return url, nil
 }
 
-func (u *fileUtil) GetUploadSignedURL(path, contentType string) (string, error) {
+func (u *cloudStorage) GetUploadSignedURL(path, contentType string) (string, error) {
 	bucket := env.GetEnv().GCPStorageBucketName
 
 	options := &storage.SignedURLOptions{
 		Method:  http.MethodPut,
-		Expires: time.Now().Add(10 * time.Minute),
+		Expires: time.Now().Add(SignedURLLifetime),
 	}
 
 	if contentType != "" {
@@ -73,7 +86,7 @@ func (u *fileUtil) GetUploadSignedURL(path, contentType string) (string, error)
 	return url, nil
 }
 
-func (u *fileUtil) Delete(ctx context.Context, path string) error {
+func (u *cloudStorage) Delete(ctx context.Context, path string) error {
 	bucket := env.GetEnv().GCPStorageBucketName
 
 	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
@@ -96,3 +109,24 @@ func (u *fileUtil) Delete(ctx context.Context, path string) error {
 
 	return nil
 }
+
+func (u *cloudStorage) GetAttributes(ctx context.Context, path string) (*FileAttributes, error) {
+	bucket := env.GetEnv().GCPStorageBucketName
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+
+	attrs, err := u.client.Bucket(bucket).Object(path).Attrs(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("object.Attrs: %w", err)
+	}
+
+	return &FileAttributes{
+		Size:        attrs.Size,
+		ContentType: attrs.ContentType,
+		UpdatedAt:   attrs.Updated,
+	}, nil
+}
@@ -19,6 +19,18 @@ var ImageContentTypes = []string{
 	"image/webp",
 }
 
+var DocumentContentTypes = []string{
+	"application/pdf",
+	"application/msword",
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	"application/vnd.ms-excel",
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	"application/vnd.ms-powerpoint",
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	"application/zip",
+	"text/plain",
+}
+
 func (u *fileUtil) CheckMIMEFileType(file multipart.File, allowed []string) (bool, string, error) {
 	buffer := make([]byte, 512)
 	_, err := file.Read(buffer)
@@ -6,8 +6,7 @@ import (
 	"io"
 	"mime/multipart"
 	"sync"
-
-	"cloud.google.com/go/storage"
+	"time"
 
 	"github.com/nathakusuma/elevateu-backend/domain/errorpkg"
 	"github.com/nathakusuma/elevateu-backend/pkg/log"
@@ -20,28 +19,66 @@ var (
 
 type IFileUtil interface {
 	CheckMIMEFileType(file multipart.File, allowed []string) (bool, string, error)
-	Upload(ctx context.Context, file io.Reader, path string) (string, error)
+	Upload(ctx context.Context, file io.Reader, path, contentType string) (string, error)
 	GetFullURL(path string) string
 	GetSignedURL(path string) (string, error)
+	GetSignedURLs(paths []string) (map[string]string, error)
 	GetUploadSignedURL(path, contentType string) (string, error)
 	Delete(ctx context.Context, path string) error
+	Exists(ctx context.Context, path string) (bool, error)
+	GetAttributes(ctx context.Context, path string) (*FileAttributes, error)
 	ValidateAndUploadFile(ctx context.Context, header *multipart.FileHeader, allowedTypes []string,
 		path string) (string, error)
 }
 
+// IStorage is the backend the files are stored in
+type IStorage interface {
+	// Upload stores the file with the content type. The content type is detected from the content when empty.
+	Upload(ctx context.Context, file io.Reader, path, contentType string) (string, error)
+	GetFullURL(path string) string
+	GetSignedURL(path string) (string, error)
+	GetUploadSignedURL(path, contentType string) (string, error)
+	Delete(ctx context.Context, path string) error
+	// GetAttributes returns nil without an error when the file does not exist
+	GetAttributes(ctx context.Context, path string) (*FileAttributes, error)
+}
+
+// FileAttributes describes a stored file
+type FileAttributes struct {
+	Size        int64
+	ContentType string
+	UpdatedAt   time.Time
+}
+
 type fileUtil struct {
-	client *storage.Client
+	IStorage
+	signedURLs *signedURLCache
 }
 
-func NewFileUtil(client *storage.Client) IFileUtil {
+func NewFileUtil(storage IStorage) IFileUtil {
 	once.Do(func() {
 		fileUtilInstance = &fileUtil{
-			client: client,
+			IStorage:   storage,
+			signedURLs: newSignedURLCache(),
 		}
 	})
 	return fileUtilInstance
 }
 
+func (u *fileUtil) Delete(ctx context.Context, path string) error {
+	u.signedURLs.delete(path)
+	return u.IStorage.Delete(ctx, path)
+}
+
+func (u *fileUtil) Exists(ctx context.Context, path string) (bool, error) {
+	attrs, err := u.GetAttributes(ctx, path)
+	if err != nil {
+		return false, err
+	}
+
+	return attrs != nil, nil
+}
+
 func (u *fileUtil) ValidateAndUploadFile(ctx context.Context, header *multipart.FileHeader, allowedTypes []string,
 	path string) (string, error) {
 	if header.Size > 2*MegaByte {
@@ -74,7 +111,7 @@ func (u *fileUtil) ValidateAndUploadFile(ctx context.Context, header *multipart.
 			fmt.Sprintf("File type %s is not allowed. Please upload a valid file", fileType))
 	}
 
-	url, err := u.Upload(ctx, file, path)
+	url, err := u.Upload(ctx, file, path, fileType)
 	if err != nil {
 		traceID := log.ErrorWithTraceID(ctx, map[string]interface{}{
 			"error": err,
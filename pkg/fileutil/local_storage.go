@@ -0,0 +1,229 @@
+package fileutil
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ILocalStorage stores the files on the local disk, so the app can run without Google Cloud.
+// The disk can't sign URLs by itself, so the signed URLs point to a route of this app which checks
+// the signature with VerifySignature, then serves the file with Open or stores it with Upload.
+type ILocalStorage interface {
+	IStorage
+	VerifySignature(method, path, contentType string, expires int64, signature string) bool
+	Open(path string) (io.ReadCloser, *FileAttributes, error)
+}
+
+type localStorage struct {
+	rootDir    string
+	baseURL    string
+	signingKey []byte
+}
+
+// NewLocalStorage stores the files under rootDir. baseURL is the URL of the route serving the signed URLs.
+func NewLocalStorage(rootDir, baseURL string, signingKey []byte) ILocalStorage {
+	return &localStorage{
+		rootDir:    rootDir,
+		baseURL:    baseURL,
+		signingKey: signingKey,
+	}
+}
+
+// Upload keeps the content type in a file next to the uploaded one, as the disk does not keep it
+func (s *localStorage) Upload(ctx context.Context, file io.Reader, path, contentType string) (string, error) {
+	fullPath, err := s.resolve(path)
+	if err != nil {
+		return "", err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(fullPath), 0o755); err != nil {
+		return "", fmt.Errorf("os.MkdirAll: %w", err)
+	}
+
+	// Write to a temporary file first so readers never see a partially written file
+	tmp, err := os.CreateTemp(filepath.Dir(fullPath), ".upload-*")
+	if err != nil {
+		return "", fmt.Errorf("os.CreateTemp: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = io.Copy(tmp, file); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("io.Copy: %w", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return "", fmt.Errorf("File.Close: %w", err)
+	}
+
+	if err = os.Rename(tmp.Name(), fullPath); err != nil {
+		return "", fmt.Errorf("os.Rename: %w", err)
+	}
+
+	if contentType == "" {
+		err = os.Remove(contentTypePath(fullPath))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("os.Remove: %w", err)
+		}
+	} else if err = os.WriteFile(contentTypePath(fullPath), []byte(contentType), 0o644); err != nil {
+		return "", fmt.Errorf("os.WriteFile: %w", err)
+	}
+
+	return s.GetFullURL(path), nil
+}
+
+func (s *localStorage) GetFullURL(path string) string {
+	return fmt.Sprintf("%s/%s", s.baseURL, (&url.URL{Path: path}).EscapedPath())
+}
+
+func (s *localStorage) GetSignedURL(path string) (string, error) {
+	return s.signURL(http.MethodGet, path, ""), nil
+}
+
+func (s *localStorage) GetUploadSignedURL(path, contentType string) (string, error) {
+	return s.signURL(http.MethodPut, path, contentType), nil
+}
+
+func (s *localStorage) Delete(_ context.Context, path string) error {
+	fullPath, err := s.resolve(path)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(fullPath); err != nil {
+		return fmt.Errorf("os.Remove(%q): %w", path, err)
+	}
+
+	if err = os.Remove(contentTypePath(fullPath)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("os.Remove(%q): %w", path, err)
+	}
+
+	return nil
+}
+
+func (s *localStorage) GetAttributes(_ context.Context, path string) (*FileAttributes, error) {
+	file, attrs, err := s.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	return attrs, nil
+}
+
+// Open returns the file with its attributes. The caller must close the file.
+// The content type is the one given to Upload, or else it is sniffed from the content.
+func (s *localStorage) Open(path string) (io.ReadCloser, *FileAttributes, error) {
+	fullPath, err := s.resolve(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	file, err := os.Open(fullPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("os.Open: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("File.Stat: %w", err)
+	}
+
+	if info.IsDir() {
+		file.Close()
+		return nil, nil, fmt.Errorf("os.Open: %w", os.ErrNotExist)
+	}
+
+	contentType, err := os.ReadFile(contentTypePath(fullPath))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		file.Close()
+		return nil, nil, fmt.Errorf("os.ReadFile: %w", err)
+	}
+
+	if len(contentType) == 0 {
+		contentType, err = sniffContentType(file)
+		if err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+	}
+
+	return file, &FileAttributes{
+		Size:        info.Size(),
+		ContentType: string(contentType),
+		UpdatedAt:   info.ModTime(),
+	}, nil
+}
+
+// sniffContentType detects the content type from the start of the file, then rewinds it
+func sniffContentType(file *os.File) ([]byte, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("io.ReadFull: %w", err)
+	}
+
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("File.Seek: %w", err)
+	}
+
+	return []byte(http.DetectContentType(head[:n])), nil
+}
+
+// contentTypePath is the hidden file keeping the content type of the file at fullPath
+func contentTypePath(fullPath string) string {
+	return filepath.Join(filepath.Dir(fullPath), "."+filepath.Base(fullPath)+".content-type")
+}
+
+func (s *localStorage) VerifySignature(method, path, contentType string, expires int64, signature string) bool {
+	if time.Now().Unix() > expires {
+		return false
+	}
+
+	expected := s.sign(method, path, contentType, expires)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+func (s *localStorage) signURL(method, path, contentType string) string {
+	expires := time.Now().Add(SignedURLLifetime).Unix()
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", s.sign(method, path, contentType, expires))
+
+	return fmt.Sprintf("%s?%s", s.GetFullURL(path), query.Encode())
+}
+
+func (s *localStorage) sign(method, path, contentType string, expires int64) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(fmt.Sprintf("%s\n%s\n%s\n%d", method, path, contentType, expires)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// resolve maps the path to the disk. Only clean relative paths are accepted, so a path can't reach
+// outside the root directory.
+func (s *localStorage) resolve(path string) (string, error) {
+	if path == "" || path == "." || path == ".." || strings.HasPrefix(path, "../") ||
+		pathpkg.IsAbs(path) || pathpkg.Clean(path) != path {
+		return "", fmt.Errorf("invalid path %q", path)
+	}
+
+	return filepath.Join(s.rootDir, filepath.FromSlash(path)), nil
+}
@@ -0,0 +1,207 @@
+package fileutil
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBaseURL = "http://localhost:8080/api/v1/storage"
+
+func newTestLocalStorage(t *testing.T) *localStorage {
+	return NewLocalStorage(t.TempDir(), testBaseURL, []byte("test-signing-key")).(*localStorage)
+}
+
+// parseSignedURL returns the path, expiry and signature of a signed URL
+func parseSignedURL(t *testing.T, signedURL string) (string, int64, string) {
+	parsed, err := url.Parse(signedURL)
+	require.NoError(t, err)
+
+	expires, err := strconv.ParseInt(parsed.Query().Get("expires"), 10, 64)
+	require.NoError(t, err)
+
+	return strings.TrimPrefix(parsed.Path, "/api/v1/storage/"), expires, parsed.Query().Get("signature")
+}
+
+func Test_LocalStorage_VerifySignature(t *testing.T) {
+	path := "course_videos/01949e48-9f6b-796b-9611-3c9025493233.mp4"
+
+	t.Run("valid download URL", func(t *testing.T) {
+		s := newTestLocalStorage(t)
+
+		signedURL, err := s.GetSignedURL(path)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(signedURL, testBaseURL+"/"+path+"?"))
+
+		urlPath, expires, signature := parseSignedURL(t, signedURL)
+		assert.Equal(t, path, urlPath)
+		assert.WithinDuration(t, time.Now().Add(SignedURLLifetime), time.Unix(expires, 0), time.Minute)
+		assert.True(t, s.VerifySignature(http.MethodGet, path, "", expires, signature))
+	})
+
+	t.Run("valid upload URL", func(t *testing.T) {
+		s := newTestLocalStorage(t)
+
+		signedURL, err := s.GetUploadSignedURL(path, "video/mp4")
+		require.NoError(t, err)
+
+		_, expires, signature := parseSignedURL(t, signedURL)
+		assert.True(t, s.VerifySignature(http.MethodPut, path, "video/mp4", expires, signature))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		s := newTestLocalStorage(t)
+
+		expires := time.Now().Add(-time.Second).Unix()
+		signature := s.sign(http.MethodGet, path, "", expires)
+
+		assert.False(t, s.VerifySignature(http.MethodGet, path, "", expires, signature))
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		s := newTestLocalStorage(t)
+
+		signedURL, err := s.GetUploadSignedURL(path, "video/mp4")
+		require.NoError(t, err)
+		_, expires, signature := parseSignedURL(t, signedURL)
+
+		otherKey := NewLocalStorage(t.TempDir(), testBaseURL, []byte("other-signing-key")).(*localStorage)
+
+		tests := []struct {
+			name        string
+			method      string
+			path        string
+			contentType string
+			expires     int64
+			signature   string
+		}{
+			{"method", http.MethodGet, path, "video/mp4", expires, signature},
+			{"path", http.MethodPut, "course_videos/other.mp4", "video/mp4", expires, signature},
+			{"content type", http.MethodPut, path, "text/html", expires, signature},
+			{"extended expiry", http.MethodPut, path, "video/mp4", expires + 3600, signature},
+			{"signature", http.MethodPut, path, "video/mp4", expires, strings.Repeat("0", len(signature))},
+			{"empty signature", http.MethodPut, path, "video/mp4", expires, ""},
+			{"signing key", http.MethodPut, path, "video/mp4", expires,
+				otherKey.sign(http.MethodPut, path, "video/mp4", expires)},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				assert.False(t, s.VerifySignature(tt.method, tt.path, tt.contentType, tt.expires, tt.signature))
+			})
+		}
+	})
+}
+
+func Test_LocalStorage_resolve(t *testing.T) {
+	s := newTestLocalStorage(t)
+
+	valid := []string{
+		"avatars/01949e48-9f6b-796b-9611-3c9025493233",
+		"course_materials/a/b/material.pdf",
+		"file..name",
+	}
+	for _, path := range valid {
+		t.Run(path, func(t *testing.T) {
+			fullPath, err := s.resolve(path)
+			require.NoError(t, err)
+			assert.Equal(t, filepath.Join(s.rootDir, filepath.FromSlash(path)), fullPath)
+		})
+	}
+
+	invalid := []string{
+		"",
+		".",
+		"..",
+		"../secret",
+		"../../etc/passwd",
+		"avatars/../../secret",
+		"avatars/../avatar",
+		"/etc/passwd",
+		"/avatars/avatar",
+		"./avatars/avatar",
+		"avatars//avatar",
+		"avatars/",
+	}
+	for _, path := range invalid {
+		t.Run("reject "+path, func(t *testing.T) {
+			_, err := s.resolve(path)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_LocalStorage_UploadOpen(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("round trip", func(t *testing.T) {
+		s := newTestLocalStorage(t)
+		content := "%PDF-1.4 test content"
+
+		fileURL, err := s.Upload(ctx, strings.NewReader(content), "course_materials/material.pdf", "")
+		require.NoError(t, err)
+		assert.Equal(t, testBaseURL+"/course_materials/material.pdf", fileURL)
+
+		file, attrs, err := s.Open("course_materials/material.pdf")
+		require.NoError(t, err)
+		defer file.Close()
+
+		data := make([]byte, len(content)+1)
+		n, _ := file.Read(data)
+		assert.Equal(t, content, string(data[:n]))
+		assert.Equal(t, int64(len(content)), attrs.Size)
+		assert.Equal(t, "application/pdf", attrs.ContentType)
+	})
+
+	t.Run("given content type is kept", func(t *testing.T) {
+		s := newTestLocalStorage(t)
+		path := "course_videos/subtitle/en"
+
+		_, err := s.Upload(ctx, strings.NewReader("WEBVTT\n"), path, "text/vtt")
+		require.NoError(t, err)
+
+		attrs, err := s.GetAttributes(ctx, path)
+		require.NoError(t, err)
+		assert.Equal(t, "text/vtt", attrs.ContentType)
+
+		// uploading again without a content type detects it again
+		_, err = s.Upload(ctx, strings.NewReader("WEBVTT\n"), path, "")
+		require.NoError(t, err)
+
+		attrs, err = s.GetAttributes(ctx, path)
+		require.NoError(t, err)
+		assert.Equal(t, "text/plain; charset=utf-8", attrs.ContentType)
+
+		require.NoError(t, s.Delete(ctx, path))
+		entries, err := os.ReadDir(filepath.Join(s.rootDir, "course_videos", "subtitle"))
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("missing file has no attributes", func(t *testing.T) {
+		s := newTestLocalStorage(t)
+
+		attrs, err := s.GetAttributes(ctx, "course_materials/missing.pdf")
+		assert.NoError(t, err)
+		assert.Nil(t, attrs)
+	})
+
+	t.Run("upload outside the root is rejected", func(t *testing.T) {
+		s := newTestLocalStorage(t)
+
+		_, err := s.Upload(ctx, strings.NewReader("content"), "../outside", "")
+		assert.Error(t, err)
+
+		_, statErr := os.Stat(filepath.Join(filepath.Dir(s.rootDir), "outside"))
+		assert.True(t, os.IsNotExist(statErr))
+	})
+}
@@ -0,0 +1,148 @@
+package fileutil
+
+import (
+	"sync"
+	"time"
+)
+
+// SignedURLLifetime is how long the signed URLs of every storage stay valid
+const SignedURLLifetime = 10 * time.Minute
+
+// A cached URL is handed out for half of its lifetime, so clients always get at least
+// the other half to use it
+const signedURLCacheTTL = SignedURLLifetime / 2
+
+// maxConcurrentSigning bounds the signing done at once by GetSignedURLs, since signing may call the storage API
+const maxConcurrentSigning = 8
+
+type signedURLEntry struct {
+	url       string
+	expiresAt time.Time
+}
+
+// signedURLCache caches the signed download URLs by path
+type signedURLCache struct {
+	mu        sync.RWMutex
+	entries   map[string]signedURLEntry
+	lastPurge time.Time
+}
+
+func newSignedURLCache() *signedURLCache {
+	return &signedURLCache{
+		entries:   make(map[string]signedURLEntry),
+		lastPurge: time.Now(),
+	}
+}
+
+func (c *signedURLCache) get(path string) (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[path]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return "", false
+	}
+
+	return entry.url, true
+}
+
+func (c *signedURLCache) set(path, url string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	c.entries[path] = signedURLEntry{
+		url:       url,
+		expiresAt: now.Add(signedURLCacheTTL),
+	}
+
+	// drop the expired entries once in a while so the cache doesn't keep growing
+	if now.Sub(c.lastPurge) > signedURLCacheTTL {
+		for p, entry := range c.entries {
+			if now.After(entry.expiresAt) {
+				delete(c.entries, p)
+			}
+		}
+		c.lastPurge = now
+	}
+}
+
+func (c *signedURLCache) delete(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, path)
+}
+
+func (u *fileUtil) GetSignedURL(path string) (string, error) {
+	if url, ok := u.signedURLs.get(path); ok {
+		return url, nil
+	}
+
+	url, err := u.IStorage.GetSignedURL(path)
+	if err != nil {
+		return "", err
+	}
+
+	u.signedURLs.set(path, url)
+
+	return url, nil
+}
+
+// GetSignedURLs signs the paths in one go for list responses. Cached URLs are reused and
+// the rest are signed concurrently. The result is keyed by path.
+func (u *fileUtil) GetSignedURLs(paths []string) (map[string]string, error) {
+	urls := make(map[string]string, len(paths))
+	var missing []string
+	for _, path := range paths {
+		if _, ok := urls[path]; ok {
+			continue
+		}
+
+		url, ok := u.signedURLs.get(path)
+		if !ok {
+			missing = append(missing, path)
+		}
+		urls[path] = url
+	}
+
+	if len(missing) == 0 {
+		return urls, nil
+	}
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		sem      = make(chan struct{}, maxConcurrentSigning)
+	)
+
+	for _, path := range missing {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(path string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			url, err := u.GetSignedURL(path)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			urls[path] = url
+		}(path)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return urls, nil
+}
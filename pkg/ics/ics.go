@@ -0,0 +1,73 @@
+package ics
+
+import (
+	"bytes"
+	"strings"
+	"time"
+)
+
+const (
+	dateTimeLayout = "20060102T150405Z"
+	maxLineOctets  = 75
+)
+
+type Event struct {
+	UID         string
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+}
+
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+)
+
+// Generate builds an iCalendar (RFC 5545) document containing the events
+func Generate(prodID string, events []Event) []byte {
+	var buf bytes.Buffer
+	stamp := time.Now().UTC().Format(dateTimeLayout)
+
+	writeLine(&buf, "BEGIN:VCALENDAR")
+	writeLine(&buf, "VERSION:2.0")
+	writeLine(&buf, "PRODID:"+prodID)
+	writeLine(&buf, "CALSCALE:GREGORIAN")
+	writeLine(&buf, "METHOD:PUBLISH")
+
+	for _, event := range events {
+		writeLine(&buf, "BEGIN:VEVENT")
+		writeLine(&buf, "UID:"+event.UID)
+		writeLine(&buf, "DTSTAMP:"+stamp)
+		writeLine(&buf, "DTSTART:"+event.Start.UTC().Format(dateTimeLayout))
+		writeLine(&buf, "DTEND:"+event.End.UTC().Format(dateTimeLayout))
+		writeLine(&buf, "SUMMARY:"+textEscaper.Replace(event.Summary))
+		if event.Description != "" {
+			writeLine(&buf, "DESCRIPTION:"+textEscaper.Replace(event.Description))
+		}
+		writeLine(&buf, "END:VEVENT")
+	}
+
+	writeLine(&buf, "END:VCALENDAR")
+
+	return buf.Bytes()
+}
+
+// writeLine folds lines longer than 75 octets without splitting multibyte characters
+func writeLine(buf *bytes.Buffer, line string) {
+	octets := 0
+	for _, r := range line {
+		size := len(string(r))
+		if octets+size > maxLineOctets {
+			buf.WriteString("\r\n ")
+			// the leading space of a continuation line counts toward its length
+			octets = 1
+		}
+		buf.WriteRune(r)
+		octets += size
+	}
+	buf.WriteString("\r\n")
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"html/template"
+	"io"
 	"sync"
 
 	"gopkg.in/gomail.v2"
@@ -15,7 +16,13 @@ import (
 )
 
 type IMailer interface {
-	Send(recipientEmail, subject, templateName string, data map[string]any) error
+	Send(recipientEmail, subject, templateName string, data map[string]any, attachments ...Attachment) error
+}
+
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
 }
 
 type mailer struct {
@@ -53,7 +60,8 @@ func NewMailDialer() IMailer {
 	return instance
 }
 
-func (m *mailer) Send(recipientEmail, subject, templateName string, data map[string]any) error {
+func (m *mailer) Send(recipientEmail, subject, templateName string, data map[string]any,
+	attachments ...Attachment) error {
 	var tmplOutput bytes.Buffer
 
 	err := m.templates.ExecuteTemplate(&tmplOutput, templateName, data)
@@ -67,5 +75,15 @@ func (m *mailer) Send(recipientEmail, subject, templateName string, data map[str
 	mail.SetHeader("Subject", subject)
 	mail.SetBody("text/html", tmplOutput.String())
 
+	for _, attachment := range attachments {
+		content := attachment.Content
+		mail.Attach(attachment.Filename,
+			gomail.SetHeader(map[string][]string{"Content-Type": {attachment.ContentType}}),
+			gomail.SetCopyFunc(func(w io.Writer) error {
+				_, err := w.Write(content)
+				return err
+			}))
+	}
+
 	return m.dialer.DialAndSend(mail)
 }
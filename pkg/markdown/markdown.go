@@ -0,0 +1,31 @@
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+var (
+	renderer = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+	policy = func() *bluemonday.Policy {
+		p := bluemonday.UGCPolicy()
+		// keep the language hint of fenced code blocks for syntax highlighting on the client
+		p.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+-]+$`)).OnElements("code")
+		return p
+	}()
+)
+
+// ToSafeHTML renders GitHub Flavored Markdown to HTML, stripping anything that could run scripts in the browser
+func ToSafeHTML(source string) (string, error) {
+	var buf bytes.Buffer
+	if err := renderer.Convert([]byte(source), &buf); err != nil {
+		return "", err
+	}
+
+	return policy.Sanitize(buf.String()), nil
+}
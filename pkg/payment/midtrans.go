@@ -41,6 +41,11 @@ func (p *midtransPayment) CreateTransaction(id string, amount int) (string, erro
 	return resp.Token, nil
 }
 
+// GetRedirectURL returns the Snap payment page of the transaction
+func (p *midtransPayment) GetRedirectURL(token string) string {
+	return midtrans.Environment.SnapURL() + "/snap/v4/redirection/" + token
+}
+
 func (p *midtransPayment) ProcessNotification(
 	notificationPayload map[string]interface{}) (enum.PaymentStatus, string, error) {
 	// 3. Get order-id from payload
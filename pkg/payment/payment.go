@@ -4,5 +4,6 @@ import "github.com/nathakusuma/elevateu-backend/domain/enum"
 
 type IPaymentGateway interface {
 	CreateTransaction(id string, amount int) (string, error)
+	GetRedirectURL(token string) string
 	ProcessNotification(notificationPayload map[string]interface{}) (enum.PaymentStatus, string, error)
 }
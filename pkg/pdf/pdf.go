@@ -0,0 +1,223 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+type IPDF interface {
+	GenerateReceipt(receipt Receipt) ([]byte, error)
+	GenerateTranscript(transcript Transcript) ([]byte, error)
+}
+
+type Receipt struct {
+	InvoiceNumber string
+	IssuedAt      time.Time
+	CustomerName  string
+	CustomerEmail string
+	PaymentID     string
+	Item          string
+	Detail        string
+	Method        string
+	Subtotal      int
+	DiscountLabel string
+	Discount      int
+	Total         int
+}
+
+type Transcript struct {
+	Title       string
+	MentorName  string
+	StudentName string
+	GeneratedAt time.Time
+	Messages    []TranscriptMessage
+}
+
+type TranscriptMessage struct {
+	SenderName string
+	SentAt     time.Time
+	Text       string
+}
+
+type pdfGenerator struct{}
+
+var (
+	pdfInstance IPDF
+	once        sync.Once
+)
+
+func GetPDF() IPDF {
+	once.Do(func() {
+		pdfInstance = &pdfGenerator{}
+	})
+
+	return pdfInstance
+}
+
+func (p *pdfGenerator) GenerateReceipt(receipt Receipt) ([]byte, error) {
+	doc := fpdf.New("P", "mm", "A4", "")
+	doc.SetMargins(20, 20, 20)
+	doc.AddPage()
+
+	// Header
+	doc.SetFont("Helvetica", "B", 22)
+	doc.SetTextColor(0, 123, 255)
+	doc.CellFormat(0, 12, "ElevateU", "", 1, "L", false, 0, "")
+	doc.SetFont("Helvetica", "", 10)
+	doc.SetTextColor(102, 102, 102)
+	doc.CellFormat(0, 5, "Jalan Veteran No. 12-16, Malang, 65145", "", 1, "L", false, 0, "")
+	doc.Ln(8)
+
+	doc.SetFont("Helvetica", "B", 16)
+	doc.SetTextColor(51, 51, 51)
+	doc.CellFormat(0, 10, "PAYMENT RECEIPT", "", 1, "L", false, 0, "")
+	doc.Ln(2)
+
+	// Receipt information
+	doc.SetFont("Helvetica", "", 10)
+	infoRows := [][2]string{
+		{"Invoice Number", receipt.InvoiceNumber},
+		{"Date", receipt.IssuedAt.Format("02 January 2006 15:04 MST")},
+		{"Payment ID", receipt.PaymentID},
+		{"Billed To", fmt.Sprintf("%s (%s)", receipt.CustomerName, receipt.CustomerEmail)},
+		{"Payment Method", receipt.Method},
+	}
+	for _, row := range infoRows {
+		doc.SetFont("Helvetica", "B", 10)
+		doc.CellFormat(40, 7, row[0], "", 0, "L", false, 0, "")
+		doc.SetFont("Helvetica", "", 10)
+		doc.CellFormat(0, 7, row[1], "", 1, "L", false, 0, "")
+	}
+	doc.Ln(6)
+
+	// Item table
+	doc.SetFillColor(248, 249, 250)
+	doc.SetFont("Helvetica", "B", 10)
+	doc.CellFormat(120, 9, "Item", "B", 0, "L", true, 0, "")
+	doc.CellFormat(0, 9, "Amount", "B", 1, "R", true, 0, "")
+
+	doc.SetFont("Helvetica", "", 10)
+	doc.CellFormat(120, 8, receipt.Item, "", 0, "L", false, 0, "")
+	doc.CellFormat(0, 8, FormatRupiah(receipt.Subtotal), "", 1, "R", false, 0, "")
+	if receipt.Detail != "" {
+		doc.SetTextColor(102, 102, 102)
+		doc.CellFormat(120, 6, receipt.Detail, "", 1, "L", false, 0, "")
+		doc.SetTextColor(51, 51, 51)
+	}
+
+	if receipt.Discount > 0 {
+		doc.CellFormat(120, 8, receipt.DiscountLabel, "", 0, "L", false, 0, "")
+		doc.CellFormat(0, 8, "-"+FormatRupiah(receipt.Discount), "", 1, "R", false, 0, "")
+	}
+
+	doc.SetFont("Helvetica", "B", 11)
+	doc.CellFormat(120, 10, "Total Paid", "T", 0, "L", false, 0, "")
+	doc.CellFormat(0, 10, FormatRupiah(receipt.Total), "T", 1, "R", false, 0, "")
+	doc.Ln(12)
+
+	// Footer
+	doc.SetFont("Helvetica", "I", 9)
+	doc.SetTextColor(102, 102, 102)
+	doc.MultiCell(0, 5, "This receipt is generated automatically and is valid without a signature. "+
+		"Having trouble? Contact our support team at support@elevateu.nathakusuma.com", "", "L", false)
+
+	var buf bytes.Buffer
+	if err := doc.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to render receipt: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (p *pdfGenerator) GenerateTranscript(transcript Transcript) ([]byte, error) {
+	doc := fpdf.New("P", "mm", "A4", "")
+	doc.SetMargins(20, 20, 20)
+	doc.SetAutoPageBreak(true, 20)
+	doc.AliasNbPages("")
+	doc.SetFooterFunc(func() {
+		doc.SetY(-15)
+		doc.SetFont("Helvetica", "I", 8)
+		doc.SetTextColor(153, 153, 153)
+		doc.CellFormat(0, 10, fmt.Sprintf("Page %d of {nb}", doc.PageNo()), "", 0, "C", false, 0, "")
+	})
+	doc.AddPage()
+
+	// The core fonts only cover cp1252, so convert message text before writing it
+	tr := doc.UnicodeTranslatorFromDescriptor("")
+
+	// Header
+	doc.SetFont("Helvetica", "B", 22)
+	doc.SetTextColor(0, 123, 255)
+	doc.CellFormat(0, 12, "ElevateU", "", 1, "L", false, 0, "")
+	doc.Ln(4)
+
+	doc.SetFont("Helvetica", "B", 16)
+	doc.SetTextColor(51, 51, 51)
+	doc.CellFormat(0, 10, tr(transcript.Title), "", 1, "L", false, 0, "")
+	doc.Ln(2)
+
+	infoRows := [][2]string{
+		{"Mentor", transcript.MentorName},
+		{"Student", transcript.StudentName},
+		{"Generated At", transcript.GeneratedAt.Format("02 January 2006 15:04 MST")},
+	}
+	for _, row := range infoRows {
+		doc.SetFont("Helvetica", "B", 10)
+		doc.CellFormat(35, 7, row[0], "", 0, "L", false, 0, "")
+		doc.SetFont("Helvetica", "", 10)
+		doc.CellFormat(0, 7, tr(row[1]), "", 1, "L", false, 0, "")
+	}
+	doc.Ln(4)
+
+	// Messages
+	if len(transcript.Messages) == 0 {
+		doc.SetFont("Helvetica", "I", 10)
+		doc.SetTextColor(102, 102, 102)
+		doc.CellFormat(0, 7, "No messages in this chat.", "", 1, "L", false, 0, "")
+	}
+
+	for _, message := range transcript.Messages {
+		doc.SetFont("Helvetica", "B", 10)
+		doc.SetTextColor(51, 51, 51)
+		doc.CellFormat(0, 6, tr(message.SenderName), "", 1, "L", false, 0, "")
+		doc.SetFont("Helvetica", "", 8)
+		doc.SetTextColor(102, 102, 102)
+		doc.CellFormat(0, 4, message.SentAt.Format("02 Jan 2006 15:04 MST"), "", 1, "L", false, 0, "")
+		doc.SetFont("Helvetica", "", 10)
+		doc.SetTextColor(51, 51, 51)
+		doc.MultiCell(0, 5, tr(message.Text), "", "L", false)
+		doc.Ln(3)
+	}
+
+	var buf bytes.Buffer
+	if err := doc.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to render transcript: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// FormatRupiah formats amount as Indonesian Rupiah, e.g. 120000 -> Rp120.000
+func FormatRupiah(amount int) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.Itoa(amount)
+	var out []byte
+	for i := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			out = append(out, '.')
+		}
+		out = append(out, digits[i])
+	}
+
+	return sign + "Rp" + string(out)
+}
@@ -0,0 +1,10 @@
+package timeutil
+
+import "time"
+
+// LocationName is the IANA name of the platform timezone, for use in queries
+const LocationName = "Asia/Jakarta"
+
+// Location is the timezone of the platform. Mentor schedules, emails and reports use it.
+// Asia/Jakarta has no daylight saving time, so a fixed offset matches it without the tz database.
+var Location = time.FixedZone("WIB", 7*60*60)
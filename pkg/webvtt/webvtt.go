@@ -0,0 +1,187 @@
+package webvtt
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Cue is a caption of a WebVTT track
+type Cue struct {
+	Start time.Duration
+	End   time.Duration
+	Text  string
+}
+
+var (
+	timestampPattern = regexp.MustCompile(`^(?:(\d{2,}):)?([0-5]\d):([0-5]\d)\.(\d{3})$`)
+	tagPattern       = regexp.MustCompile(`<[^>]*>`)
+)
+
+// Parse validates the WebVTT track and returns its cues. The errors tell which line is invalid,
+// so they can be shown to the uploader as is.
+func Parse(data []byte) ([]Cue, error) {
+	if !utf8.Valid(data) {
+		return nil, fmt.Errorf("track is not UTF-8 encoded")
+	}
+
+	text := strings.TrimPrefix(string(data), "\uFEFF")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	lines := strings.Split(text, "\n")
+
+	if !isHeader(lines[0]) {
+		return nil, fmt.Errorf("line 1: track must start with WEBVTT")
+	}
+
+	// skip the rest of the header
+	i := 1
+	for i < len(lines) && lines[i] != "" {
+		if strings.Contains(lines[i], "-->") {
+			return nil, fmt.Errorf("line %d: header must be followed by a blank line", i+1)
+		}
+		i++
+	}
+
+	var cues []Cue
+	for i < len(lines) {
+		if lines[i] == "" {
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(lines) && lines[i] != "" {
+			i++
+		}
+		block := lines[start:i]
+
+		if isBlockKeyword(block[0], "NOTE") {
+			continue
+		}
+
+		if isBlockKeyword(block[0], "STYLE") || isBlockKeyword(block[0], "REGION") {
+			if len(cues) > 0 {
+				return nil, fmt.Errorf("line %d: %s blocks must come before the cues", start+1,
+					strings.Fields(block[0])[0])
+			}
+			continue
+		}
+
+		cue, err := parseCue(block, start+1)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(cues) > 0 && cue.Start < cues[len(cues)-1].Start {
+			return nil, fmt.Errorf("line %d: cues must be ordered by start time", start+1)
+		}
+
+		cues = append(cues, cue)
+	}
+
+	if len(cues) == 0 {
+		return nil, fmt.Errorf("track has no cues")
+	}
+
+	return cues, nil
+}
+
+// Transcript joins the text of the cues without the markup. Lines repeated by consecutive cues,
+// as roll-up captions do, are only kept once.
+func Transcript(cues []Cue) string {
+	var lines []string
+	for _, cue := range cues {
+		for _, line := range strings.Split(cue.Text, "\n") {
+			line = strings.TrimSpace(html.UnescapeString(tagPattern.ReplaceAllString(line, "")))
+			if line == "" || (len(lines) > 0 && lines[len(lines)-1] == line) {
+				continue
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func isHeader(line string) bool {
+	rest, ok := strings.CutPrefix(line, "WEBVTT")
+	return ok && (rest == "" || rest[0] == ' ' || rest[0] == '\t')
+}
+
+func isBlockKeyword(line, keyword string) bool {
+	rest, ok := strings.CutPrefix(line, keyword)
+	return ok && !strings.Contains(line, "-->") && (rest == "" || rest[0] == ' ' || rest[0] == '\t')
+}
+
+// parseCue parses a block of an optional identifier, the timings and the payload.
+// lineNumber is the line number of the first line of the block.
+func parseCue(block []string, lineNumber int) (Cue, error) {
+	if !strings.Contains(block[0], "-->") {
+		if len(block) < 2 || !strings.Contains(block[1], "-->") {
+			return Cue{}, fmt.Errorf("line %d: cue has no timings", lineNumber)
+		}
+		block = block[1:]
+		lineNumber++
+	}
+
+	timings := strings.SplitN(block[0], "-->", 2)
+	startText := strings.TrimSpace(timings[0])
+	// cue settings may follow the end timestamp
+	endFields := strings.Fields(timings[1])
+	if len(endFields) == 0 {
+		return Cue{}, fmt.Errorf("line %d: cue has no end timestamp", lineNumber)
+	}
+
+	start, err := parseTimestamp(startText)
+	if err != nil {
+		return Cue{}, fmt.Errorf("line %d: %w", lineNumber, err)
+	}
+
+	end, err := parseTimestamp(endFields[0])
+	if err != nil {
+		return Cue{}, fmt.Errorf("line %d: %w", lineNumber, err)
+	}
+
+	if end <= start {
+		return Cue{}, fmt.Errorf("line %d: cue must end after it starts", lineNumber)
+	}
+
+	for j, line := range block[1:] {
+		if strings.Contains(line, "-->") {
+			return Cue{}, fmt.Errorf("line %d: cue text must not contain -->", lineNumber+j+1)
+		}
+	}
+
+	return Cue{
+		Start: start,
+		End:   end,
+		Text:  strings.Join(block[1:], "\n"),
+	}, nil
+}
+
+func parseTimestamp(text string) (time.Duration, error) {
+	match := timestampPattern.FindStringSubmatch(text)
+	if match == nil {
+		return 0, fmt.Errorf("invalid timestamp %q", text)
+	}
+
+	var hours int
+	if match[1] != "" {
+		var err error
+		hours, err = strconv.Atoi(match[1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid timestamp %q", text)
+		}
+	}
+	minutes, _ := strconv.Atoi(match[2])
+	seconds, _ := strconv.Atoi(match[3])
+	millis, _ := strconv.Atoi(match[4])
+
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second + time.Duration(millis)*time.Millisecond, nil
+}
@@ -0,0 +1,236 @@
+package webvtt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Parse(t *testing.T) {
+	tests := []struct {
+		name    string
+		track   string
+		want    []Cue
+		wantErr string
+	}{
+		{
+			name:  "minimal track",
+			track: "WEBVTT\n\n00:01.000 --> 00:04.000\nHello\n",
+			want: []Cue{
+				{Start: time.Second, End: 4 * time.Second, Text: "Hello"},
+			},
+		},
+		{
+			name:  "header with a description and metadata",
+			track: "WEBVTT - Lesson 1\nKind: captions\nLanguage: en\n\n00:01.000 --> 00:02.000\nHello\n",
+			want: []Cue{
+				{Start: time.Second, End: 2 * time.Second, Text: "Hello"},
+			},
+		},
+		{
+			name:  "byte order mark and CRLF line endings",
+			track: "\uFEFFWEBVTT\r\n\r\n00:01.000 --> 00:02.000\r\nHello\r\n",
+			want: []Cue{
+				{Start: time.Second, End: 2 * time.Second, Text: "Hello"},
+			},
+		},
+		{
+			name: "hours, identifiers and cue settings",
+			track: "WEBVTT\n\nintro\n01:02:03.004 --> 01:02:05.500 align:start position:10%\nHello\n\n" +
+				"2\n01:02:06.000 --> 01:02:07.000\nWorld\n",
+			want: []Cue{
+				{
+					Start: time.Hour + 2*time.Minute + 3*time.Second + 4*time.Millisecond,
+					End:   time.Hour + 2*time.Minute + 5*time.Second + 500*time.Millisecond,
+					Text:  "Hello",
+				},
+				{
+					Start: time.Hour + 2*time.Minute + 6*time.Second,
+					End:   time.Hour + 2*time.Minute + 7*time.Second,
+					Text:  "World",
+				},
+			},
+		},
+		{
+			name:  "multi-line cue",
+			track: "WEBVTT\n\n00:01.000 --> 00:04.000\n<v Teacher>First line\nSecond line\n\n\n00:05.000 --> 00:06.000\nNext\n",
+			want: []Cue{
+				{Start: time.Second, End: 4 * time.Second, Text: "<v Teacher>First line\nSecond line"},
+				{Start: 5 * time.Second, End: 6 * time.Second, Text: "Next"},
+			},
+		},
+		{
+			name: "NOTE, STYLE and REGION blocks",
+			track: "WEBVTT\n\nSTYLE\n::cue { color: yellow }\n\nREGION\nid:fred\n\nNOTE a comment\nover two lines\n\n" +
+				"00:01.000 --> 00:02.000\nHello\n\nNOTE\nbetween the cues\n\n00:03.000 --> 00:04.000\nWorld\n",
+			want: []Cue{
+				{Start: time.Second, End: 2 * time.Second, Text: "Hello"},
+				{Start: 3 * time.Second, End: 4 * time.Second, Text: "World"},
+			},
+		},
+		{
+			name:  "cue without text",
+			track: "WEBVTT\n\n00:01.000 --> 00:02.000\n",
+			want: []Cue{
+				{Start: time.Second, End: 2 * time.Second, Text: ""},
+			},
+		},
+		{
+			name:    "missing header",
+			track:   "00:01.000 --> 00:02.000\nHello\n",
+			wantErr: "line 1: track must start with WEBVTT",
+		},
+		{
+			name:    "header without a separator",
+			track:   "WEBVTTX\n\n00:01.000 --> 00:02.000\nHello\n",
+			wantErr: "line 1: track must start with WEBVTT",
+		},
+		{
+			name:    "header not followed by a blank line",
+			track:   "WEBVTT\n00:01.000 --> 00:02.000\nHello\n",
+			wantErr: "line 2: header must be followed by a blank line",
+		},
+		{
+			name:    "not UTF-8",
+			track:   "WEBVTT\n\n00:01.000 --> 00:02.000\n\xff\xfe\n",
+			wantErr: "track is not UTF-8 encoded",
+		},
+		{
+			name:    "no cues",
+			track:   "WEBVTT\n\nNOTE only a comment\n",
+			wantErr: "track has no cues",
+		},
+		{
+			name:    "STYLE after a cue",
+			track:   "WEBVTT\n\n00:01.000 --> 00:02.000\nHello\n\nSTYLE\n::cue { color: red }\n",
+			wantErr: "line 6: STYLE blocks must come before the cues",
+		},
+		{
+			name:    "block without timings",
+			track:   "WEBVTT\n\nHello\nWorld\n",
+			wantErr: "line 3: cue has no timings",
+		},
+		{
+			name:    "missing end timestamp",
+			track:   "WEBVTT\n\n00:01.000 -->\nHello\n",
+			wantErr: "line 3: cue has no end timestamp",
+		},
+		{
+			name:    "timestamp without milliseconds",
+			track:   "WEBVTT\n\n00:01 --> 00:02.000\nHello\n",
+			wantErr: `line 3: invalid timestamp "00:01"`,
+		},
+		{
+			name:    "timestamp with a comma",
+			track:   "WEBVTT\n\n00:00:01,000 --> 00:00:02,000\nHello\n",
+			wantErr: `line 3: invalid timestamp "00:00:01,000"`,
+		},
+		{
+			name:    "minutes out of range",
+			track:   "WEBVTT\n\n00:01.000 --> 00:60:00.000\nHello\n",
+			wantErr: `line 3: invalid timestamp "00:60:00.000"`,
+		},
+		{
+			name:    "single digit hours",
+			track:   "WEBVTT\n\n1:00:01.000 --> 1:00:02.000\nHello\n",
+			wantErr: `line 3: invalid timestamp "1:00:01.000"`,
+		},
+		{
+			name:    "invalid timestamp after an identifier",
+			track:   "WEBVTT\n\nintro\n00:01.000 --> 00:02.00\nHello\n",
+			wantErr: `line 4: invalid timestamp "00:02.00"`,
+		},
+		{
+			name:    "cue ending before it starts",
+			track:   "WEBVTT\n\n00:02.000 --> 00:01.000\nHello\n",
+			wantErr: "line 3: cue must end after it starts",
+		},
+		{
+			name:    "arrow in the cue text",
+			track:   "WEBVTT\n\n00:01.000 --> 00:02.000\nHello\nA --> B\n",
+			wantErr: "line 5: cue text must not contain -->",
+		},
+		{
+			name:    "cues out of order",
+			track:   "WEBVTT\n\n00:05.000 --> 00:06.000\nLater\n\n00:01.000 --> 00:02.000\nEarlier\n",
+			wantErr: "line 6: cues must be ordered by start time",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cues, err := Parse([]byte(tt.track))
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, cues)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cues)
+		})
+	}
+}
+
+func Test_Transcript(t *testing.T) {
+	tests := []struct {
+		name string
+		cues []Cue
+		want string
+	}{
+		{
+			name: "no cues",
+			want: "",
+		},
+		{
+			name: "lines of every cue",
+			cues: []Cue{
+				{Text: "First line\nSecond line"},
+				{Text: "Third line"},
+			},
+			want: "First line\nSecond line\nThird line",
+		},
+		{
+			name: "markup and entities",
+			cues: []Cue{
+				{Text: "<v Teacher>Use <b>Go</b> &amp; <i>SQL</i></v>"},
+				{Text: "<00:00:01.000>1 &lt; 2"},
+			},
+			want: "Use Go & SQL\n1 < 2",
+		},
+		{
+			name: "lines repeated by roll-up captions",
+			cues: []Cue{
+				{Text: "one"},
+				{Text: "one\ntwo"},
+				{Text: "two\nthree"},
+			},
+			want: "one\ntwo\nthree",
+		},
+		{
+			name: "lines repeated later are kept",
+			cues: []Cue{
+				{Text: "yes"},
+				{Text: "no"},
+				{Text: "yes"},
+			},
+			want: "yes\nno\nyes",
+		},
+		{
+			name: "blank and markup only lines",
+			cues: []Cue{
+				{Text: "  \n<c.yellow></c>\nText  "},
+				{Text: ""},
+			},
+			want: "Text",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Transcript(tt.cues))
+		})
+	}
+}
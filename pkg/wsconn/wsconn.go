@@ -0,0 +1,61 @@
+package wsconn
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/google/uuid"
+)
+
+const writeTimeout = 10 * time.Second
+
+// Conn wraps a websocket connection so it can be written from multiple goroutines.
+// The underlying connection supports only one concurrent writer.
+type Conn struct {
+	*websocket.Conn
+	ID      uuid.UUID
+	writeMu sync.Mutex
+}
+
+func New(conn *websocket.Conn) *Conn {
+	return &Conn{
+		Conn: conn,
+		ID:   uuid.New(),
+	}
+}
+
+func (c *Conn) WriteJSON(v interface{}) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if err := c.Conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+
+	return c.Conn.WriteJSON(v)
+}
+
+func (c *Conn) WriteMessage(messageType int, data []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if err := c.Conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+
+	return c.Conn.WriteMessage(messageType, data)
+}
+
+// Shutdown starts the closing handshake. The reader of the connection gets the client's close frame,
+// or a timeout error once wait has passed.
+func (c *Conn) Shutdown(reason string, wait time.Duration) {
+	deadline := time.Now().Add(wait)
+	closeMessage := websocket.FormatCloseMessage(websocket.CloseNormalClosure, reason)
+	if err := c.Conn.WriteControl(websocket.CloseMessage, closeMessage, deadline); err != nil {
+		c.Conn.Close()
+		return
+	}
+
+	c.Conn.SetReadDeadline(deadline)
+}
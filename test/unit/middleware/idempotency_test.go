@@ -0,0 +1,292 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/nathakusuma/elevateu-backend/domain/ctxkey"
+	"github.com/nathakusuma/elevateu-backend/domain/errorpkg"
+	"github.com/nathakusuma/elevateu-backend/internal/middleware"
+	inframocks "github.com/nathakusuma/elevateu-backend/test/unit/mocks/infra"
+	_ "github.com/nathakusuma/elevateu-backend/test/unit/setup"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	idempotencyTTL  = 24 * time.Hour
+	idempotencyPath = "/payments/course"
+)
+
+type idempotencyTest struct {
+	app      *fiber.App
+	cache    *inframocks.MockICache
+	userID   uuid.UUID
+	calls    *atomic.Int32
+	handler  func(ctx *fiber.Ctx) error
+	cacheKey string
+}
+
+// setupIdempotencyTest mounts the middleware in front of a handler that counts its calls.
+// The handler responds with 201 unless test.handler is replaced.
+func setupIdempotencyTest(t *testing.T, key string) *idempotencyTest {
+	test := &idempotencyTest{
+		cache:  inframocks.NewMockICache(t),
+		userID: uuid.New(),
+		calls:  &atomic.Int32{},
+	}
+	test.cacheKey = fmt.Sprintf("idempotency:%s:%s", test.userID, key)
+	test.handler = func(ctx *fiber.Ctx) error {
+		return ctx.Status(fiber.StatusCreated).JSON(map[string]any{
+			"payment_token": "token-" + string(ctx.Body()),
+		})
+	}
+
+	// Idempotent only needs the cache
+	midw := middleware.NewMiddleware(nil, test.cache)
+
+	test.app = fiber.New(fiber.Config{
+		ErrorHandler: func(ctx *fiber.Ctx, err error) error {
+			var apiErr *errorpkg.ResponseError
+			if errors.As(err, &apiErr) {
+				return ctx.Status(apiErr.Status).JSON(apiErr)
+			}
+			return ctx.SendStatus(fiber.StatusInternalServerError)
+		},
+	})
+	test.app.Post(idempotencyPath,
+		func(ctx *fiber.Ctx) error {
+			ctx.Locals(ctxkey.UserID, test.userID)
+			return ctx.Next()
+		},
+		midw.Idempotent(idempotencyTTL),
+		func(ctx *fiber.Ctx) error {
+			test.calls.Add(1)
+			return test.handler(ctx)
+		})
+
+	return test
+}
+
+func (test *idempotencyTest) send(t *testing.T, key, body string) (int, string, string) {
+	req := httptest.NewRequest(fiber.MethodPost, idempotencyPath, strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+
+	resp, err := test.app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp.StatusCode, string(respBody), resp.Header.Get("Idempotent-Replayed")
+}
+
+func fingerprint(body string) string {
+	hash := sha256.Sum256([]byte(fiber.MethodPost + " " + idempotencyPath + "\n" + body))
+	return hex.EncodeToString(hash[:])
+}
+
+func (test *idempotencyTest) expectNotStored() {
+	test.cache.EXPECT().
+		Get(mock.Anything, test.cacheKey, mock.Anything).
+		Return(errors.New("not found: redis: nil")).
+		Once()
+}
+
+func (test *idempotencyTest) expectLock(body string, acquired bool) {
+	test.cache.EXPECT().
+		SetNX(mock.Anything, test.cacheKey+":lock", fingerprint(body), 30*time.Second).
+		Return(acquired, nil).
+		Once()
+}
+
+func (test *idempotencyTest) expectUnlock() {
+	test.cache.EXPECT().
+		Del(mock.Anything, test.cacheKey+":lock").
+		Return(nil).
+		Once()
+}
+
+func Test_Middleware_Idempotent(t *testing.T) {
+	key := "3f1a8c2e-checkout"
+	body := `{"course_id":"01949e48-9f6b-796b-9611-3c9025493233"}`
+
+	t.Run("without key", func(t *testing.T) {
+		test := setupIdempotencyTest(t, key)
+
+		status, _, replayed := test.send(t, "", body)
+		assert.Equal(t, fiber.StatusCreated, status)
+		assert.Empty(t, replayed)
+
+		status, _, _ = test.send(t, "", body)
+		assert.Equal(t, fiber.StatusCreated, status)
+		assert.Equal(t, int32(2), test.calls.Load())
+	})
+
+	t.Run("key too long", func(t *testing.T) {
+		test := setupIdempotencyTest(t, key)
+
+		status, _, _ := test.send(t, strings.Repeat("k", 256), body)
+
+		assert.Equal(t, fiber.StatusUnprocessableEntity, status)
+		assert.Equal(t, int32(0), test.calls.Load())
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		test := setupIdempotencyTest(t, key)
+
+		// first request runs the handler and stores its response
+		var stored string
+		test.expectNotStored()
+		test.expectLock(body, true)
+		test.cache.EXPECT().
+			Set(mock.Anything, test.cacheKey, mock.AnythingOfType("string"), idempotencyTTL).
+			Run(func(_ context.Context, _ string, value interface{}, _ time.Duration) {
+				stored = value.(string)
+			}).
+			Return(nil).
+			Once()
+		test.expectUnlock()
+
+		status, firstBody, replayed := test.send(t, key, body)
+		require.Equal(t, fiber.StatusCreated, status)
+		assert.Empty(t, replayed)
+
+		// second request is answered from the cache
+		test.cache.EXPECT().
+			Get(mock.Anything, test.cacheKey, mock.Anything).
+			Run(func(_ context.Context, _ string, val interface{}) {
+				*val.(*string) = stored
+			}).
+			Return(nil).
+			Once()
+
+		status, secondBody, replayed := test.send(t, key, body)
+		assert.Equal(t, fiber.StatusCreated, status)
+		assert.Equal(t, firstBody, secondBody)
+		assert.Equal(t, "true", replayed)
+		assert.Equal(t, int32(1), test.calls.Load())
+	})
+
+	t.Run("same key with another body", func(t *testing.T) {
+		test := setupIdempotencyTest(t, key)
+
+		stored := fmt.Sprintf(`{"fingerprint":%q,"status":201,"content_type":"application/json","body":"e30="}`,
+			fingerprint(body))
+		test.cache.EXPECT().
+			Get(mock.Anything, test.cacheKey, mock.Anything).
+			Run(func(_ context.Context, _ string, val interface{}) {
+				*val.(*string) = stored
+			}).
+			Return(nil).
+			Once()
+
+		status, respBody, replayed := test.send(t, key, `{"course_id":"01949e48-9f6b-796b-9611-000000000000"}`)
+
+		assert.Equal(t, fiber.StatusUnprocessableEntity, status)
+		assert.Contains(t, respBody, "idempotency-key-reused")
+		assert.Empty(t, replayed)
+		assert.Equal(t, int32(0), test.calls.Load())
+	})
+
+	t.Run("concurrent request in flight", func(t *testing.T) {
+		test := setupIdempotencyTest(t, key)
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		test.handler = func(ctx *fiber.Ctx) error {
+			close(started)
+			<-release
+			return ctx.SendStatus(fiber.StatusCreated)
+		}
+
+		test.expectNotStored()
+		test.expectLock(body, true)
+		test.cache.EXPECT().
+			Set(mock.Anything, test.cacheKey, mock.AnythingOfType("string"), idempotencyTTL).
+			Return(nil).
+			Once()
+		test.expectUnlock()
+
+		firstStatus := make(chan int, 1)
+		go func() {
+			status, _, _ := test.send(t, key, body)
+			firstStatus <- status
+		}()
+		<-started
+
+		// the first request holds the lock until its handler returns
+		test.expectNotStored()
+		test.expectLock(body, false)
+
+		status, respBody, _ := test.send(t, key, body)
+		assert.Equal(t, fiber.StatusConflict, status)
+		assert.Contains(t, respBody, "idempotency-key-in-progress")
+
+		close(release)
+		assert.Equal(t, fiber.StatusCreated, <-firstStatus)
+		assert.Equal(t, int32(1), test.calls.Load())
+	})
+
+	t.Run("error response is not stored", func(t *testing.T) {
+		test := setupIdempotencyTest(t, key)
+		test.handler = func(ctx *fiber.Ctx) error {
+			return errorpkg.ErrCourseNotForSale()
+		}
+
+		test.expectNotStored()
+		test.expectLock(body, true)
+		test.expectUnlock()
+
+		status, _, _ := test.send(t, key, body)
+
+		assert.Equal(t, errorpkg.ErrCourseNotForSale().Status, status)
+		test.cache.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("non-2xx response is not stored", func(t *testing.T) {
+		test := setupIdempotencyTest(t, key)
+		test.handler = func(ctx *fiber.Ctx) error {
+			return ctx.SendStatus(fiber.StatusFound)
+		}
+
+		test.expectNotStored()
+		test.expectLock(body, true)
+		test.expectUnlock()
+
+		status, _, _ := test.send(t, key, body)
+
+		assert.Equal(t, fiber.StatusFound, status)
+		test.cache.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("cache failure", func(t *testing.T) {
+		test := setupIdempotencyTest(t, key)
+
+		test.cache.EXPECT().
+			Get(mock.Anything, test.cacheKey, mock.Anything).
+			Return(errors.New("connection refused")).
+			Once()
+
+		status, _, _ := test.send(t, key, body)
+
+		assert.Equal(t, fiber.StatusInternalServerError, status)
+		assert.Equal(t, int32(0), test.calls.Load())
+	})
+}
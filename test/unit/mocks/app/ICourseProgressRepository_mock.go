@@ -0,0 +1,503 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	database "github.com/nathakusuma/elevateu-backend/internal/infra/database"
+
+	entity "github.com/nathakusuma/elevateu-backend/domain/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockICourseProgressRepository is an autogenerated mock type for the ICourseProgressRepository type
+type MockICourseProgressRepository struct {
+	mock.Mock
+}
+
+type MockICourseProgressRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockICourseProgressRepository) EXPECT() *MockICourseProgressRepository_Expecter {
+	return &MockICourseProgressRepository_Expecter{mock: &_m.Mock}
+}
+
+// BatchDecrementCourseProgress provides a mock function with given fields: ctx, txWrapper, courseID, contentID, contentType
+func (_m *MockICourseProgressRepository) BatchDecrementCourseProgress(ctx context.Context, txWrapper database.ITransaction, courseID uuid.UUID, contentID uuid.UUID, contentType string) error {
+	ret := _m.Called(ctx, txWrapper, courseID, contentID, contentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchDecrementCourseProgress")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ITransaction, uuid.UUID, uuid.UUID, string) error); ok {
+		r0 = rf(ctx, txWrapper, courseID, contentID, contentType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockICourseProgressRepository_BatchDecrementCourseProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchDecrementCourseProgress'
+type MockICourseProgressRepository_BatchDecrementCourseProgress_Call struct {
+	*mock.Call
+}
+
+// BatchDecrementCourseProgress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - txWrapper database.ITransaction
+//   - courseID uuid.UUID
+//   - contentID uuid.UUID
+//   - contentType string
+func (_e *MockICourseProgressRepository_Expecter) BatchDecrementCourseProgress(ctx interface{}, txWrapper interface{}, courseID interface{}, contentID interface{}, contentType interface{}) *MockICourseProgressRepository_BatchDecrementCourseProgress_Call {
+	return &MockICourseProgressRepository_BatchDecrementCourseProgress_Call{Call: _e.mock.On("BatchDecrementCourseProgress", ctx, txWrapper, courseID, contentID, contentType)}
+}
+
+func (_c *MockICourseProgressRepository_BatchDecrementCourseProgress_Call) Run(run func(ctx context.Context, txWrapper database.ITransaction, courseID uuid.UUID, contentID uuid.UUID, contentType string)) *MockICourseProgressRepository_BatchDecrementCourseProgress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ITransaction), args[2].(uuid.UUID), args[3].(uuid.UUID), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockICourseProgressRepository_BatchDecrementCourseProgress_Call) Return(_a0 error) *MockICourseProgressRepository_BatchDecrementCourseProgress_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockICourseProgressRepository_BatchDecrementCourseProgress_Call) RunAndReturn(run func(context.Context, database.ITransaction, uuid.UUID, uuid.UUID, string) error) *MockICourseProgressRepository_BatchDecrementCourseProgress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetContentCourseID provides a mock function with given fields: ctx, contentID, contentType
+func (_m *MockICourseProgressRepository) GetContentCourseID(ctx context.Context, contentID uuid.UUID, contentType string) (uuid.UUID, error) {
+	ret := _m.Called(ctx, contentID, contentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContentCourseID")
+	}
+
+	var r0 uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) (uuid.UUID, error)); ok {
+		return rf(ctx, contentID, contentType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, string) uuid.UUID); ok {
+		r0 = rf(ctx, contentID, contentType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, string) error); ok {
+		r1 = rf(ctx, contentID, contentType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockICourseProgressRepository_GetContentCourseID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetContentCourseID'
+type MockICourseProgressRepository_GetContentCourseID_Call struct {
+	*mock.Call
+}
+
+// GetContentCourseID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - contentID uuid.UUID
+//   - contentType string
+func (_e *MockICourseProgressRepository_Expecter) GetContentCourseID(ctx interface{}, contentID interface{}, contentType interface{}) *MockICourseProgressRepository_GetContentCourseID_Call {
+	return &MockICourseProgressRepository_GetContentCourseID_Call{Call: _e.mock.On("GetContentCourseID", ctx, contentID, contentType)}
+}
+
+func (_c *MockICourseProgressRepository_GetContentCourseID_Call) Run(run func(ctx context.Context, contentID uuid.UUID, contentType string)) *MockICourseProgressRepository_GetContentCourseID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockICourseProgressRepository_GetContentCourseID_Call) Return(_a0 uuid.UUID, _a1 error) *MockICourseProgressRepository_GetContentCourseID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockICourseProgressRepository_GetContentCourseID_Call) RunAndReturn(run func(context.Context, uuid.UUID, string) (uuid.UUID, error)) *MockICourseProgressRepository_GetContentCourseID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSectionProgresses provides a mock function with given fields: ctx, courseID, studentID
+func (_m *MockICourseProgressRepository) GetSectionProgresses(ctx context.Context, courseID uuid.UUID, studentID uuid.UUID) ([]*entity.CourseSectionProgress, error) {
+	ret := _m.Called(ctx, courseID, studentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSectionProgresses")
+	}
+
+	var r0 []*entity.CourseSectionProgress
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) ([]*entity.CourseSectionProgress, error)); ok {
+		return rf(ctx, courseID, studentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) []*entity.CourseSectionProgress); ok {
+		r0 = rf(ctx, courseID, studentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.CourseSectionProgress)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, courseID, studentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockICourseProgressRepository_GetSectionProgresses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSectionProgresses'
+type MockICourseProgressRepository_GetSectionProgresses_Call struct {
+	*mock.Call
+}
+
+// GetSectionProgresses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - courseID uuid.UUID
+//   - studentID uuid.UUID
+func (_e *MockICourseProgressRepository_Expecter) GetSectionProgresses(ctx interface{}, courseID interface{}, studentID interface{}) *MockICourseProgressRepository_GetSectionProgresses_Call {
+	return &MockICourseProgressRepository_GetSectionProgresses_Call{Call: _e.mock.On("GetSectionProgresses", ctx, courseID, studentID)}
+}
+
+func (_c *MockICourseProgressRepository_GetSectionProgresses_Call) Run(run func(ctx context.Context, courseID uuid.UUID, studentID uuid.UUID)) *MockICourseProgressRepository_GetSectionProgresses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockICourseProgressRepository_GetSectionProgresses_Call) Return(_a0 []*entity.CourseSectionProgress, _a1 error) *MockICourseProgressRepository_GetSectionProgresses_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockICourseProgressRepository_GetSectionProgresses_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) ([]*entity.CourseSectionProgress, error)) *MockICourseProgressRepository_GetSectionProgresses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrementCourseProgress provides a mock function with given fields: ctx, txWrapper, courseID, studentID
+func (_m *MockICourseProgressRepository) IncrementCourseProgress(ctx context.Context, txWrapper database.ITransaction, courseID uuid.UUID, studentID uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, txWrapper, courseID, studentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrementCourseProgress")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ITransaction, uuid.UUID, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, txWrapper, courseID, studentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ITransaction, uuid.UUID, uuid.UUID) bool); ok {
+		r0 = rf(ctx, txWrapper, courseID, studentID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ITransaction, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, txWrapper, courseID, studentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockICourseProgressRepository_IncrementCourseProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementCourseProgress'
+type MockICourseProgressRepository_IncrementCourseProgress_Call struct {
+	*mock.Call
+}
+
+// IncrementCourseProgress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - txWrapper database.ITransaction
+//   - courseID uuid.UUID
+//   - studentID uuid.UUID
+func (_e *MockICourseProgressRepository_Expecter) IncrementCourseProgress(ctx interface{}, txWrapper interface{}, courseID interface{}, studentID interface{}) *MockICourseProgressRepository_IncrementCourseProgress_Call {
+	return &MockICourseProgressRepository_IncrementCourseProgress_Call{Call: _e.mock.On("IncrementCourseProgress", ctx, txWrapper, courseID, studentID)}
+}
+
+func (_c *MockICourseProgressRepository_IncrementCourseProgress_Call) Run(run func(ctx context.Context, txWrapper database.ITransaction, courseID uuid.UUID, studentID uuid.UUID)) *MockICourseProgressRepository_IncrementCourseProgress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ITransaction), args[2].(uuid.UUID), args[3].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockICourseProgressRepository_IncrementCourseProgress_Call) Return(_a0 bool, _a1 error) *MockICourseProgressRepository_IncrementCourseProgress_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockICourseProgressRepository_IncrementCourseProgress_Call) RunAndReturn(run func(context.Context, database.ITransaction, uuid.UUID, uuid.UUID) (bool, error)) *MockICourseProgressRepository_IncrementCourseProgress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateArticleProgress provides a mock function with given fields: ctx, txWrapper, progress
+func (_m *MockICourseProgressRepository) UpdateArticleProgress(ctx context.Context, txWrapper database.ITransaction, progress entity.CourseArticleProgress) (bool, error) {
+	ret := _m.Called(ctx, txWrapper, progress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateArticleProgress")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ITransaction, entity.CourseArticleProgress) (bool, error)); ok {
+		return rf(ctx, txWrapper, progress)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ITransaction, entity.CourseArticleProgress) bool); ok {
+		r0 = rf(ctx, txWrapper, progress)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ITransaction, entity.CourseArticleProgress) error); ok {
+		r1 = rf(ctx, txWrapper, progress)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockICourseProgressRepository_UpdateArticleProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateArticleProgress'
+type MockICourseProgressRepository_UpdateArticleProgress_Call struct {
+	*mock.Call
+}
+
+// UpdateArticleProgress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - txWrapper database.ITransaction
+//   - progress entity.CourseArticleProgress
+func (_e *MockICourseProgressRepository_Expecter) UpdateArticleProgress(ctx interface{}, txWrapper interface{}, progress interface{}) *MockICourseProgressRepository_UpdateArticleProgress_Call {
+	return &MockICourseProgressRepository_UpdateArticleProgress_Call{Call: _e.mock.On("UpdateArticleProgress", ctx, txWrapper, progress)}
+}
+
+func (_c *MockICourseProgressRepository_UpdateArticleProgress_Call) Run(run func(ctx context.Context, txWrapper database.ITransaction, progress entity.CourseArticleProgress)) *MockICourseProgressRepository_UpdateArticleProgress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ITransaction), args[2].(entity.CourseArticleProgress))
+	})
+	return _c
+}
+
+func (_c *MockICourseProgressRepository_UpdateArticleProgress_Call) Return(_a0 bool, _a1 error) *MockICourseProgressRepository_UpdateArticleProgress_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockICourseProgressRepository_UpdateArticleProgress_Call) RunAndReturn(run func(context.Context, database.ITransaction, entity.CourseArticleProgress) (bool, error)) *MockICourseProgressRepository_UpdateArticleProgress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMaterialProgress provides a mock function with given fields: ctx, txWrapper, progress
+func (_m *MockICourseProgressRepository) UpdateMaterialProgress(ctx context.Context, txWrapper database.ITransaction, progress entity.CourseMaterialProgress) (bool, error) {
+	ret := _m.Called(ctx, txWrapper, progress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMaterialProgress")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ITransaction, entity.CourseMaterialProgress) (bool, error)); ok {
+		return rf(ctx, txWrapper, progress)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ITransaction, entity.CourseMaterialProgress) bool); ok {
+		r0 = rf(ctx, txWrapper, progress)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ITransaction, entity.CourseMaterialProgress) error); ok {
+		r1 = rf(ctx, txWrapper, progress)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockICourseProgressRepository_UpdateMaterialProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMaterialProgress'
+type MockICourseProgressRepository_UpdateMaterialProgress_Call struct {
+	*mock.Call
+}
+
+// UpdateMaterialProgress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - txWrapper database.ITransaction
+//   - progress entity.CourseMaterialProgress
+func (_e *MockICourseProgressRepository_Expecter) UpdateMaterialProgress(ctx interface{}, txWrapper interface{}, progress interface{}) *MockICourseProgressRepository_UpdateMaterialProgress_Call {
+	return &MockICourseProgressRepository_UpdateMaterialProgress_Call{Call: _e.mock.On("UpdateMaterialProgress", ctx, txWrapper, progress)}
+}
+
+func (_c *MockICourseProgressRepository_UpdateMaterialProgress_Call) Run(run func(ctx context.Context, txWrapper database.ITransaction, progress entity.CourseMaterialProgress)) *MockICourseProgressRepository_UpdateMaterialProgress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ITransaction), args[2].(entity.CourseMaterialProgress))
+	})
+	return _c
+}
+
+func (_c *MockICourseProgressRepository_UpdateMaterialProgress_Call) Return(_a0 bool, _a1 error) *MockICourseProgressRepository_UpdateMaterialProgress_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockICourseProgressRepository_UpdateMaterialProgress_Call) RunAndReturn(run func(context.Context, database.ITransaction, entity.CourseMaterialProgress) (bool, error)) *MockICourseProgressRepository_UpdateMaterialProgress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateQuizProgress provides a mock function with given fields: ctx, txWrapper, progress
+func (_m *MockICourseProgressRepository) UpdateQuizProgress(ctx context.Context, txWrapper database.ITransaction, progress entity.CourseQuizProgress) (bool, error) {
+	ret := _m.Called(ctx, txWrapper, progress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateQuizProgress")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ITransaction, entity.CourseQuizProgress) (bool, error)); ok {
+		return rf(ctx, txWrapper, progress)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ITransaction, entity.CourseQuizProgress) bool); ok {
+		r0 = rf(ctx, txWrapper, progress)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ITransaction, entity.CourseQuizProgress) error); ok {
+		r1 = rf(ctx, txWrapper, progress)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockICourseProgressRepository_UpdateQuizProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateQuizProgress'
+type MockICourseProgressRepository_UpdateQuizProgress_Call struct {
+	*mock.Call
+}
+
+// UpdateQuizProgress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - txWrapper database.ITransaction
+//   - progress entity.CourseQuizProgress
+func (_e *MockICourseProgressRepository_Expecter) UpdateQuizProgress(ctx interface{}, txWrapper interface{}, progress interface{}) *MockICourseProgressRepository_UpdateQuizProgress_Call {
+	return &MockICourseProgressRepository_UpdateQuizProgress_Call{Call: _e.mock.On("UpdateQuizProgress", ctx, txWrapper, progress)}
+}
+
+func (_c *MockICourseProgressRepository_UpdateQuizProgress_Call) Run(run func(ctx context.Context, txWrapper database.ITransaction, progress entity.CourseQuizProgress)) *MockICourseProgressRepository_UpdateQuizProgress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ITransaction), args[2].(entity.CourseQuizProgress))
+	})
+	return _c
+}
+
+func (_c *MockICourseProgressRepository_UpdateQuizProgress_Call) Return(_a0 bool, _a1 error) *MockICourseProgressRepository_UpdateQuizProgress_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockICourseProgressRepository_UpdateQuizProgress_Call) RunAndReturn(run func(context.Context, database.ITransaction, entity.CourseQuizProgress) (bool, error)) *MockICourseProgressRepository_UpdateQuizProgress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateVideoProgress provides a mock function with given fields: ctx, txWrapper, progress
+func (_m *MockICourseProgressRepository) UpdateVideoProgress(ctx context.Context, txWrapper database.ITransaction, progress entity.CourseVideoProgress) (bool, error) {
+	ret := _m.Called(ctx, txWrapper, progress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateVideoProgress")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ITransaction, entity.CourseVideoProgress) (bool, error)); ok {
+		return rf(ctx, txWrapper, progress)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ITransaction, entity.CourseVideoProgress) bool); ok {
+		r0 = rf(ctx, txWrapper, progress)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ITransaction, entity.CourseVideoProgress) error); ok {
+		r1 = rf(ctx, txWrapper, progress)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockICourseProgressRepository_UpdateVideoProgress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateVideoProgress'
+type MockICourseProgressRepository_UpdateVideoProgress_Call struct {
+	*mock.Call
+}
+
+// UpdateVideoProgress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - txWrapper database.ITransaction
+//   - progress entity.CourseVideoProgress
+func (_e *MockICourseProgressRepository_Expecter) UpdateVideoProgress(ctx interface{}, txWrapper interface{}, progress interface{}) *MockICourseProgressRepository_UpdateVideoProgress_Call {
+	return &MockICourseProgressRepository_UpdateVideoProgress_Call{Call: _e.mock.On("UpdateVideoProgress", ctx, txWrapper, progress)}
+}
+
+func (_c *MockICourseProgressRepository_UpdateVideoProgress_Call) Run(run func(ctx context.Context, txWrapper database.ITransaction, progress entity.CourseVideoProgress)) *MockICourseProgressRepository_UpdateVideoProgress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ITransaction), args[2].(entity.CourseVideoProgress))
+	})
+	return _c
+}
+
+func (_c *MockICourseProgressRepository_UpdateVideoProgress_Call) Return(_a0 bool, _a1 error) *MockICourseProgressRepository_UpdateVideoProgress_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockICourseProgressRepository_UpdateVideoProgress_Call) RunAndReturn(run func(context.Context, database.ITransaction, entity.CourseVideoProgress) (bool, error)) *MockICourseProgressRepository_UpdateVideoProgress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockICourseProgressRepository creates a new instance of MockICourseProgressRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockICourseProgressRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockICourseProgressRepository {
+	mock := &MockICourseProgressRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,519 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	database "github.com/nathakusuma/elevateu-backend/internal/infra/database"
+
+	dto "github.com/nathakusuma/elevateu-backend/domain/dto"
+
+	entity "github.com/nathakusuma/elevateu-backend/domain/entity"
+
+	mock "github.com/stretchr/testify/mock"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockICourseQuizRepository is an autogenerated mock type for the ICourseQuizRepository type
+type MockICourseQuizRepository struct {
+	mock.Mock
+}
+
+type MockICourseQuizRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockICourseQuizRepository) EXPECT() *MockICourseQuizRepository_Expecter {
+	return &MockICourseQuizRepository_Expecter{mock: &_m.Mock}
+}
+
+// CountAttempts provides a mock function with given fields: ctx, txWrapper, quizID, studentID
+func (_m *MockICourseQuizRepository) CountAttempts(ctx context.Context, txWrapper database.ITransaction, quizID uuid.UUID, studentID uuid.UUID) (int, error) {
+	ret := _m.Called(ctx, txWrapper, quizID, studentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountAttempts")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ITransaction, uuid.UUID, uuid.UUID) (int, error)); ok {
+		return rf(ctx, txWrapper, quizID, studentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, database.ITransaction, uuid.UUID, uuid.UUID) int); ok {
+		r0 = rf(ctx, txWrapper, quizID, studentID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, database.ITransaction, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, txWrapper, quizID, studentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockICourseQuizRepository_CountAttempts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountAttempts'
+type MockICourseQuizRepository_CountAttempts_Call struct {
+	*mock.Call
+}
+
+// CountAttempts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - txWrapper database.ITransaction
+//   - quizID uuid.UUID
+//   - studentID uuid.UUID
+func (_e *MockICourseQuizRepository_Expecter) CountAttempts(ctx interface{}, txWrapper interface{}, quizID interface{}, studentID interface{}) *MockICourseQuizRepository_CountAttempts_Call {
+	return &MockICourseQuizRepository_CountAttempts_Call{Call: _e.mock.On("CountAttempts", ctx, txWrapper, quizID, studentID)}
+}
+
+func (_c *MockICourseQuizRepository_CountAttempts_Call) Run(run func(ctx context.Context, txWrapper database.ITransaction, quizID uuid.UUID, studentID uuid.UUID)) *MockICourseQuizRepository_CountAttempts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ITransaction), args[2].(uuid.UUID), args[3].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockICourseQuizRepository_CountAttempts_Call) Return(_a0 int, _a1 error) *MockICourseQuizRepository_CountAttempts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockICourseQuizRepository_CountAttempts_Call) RunAndReturn(run func(context.Context, database.ITransaction, uuid.UUID, uuid.UUID) (int, error)) *MockICourseQuizRepository_CountAttempts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateAttempt provides a mock function with given fields: ctx, txWrapper, attempt
+func (_m *MockICourseQuizRepository) CreateAttempt(ctx context.Context, txWrapper database.ITransaction, attempt *entity.CourseQuizAttempt) error {
+	ret := _m.Called(ctx, txWrapper, attempt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAttempt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ITransaction, *entity.CourseQuizAttempt) error); ok {
+		r0 = rf(ctx, txWrapper, attempt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockICourseQuizRepository_CreateAttempt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAttempt'
+type MockICourseQuizRepository_CreateAttempt_Call struct {
+	*mock.Call
+}
+
+// CreateAttempt is a helper method to define mock.On call
+//   - ctx context.Context
+//   - txWrapper database.ITransaction
+//   - attempt *entity.CourseQuizAttempt
+func (_e *MockICourseQuizRepository_Expecter) CreateAttempt(ctx interface{}, txWrapper interface{}, attempt interface{}) *MockICourseQuizRepository_CreateAttempt_Call {
+	return &MockICourseQuizRepository_CreateAttempt_Call{Call: _e.mock.On("CreateAttempt", ctx, txWrapper, attempt)}
+}
+
+func (_c *MockICourseQuizRepository_CreateAttempt_Call) Run(run func(ctx context.Context, txWrapper database.ITransaction, attempt *entity.CourseQuizAttempt)) *MockICourseQuizRepository_CreateAttempt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ITransaction), args[2].(*entity.CourseQuizAttempt))
+	})
+	return _c
+}
+
+func (_c *MockICourseQuizRepository_CreateAttempt_Call) Return(_a0 error) *MockICourseQuizRepository_CreateAttempt_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockICourseQuizRepository_CreateAttempt_Call) RunAndReturn(run func(context.Context, database.ITransaction, *entity.CourseQuizAttempt) error) *MockICourseQuizRepository_CreateAttempt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateQuiz provides a mock function with given fields: ctx, quiz
+func (_m *MockICourseQuizRepository) CreateQuiz(ctx context.Context, quiz *entity.CourseQuiz) error {
+	ret := _m.Called(ctx, quiz)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateQuiz")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.CourseQuiz) error); ok {
+		r0 = rf(ctx, quiz)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockICourseQuizRepository_CreateQuiz_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateQuiz'
+type MockICourseQuizRepository_CreateQuiz_Call struct {
+	*mock.Call
+}
+
+// CreateQuiz is a helper method to define mock.On call
+//   - ctx context.Context
+//   - quiz *entity.CourseQuiz
+func (_e *MockICourseQuizRepository_Expecter) CreateQuiz(ctx interface{}, quiz interface{}) *MockICourseQuizRepository_CreateQuiz_Call {
+	return &MockICourseQuizRepository_CreateQuiz_Call{Call: _e.mock.On("CreateQuiz", ctx, quiz)}
+}
+
+func (_c *MockICourseQuizRepository_CreateQuiz_Call) Run(run func(ctx context.Context, quiz *entity.CourseQuiz)) *MockICourseQuizRepository_CreateQuiz_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.CourseQuiz))
+	})
+	return _c
+}
+
+func (_c *MockICourseQuizRepository_CreateQuiz_Call) Return(_a0 error) *MockICourseQuizRepository_CreateQuiz_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockICourseQuizRepository_CreateQuiz_Call) RunAndReturn(run func(context.Context, *entity.CourseQuiz) error) *MockICourseQuizRepository_CreateQuiz_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteQuiz provides a mock function with given fields: ctx, id
+func (_m *MockICourseQuizRepository) DeleteQuiz(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteQuiz")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockICourseQuizRepository_DeleteQuiz_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteQuiz'
+type MockICourseQuizRepository_DeleteQuiz_Call struct {
+	*mock.Call
+}
+
+// DeleteQuiz is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockICourseQuizRepository_Expecter) DeleteQuiz(ctx interface{}, id interface{}) *MockICourseQuizRepository_DeleteQuiz_Call {
+	return &MockICourseQuizRepository_DeleteQuiz_Call{Call: _e.mock.On("DeleteQuiz", ctx, id)}
+}
+
+func (_c *MockICourseQuizRepository_DeleteQuiz_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockICourseQuizRepository_DeleteQuiz_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockICourseQuizRepository_DeleteQuiz_Call) Return(_a0 error) *MockICourseQuizRepository_DeleteQuiz_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockICourseQuizRepository_DeleteQuiz_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *MockICourseQuizRepository_DeleteQuiz_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAttempts provides a mock function with given fields: ctx, quizID, studentID
+func (_m *MockICourseQuizRepository) GetAttempts(ctx context.Context, quizID uuid.UUID, studentID uuid.UUID) ([]*entity.CourseQuizAttempt, error) {
+	ret := _m.Called(ctx, quizID, studentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAttempts")
+	}
+
+	var r0 []*entity.CourseQuizAttempt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) ([]*entity.CourseQuizAttempt, error)); ok {
+		return rf(ctx, quizID, studentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) []*entity.CourseQuizAttempt); ok {
+		r0 = rf(ctx, quizID, studentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.CourseQuizAttempt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, quizID, studentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockICourseQuizRepository_GetAttempts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAttempts'
+type MockICourseQuizRepository_GetAttempts_Call struct {
+	*mock.Call
+}
+
+// GetAttempts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - quizID uuid.UUID
+//   - studentID uuid.UUID
+func (_e *MockICourseQuizRepository_Expecter) GetAttempts(ctx interface{}, quizID interface{}, studentID interface{}) *MockICourseQuizRepository_GetAttempts_Call {
+	return &MockICourseQuizRepository_GetAttempts_Call{Call: _e.mock.On("GetAttempts", ctx, quizID, studentID)}
+}
+
+func (_c *MockICourseQuizRepository_GetAttempts_Call) Run(run func(ctx context.Context, quizID uuid.UUID, studentID uuid.UUID)) *MockICourseQuizRepository_GetAttempts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockICourseQuizRepository_GetAttempts_Call) Return(_a0 []*entity.CourseQuizAttempt, _a1 error) *MockICourseQuizRepository_GetAttempts_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockICourseQuizRepository_GetAttempts_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) ([]*entity.CourseQuizAttempt, error)) *MockICourseQuizRepository_GetAttempts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetQuizByID provides a mock function with given fields: ctx, id
+func (_m *MockICourseQuizRepository) GetQuizByID(ctx context.Context, id uuid.UUID) (*entity.CourseQuiz, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuizByID")
+	}
+
+	var r0 *entity.CourseQuiz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*entity.CourseQuiz, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *entity.CourseQuiz); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.CourseQuiz)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockICourseQuizRepository_GetQuizByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQuizByID'
+type MockICourseQuizRepository_GetQuizByID_Call struct {
+	*mock.Call
+}
+
+// GetQuizByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockICourseQuizRepository_Expecter) GetQuizByID(ctx interface{}, id interface{}) *MockICourseQuizRepository_GetQuizByID_Call {
+	return &MockICourseQuizRepository_GetQuizByID_Call{Call: _e.mock.On("GetQuizByID", ctx, id)}
+}
+
+func (_c *MockICourseQuizRepository_GetQuizByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockICourseQuizRepository_GetQuizByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockICourseQuizRepository_GetQuizByID_Call) Return(_a0 *entity.CourseQuiz, _a1 error) *MockICourseQuizRepository_GetQuizByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockICourseQuizRepository_GetQuizByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*entity.CourseQuiz, error)) *MockICourseQuizRepository_GetQuizByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetQuizzesByCourseID provides a mock function with given fields: ctx, courseID
+func (_m *MockICourseQuizRepository) GetQuizzesByCourseID(ctx context.Context, courseID uuid.UUID) ([]*entity.CourseQuiz, error) {
+	ret := _m.Called(ctx, courseID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuizzesByCourseID")
+	}
+
+	var r0 []*entity.CourseQuiz
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*entity.CourseQuiz, error)); ok {
+		return rf(ctx, courseID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*entity.CourseQuiz); ok {
+		r0 = rf(ctx, courseID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.CourseQuiz)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, courseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockICourseQuizRepository_GetQuizzesByCourseID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQuizzesByCourseID'
+type MockICourseQuizRepository_GetQuizzesByCourseID_Call struct {
+	*mock.Call
+}
+
+// GetQuizzesByCourseID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - courseID uuid.UUID
+func (_e *MockICourseQuizRepository_Expecter) GetQuizzesByCourseID(ctx interface{}, courseID interface{}) *MockICourseQuizRepository_GetQuizzesByCourseID_Call {
+	return &MockICourseQuizRepository_GetQuizzesByCourseID_Call{Call: _e.mock.On("GetQuizzesByCourseID", ctx, courseID)}
+}
+
+func (_c *MockICourseQuizRepository_GetQuizzesByCourseID_Call) Run(run func(ctx context.Context, courseID uuid.UUID)) *MockICourseQuizRepository_GetQuizzesByCourseID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockICourseQuizRepository_GetQuizzesByCourseID_Call) Return(_a0 []*entity.CourseQuiz, _a1 error) *MockICourseQuizRepository_GetQuizzesByCourseID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockICourseQuizRepository_GetQuizzesByCourseID_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]*entity.CourseQuiz, error)) *MockICourseQuizRepository_GetQuizzesByCourseID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReplaceQuestions provides a mock function with given fields: ctx, quizID, questions
+func (_m *MockICourseQuizRepository) ReplaceQuestions(ctx context.Context, quizID uuid.UUID, questions []*entity.CourseQuizQuestion) error {
+	ret := _m.Called(ctx, quizID, questions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceQuestions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, []*entity.CourseQuizQuestion) error); ok {
+		r0 = rf(ctx, quizID, questions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockICourseQuizRepository_ReplaceQuestions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReplaceQuestions'
+type MockICourseQuizRepository_ReplaceQuestions_Call struct {
+	*mock.Call
+}
+
+// ReplaceQuestions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - quizID uuid.UUID
+//   - questions []*entity.CourseQuizQuestion
+func (_e *MockICourseQuizRepository_Expecter) ReplaceQuestions(ctx interface{}, quizID interface{}, questions interface{}) *MockICourseQuizRepository_ReplaceQuestions_Call {
+	return &MockICourseQuizRepository_ReplaceQuestions_Call{Call: _e.mock.On("ReplaceQuestions", ctx, quizID, questions)}
+}
+
+func (_c *MockICourseQuizRepository_ReplaceQuestions_Call) Run(run func(ctx context.Context, quizID uuid.UUID, questions []*entity.CourseQuizQuestion)) *MockICourseQuizRepository_ReplaceQuestions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].([]*entity.CourseQuizQuestion))
+	})
+	return _c
+}
+
+func (_c *MockICourseQuizRepository_ReplaceQuestions_Call) Return(_a0 error) *MockICourseQuizRepository_ReplaceQuestions_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockICourseQuizRepository_ReplaceQuestions_Call) RunAndReturn(run func(context.Context, uuid.UUID, []*entity.CourseQuizQuestion) error) *MockICourseQuizRepository_ReplaceQuestions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateQuiz provides a mock function with given fields: ctx, id, updates
+func (_m *MockICourseQuizRepository) UpdateQuiz(ctx context.Context, id uuid.UUID, updates dto.CourseQuizUpdate) error {
+	ret := _m.Called(ctx, id, updates)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateQuiz")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, dto.CourseQuizUpdate) error); ok {
+		r0 = rf(ctx, id, updates)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockICourseQuizRepository_UpdateQuiz_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateQuiz'
+type MockICourseQuizRepository_UpdateQuiz_Call struct {
+	*mock.Call
+}
+
+// UpdateQuiz is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - updates dto.CourseQuizUpdate
+func (_e *MockICourseQuizRepository_Expecter) UpdateQuiz(ctx interface{}, id interface{}, updates interface{}) *MockICourseQuizRepository_UpdateQuiz_Call {
+	return &MockICourseQuizRepository_UpdateQuiz_Call{Call: _e.mock.On("UpdateQuiz", ctx, id, updates)}
+}
+
+func (_c *MockICourseQuizRepository_UpdateQuiz_Call) Run(run func(ctx context.Context, id uuid.UUID, updates dto.CourseQuizUpdate)) *MockICourseQuizRepository_UpdateQuiz_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(dto.CourseQuizUpdate))
+	})
+	return _c
+}
+
+func (_c *MockICourseQuizRepository_UpdateQuiz_Call) Return(_a0 error) *MockICourseQuizRepository_UpdateQuiz_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockICourseQuizRepository_UpdateQuiz_Call) RunAndReturn(run func(context.Context, uuid.UUID, dto.CourseQuizUpdate) error) *MockICourseQuizRepository_UpdateQuiz_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockICourseQuizRepository creates a new instance of MockICourseQuizRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockICourseQuizRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockICourseQuizRepository {
+	mock := &MockICourseQuizRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,589 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	database "github.com/nathakusuma/elevateu-backend/internal/infra/database"
+
+	dto "github.com/nathakusuma/elevateu-backend/domain/dto"
+
+	entity "github.com/nathakusuma/elevateu-backend/domain/entity"
+
+	enum "github.com/nathakusuma/elevateu-backend/domain/enum"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockICourseRepository is an autogenerated mock type for the ICourseRepository type
+type MockICourseRepository struct {
+	mock.Mock
+}
+
+type MockICourseRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockICourseRepository) EXPECT() *MockICourseRepository_Expecter {
+	return &MockICourseRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateCourse provides a mock function with given fields: ctx, course
+func (_m *MockICourseRepository) CreateCourse(ctx context.Context, course *entity.Course) error {
+	ret := _m.Called(ctx, course)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateCourse")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Course) error); ok {
+		r0 = rf(ctx, course)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockICourseRepository_CreateCourse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCourse'
+type MockICourseRepository_CreateCourse_Call struct {
+	*mock.Call
+}
+
+// CreateCourse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - course *entity.Course
+func (_e *MockICourseRepository_Expecter) CreateCourse(ctx interface{}, course interface{}) *MockICourseRepository_CreateCourse_Call {
+	return &MockICourseRepository_CreateCourse_Call{Call: _e.mock.On("CreateCourse", ctx, course)}
+}
+
+func (_c *MockICourseRepository_CreateCourse_Call) Run(run func(ctx context.Context, course *entity.Course)) *MockICourseRepository_CreateCourse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entity.Course))
+	})
+	return _c
+}
+
+func (_c *MockICourseRepository_CreateCourse_Call) Return(_a0 error) *MockICourseRepository_CreateCourse_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockICourseRepository_CreateCourse_Call) RunAndReturn(run func(context.Context, *entity.Course) error) *MockICourseRepository_CreateCourse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateEnrollment provides a mock function with given fields: ctx, courseID, studentID
+func (_m *MockICourseRepository) CreateEnrollment(ctx context.Context, courseID uuid.UUID, studentID uuid.UUID) error {
+	ret := _m.Called(ctx, courseID, studentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEnrollment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, courseID, studentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockICourseRepository_CreateEnrollment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEnrollment'
+type MockICourseRepository_CreateEnrollment_Call struct {
+	*mock.Call
+}
+
+// CreateEnrollment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - courseID uuid.UUID
+//   - studentID uuid.UUID
+func (_e *MockICourseRepository_Expecter) CreateEnrollment(ctx interface{}, courseID interface{}, studentID interface{}) *MockICourseRepository_CreateEnrollment_Call {
+	return &MockICourseRepository_CreateEnrollment_Call{Call: _e.mock.On("CreateEnrollment", ctx, courseID, studentID)}
+}
+
+func (_c *MockICourseRepository_CreateEnrollment_Call) Run(run func(ctx context.Context, courseID uuid.UUID, studentID uuid.UUID)) *MockICourseRepository_CreateEnrollment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockICourseRepository_CreateEnrollment_Call) Return(_a0 error) *MockICourseRepository_CreateEnrollment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockICourseRepository_CreateEnrollment_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *MockICourseRepository_CreateEnrollment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteCourse provides a mock function with given fields: ctx, txWrapper, id
+func (_m *MockICourseRepository) DeleteCourse(ctx context.Context, txWrapper database.ITransaction, id uuid.UUID) error {
+	ret := _m.Called(ctx, txWrapper, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCourse")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ITransaction, uuid.UUID) error); ok {
+		r0 = rf(ctx, txWrapper, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockICourseRepository_DeleteCourse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCourse'
+type MockICourseRepository_DeleteCourse_Call struct {
+	*mock.Call
+}
+
+// DeleteCourse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - txWrapper database.ITransaction
+//   - id uuid.UUID
+func (_e *MockICourseRepository_Expecter) DeleteCourse(ctx interface{}, txWrapper interface{}, id interface{}) *MockICourseRepository_DeleteCourse_Call {
+	return &MockICourseRepository_DeleteCourse_Call{Call: _e.mock.On("DeleteCourse", ctx, txWrapper, id)}
+}
+
+func (_c *MockICourseRepository_DeleteCourse_Call) Run(run func(ctx context.Context, txWrapper database.ITransaction, id uuid.UUID)) *MockICourseRepository_DeleteCourse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ITransaction), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockICourseRepository_DeleteCourse_Call) Return(_a0 error) *MockICourseRepository_DeleteCourse_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockICourseRepository_DeleteCourse_Call) RunAndReturn(run func(context.Context, database.ITransaction, uuid.UUID) error) *MockICourseRepository_DeleteCourse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCourseByID provides a mock function with given fields: ctx, id
+func (_m *MockICourseRepository) GetCourseByID(ctx context.Context, id uuid.UUID) (*entity.Course, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCourseByID")
+	}
+
+	var r0 *entity.Course
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*entity.Course, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *entity.Course); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Course)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockICourseRepository_GetCourseByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCourseByID'
+type MockICourseRepository_GetCourseByID_Call struct {
+	*mock.Call
+}
+
+// GetCourseByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockICourseRepository_Expecter) GetCourseByID(ctx interface{}, id interface{}) *MockICourseRepository_GetCourseByID_Call {
+	return &MockICourseRepository_GetCourseByID_Call{Call: _e.mock.On("GetCourseByID", ctx, id)}
+}
+
+func (_c *MockICourseRepository_GetCourseByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockICourseRepository_GetCourseByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockICourseRepository_GetCourseByID_Call) Return(_a0 *entity.Course, _a1 error) *MockICourseRepository_GetCourseByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockICourseRepository_GetCourseByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*entity.Course, error)) *MockICourseRepository_GetCourseByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCourses provides a mock function with given fields: ctx, query, pageReq
+func (_m *MockICourseRepository) GetCourses(ctx context.Context, query dto.GetCoursesQuery, pageReq dto.PaginationRequest) ([]*entity.Course, dto.PaginationResponse, error) {
+	ret := _m.Called(ctx, query, pageReq)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCourses")
+	}
+
+	var r0 []*entity.Course
+	var r1 dto.PaginationResponse
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.GetCoursesQuery, dto.PaginationRequest) ([]*entity.Course, dto.PaginationResponse, error)); ok {
+		return rf(ctx, query, pageReq)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.GetCoursesQuery, dto.PaginationRequest) []*entity.Course); ok {
+		r0 = rf(ctx, query, pageReq)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Course)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.GetCoursesQuery, dto.PaginationRequest) dto.PaginationResponse); ok {
+		r1 = rf(ctx, query, pageReq)
+	} else {
+		r1 = ret.Get(1).(dto.PaginationResponse)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, dto.GetCoursesQuery, dto.PaginationRequest) error); ok {
+		r2 = rf(ctx, query, pageReq)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockICourseRepository_GetCourses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCourses'
+type MockICourseRepository_GetCourses_Call struct {
+	*mock.Call
+}
+
+// GetCourses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query dto.GetCoursesQuery
+//   - pageReq dto.PaginationRequest
+func (_e *MockICourseRepository_Expecter) GetCourses(ctx interface{}, query interface{}, pageReq interface{}) *MockICourseRepository_GetCourses_Call {
+	return &MockICourseRepository_GetCourses_Call{Call: _e.mock.On("GetCourses", ctx, query, pageReq)}
+}
+
+func (_c *MockICourseRepository_GetCourses_Call) Run(run func(ctx context.Context, query dto.GetCoursesQuery, pageReq dto.PaginationRequest)) *MockICourseRepository_GetCourses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dto.GetCoursesQuery), args[2].(dto.PaginationRequest))
+	})
+	return _c
+}
+
+func (_c *MockICourseRepository_GetCourses_Call) Return(_a0 []*entity.Course, _a1 dto.PaginationResponse, _a2 error) *MockICourseRepository_GetCourses_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockICourseRepository_GetCourses_Call) RunAndReturn(run func(context.Context, dto.GetCoursesQuery, dto.PaginationRequest) ([]*entity.Course, dto.PaginationResponse, error)) *MockICourseRepository_GetCourses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEnrolledCourses provides a mock function with given fields: ctx, studentID, pageReq
+func (_m *MockICourseRepository) GetEnrolledCourses(ctx context.Context, studentID uuid.UUID, pageReq dto.PaginationRequest) ([]*entity.Course, dto.PaginationResponse, error) {
+	ret := _m.Called(ctx, studentID, pageReq)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEnrolledCourses")
+	}
+
+	var r0 []*entity.Course
+	var r1 dto.PaginationResponse
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, dto.PaginationRequest) ([]*entity.Course, dto.PaginationResponse, error)); ok {
+		return rf(ctx, studentID, pageReq)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, dto.PaginationRequest) []*entity.Course); ok {
+		r0 = rf(ctx, studentID, pageReq)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Course)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, dto.PaginationRequest) dto.PaginationResponse); ok {
+		r1 = rf(ctx, studentID, pageReq)
+	} else {
+		r1 = ret.Get(1).(dto.PaginationResponse)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uuid.UUID, dto.PaginationRequest) error); ok {
+		r2 = rf(ctx, studentID, pageReq)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockICourseRepository_GetEnrolledCourses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEnrolledCourses'
+type MockICourseRepository_GetEnrolledCourses_Call struct {
+	*mock.Call
+}
+
+// GetEnrolledCourses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - studentID uuid.UUID
+//   - pageReq dto.PaginationRequest
+func (_e *MockICourseRepository_Expecter) GetEnrolledCourses(ctx interface{}, studentID interface{}, pageReq interface{}) *MockICourseRepository_GetEnrolledCourses_Call {
+	return &MockICourseRepository_GetEnrolledCourses_Call{Call: _e.mock.On("GetEnrolledCourses", ctx, studentID, pageReq)}
+}
+
+func (_c *MockICourseRepository_GetEnrolledCourses_Call) Run(run func(ctx context.Context, studentID uuid.UUID, pageReq dto.PaginationRequest)) *MockICourseRepository_GetEnrolledCourses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(dto.PaginationRequest))
+	})
+	return _c
+}
+
+func (_c *MockICourseRepository_GetEnrolledCourses_Call) Return(_a0 []*entity.Course, _a1 dto.PaginationResponse, _a2 error) *MockICourseRepository_GetEnrolledCourses_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockICourseRepository_GetEnrolledCourses_Call) RunAndReturn(run func(context.Context, uuid.UUID, dto.PaginationRequest) ([]*entity.Course, dto.PaginationResponse, error)) *MockICourseRepository_GetEnrolledCourses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEnrollment provides a mock function with given fields: ctx, courseID, studentID
+func (_m *MockICourseRepository) GetEnrollment(ctx context.Context, courseID uuid.UUID, studentID uuid.UUID) (*entity.CourseEnrollment, error) {
+	ret := _m.Called(ctx, courseID, studentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEnrollment")
+	}
+
+	var r0 *entity.CourseEnrollment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) (*entity.CourseEnrollment, error)); ok {
+		return rf(ctx, courseID, studentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) *entity.CourseEnrollment); ok {
+		r0 = rf(ctx, courseID, studentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.CourseEnrollment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r1 = rf(ctx, courseID, studentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockICourseRepository_GetEnrollment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEnrollment'
+type MockICourseRepository_GetEnrollment_Call struct {
+	*mock.Call
+}
+
+// GetEnrollment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - courseID uuid.UUID
+//   - studentID uuid.UUID
+func (_e *MockICourseRepository_Expecter) GetEnrollment(ctx interface{}, courseID interface{}, studentID interface{}) *MockICourseRepository_GetEnrollment_Call {
+	return &MockICourseRepository_GetEnrollment_Call{Call: _e.mock.On("GetEnrollment", ctx, courseID, studentID)}
+}
+
+func (_c *MockICourseRepository_GetEnrollment_Call) Run(run func(ctx context.Context, courseID uuid.UUID, studentID uuid.UUID)) *MockICourseRepository_GetEnrollment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *MockICourseRepository_GetEnrollment_Call) Return(_a0 *entity.CourseEnrollment, _a1 error) *MockICourseRepository_GetEnrollment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockICourseRepository_GetEnrollment_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) (*entity.CourseEnrollment, error)) *MockICourseRepository_GetEnrollment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCourse provides a mock function with given fields: ctx, txWrapper, updates
+func (_m *MockICourseRepository) UpdateCourse(ctx context.Context, txWrapper database.ITransaction, updates *dto.CourseUpdate) error {
+	ret := _m.Called(ctx, txWrapper, updates)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCourse")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ITransaction, *dto.CourseUpdate) error); ok {
+		r0 = rf(ctx, txWrapper, updates)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockICourseRepository_UpdateCourse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCourse'
+type MockICourseRepository_UpdateCourse_Call struct {
+	*mock.Call
+}
+
+// UpdateCourse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - txWrapper database.ITransaction
+//   - updates *dto.CourseUpdate
+func (_e *MockICourseRepository_Expecter) UpdateCourse(ctx interface{}, txWrapper interface{}, updates interface{}) *MockICourseRepository_UpdateCourse_Call {
+	return &MockICourseRepository_UpdateCourse_Call{Call: _e.mock.On("UpdateCourse", ctx, txWrapper, updates)}
+}
+
+func (_c *MockICourseRepository_UpdateCourse_Call) Run(run func(ctx context.Context, txWrapper database.ITransaction, updates *dto.CourseUpdate)) *MockICourseRepository_UpdateCourse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ITransaction), args[2].(*dto.CourseUpdate))
+	})
+	return _c
+}
+
+func (_c *MockICourseRepository_UpdateCourse_Call) Return(_a0 error) *MockICourseRepository_UpdateCourse_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockICourseRepository_UpdateCourse_Call) RunAndReturn(run func(context.Context, database.ITransaction, *dto.CourseUpdate) error) *MockICourseRepository_UpdateCourse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateCourseStatus provides a mock function with given fields: ctx, id, status, publishAt
+func (_m *MockICourseRepository) UpdateCourseStatus(ctx context.Context, id uuid.UUID, status enum.CourseStatus, publishAt *time.Time) error {
+	ret := _m.Called(ctx, id, status, publishAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateCourseStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, enum.CourseStatus, *time.Time) error); ok {
+		r0 = rf(ctx, id, status, publishAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockICourseRepository_UpdateCourseStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCourseStatus'
+type MockICourseRepository_UpdateCourseStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateCourseStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - status enum.CourseStatus
+//   - publishAt *time.Time
+func (_e *MockICourseRepository_Expecter) UpdateCourseStatus(ctx interface{}, id interface{}, status interface{}, publishAt interface{}) *MockICourseRepository_UpdateCourseStatus_Call {
+	return &MockICourseRepository_UpdateCourseStatus_Call{Call: _e.mock.On("UpdateCourseStatus", ctx, id, status, publishAt)}
+}
+
+func (_c *MockICourseRepository_UpdateCourseStatus_Call) Run(run func(ctx context.Context, id uuid.UUID, status enum.CourseStatus, publishAt *time.Time)) *MockICourseRepository_UpdateCourseStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(enum.CourseStatus), args[3].(*time.Time))
+	})
+	return _c
+}
+
+func (_c *MockICourseRepository_UpdateCourseStatus_Call) Return(_a0 error) *MockICourseRepository_UpdateCourseStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockICourseRepository_UpdateCourseStatus_Call) RunAndReturn(run func(context.Context, uuid.UUID, enum.CourseStatus, *time.Time) error) *MockICourseRepository_UpdateCourseStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePreviewVideoStatus provides a mock function with given fields: ctx, id, status
+func (_m *MockICourseRepository) UpdatePreviewVideoStatus(ctx context.Context, id uuid.UUID, status enum.AssetStatus) error {
+	ret := _m.Called(ctx, id, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePreviewVideoStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, enum.AssetStatus) error); ok {
+		r0 = rf(ctx, id, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockICourseRepository_UpdatePreviewVideoStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePreviewVideoStatus'
+type MockICourseRepository_UpdatePreviewVideoStatus_Call struct {
+	*mock.Call
+}
+
+// UpdatePreviewVideoStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - status enum.AssetStatus
+func (_e *MockICourseRepository_Expecter) UpdatePreviewVideoStatus(ctx interface{}, id interface{}, status interface{}) *MockICourseRepository_UpdatePreviewVideoStatus_Call {
+	return &MockICourseRepository_UpdatePreviewVideoStatus_Call{Call: _e.mock.On("UpdatePreviewVideoStatus", ctx, id, status)}
+}
+
+func (_c *MockICourseRepository_UpdatePreviewVideoStatus_Call) Run(run func(ctx context.Context, id uuid.UUID, status enum.AssetStatus)) *MockICourseRepository_UpdatePreviewVideoStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(enum.AssetStatus))
+	})
+	return _c
+}
+
+func (_c *MockICourseRepository_UpdatePreviewVideoStatus_Call) Return(_a0 error) *MockICourseRepository_UpdatePreviewVideoStatus_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockICourseRepository_UpdatePreviewVideoStatus_Call) RunAndReturn(run func(context.Context, uuid.UUID, enum.AssetStatus) error) *MockICourseRepository_UpdatePreviewVideoStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockICourseRepository creates a new instance of MockICourseRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockICourseRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockICourseRepository {
+	mock := &MockICourseRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
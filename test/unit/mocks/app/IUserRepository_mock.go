@@ -5,6 +5,10 @@ package mocks
 import (
 	context "context"
 
+	database "github.com/nathakusuma/elevateu-backend/internal/infra/database"
+
+	dto "github.com/nathakusuma/elevateu-backend/domain/dto"
+
 	entity "github.com/nathakusuma/elevateu-backend/domain/entity"
 
 	mock "github.com/stretchr/testify/mock"
@@ -25,6 +29,55 @@ func (_m *MockIUserRepository) EXPECT() *MockIUserRepository_Expecter {
 	return &MockIUserRepository_Expecter{mock: &_m.Mock}
 }
 
+// AddPoint provides a mock function with given fields: ctx, txWrapper, userID, point
+func (_m *MockIUserRepository) AddPoint(ctx context.Context, txWrapper database.ITransaction, userID uuid.UUID, point int) error {
+	ret := _m.Called(ctx, txWrapper, userID, point)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPoint")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, database.ITransaction, uuid.UUID, int) error); ok {
+		r0 = rf(ctx, txWrapper, userID, point)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIUserRepository_AddPoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddPoint'
+type MockIUserRepository_AddPoint_Call struct {
+	*mock.Call
+}
+
+// AddPoint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - txWrapper database.ITransaction
+//   - userID uuid.UUID
+//   - point int
+func (_e *MockIUserRepository_Expecter) AddPoint(ctx interface{}, txWrapper interface{}, userID interface{}, point interface{}) *MockIUserRepository_AddPoint_Call {
+	return &MockIUserRepository_AddPoint_Call{Call: _e.mock.On("AddPoint", ctx, txWrapper, userID, point)}
+}
+
+func (_c *MockIUserRepository_AddPoint_Call) Run(run func(ctx context.Context, txWrapper database.ITransaction, userID uuid.UUID, point int)) *MockIUserRepository_AddPoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(database.ITransaction), args[2].(uuid.UUID), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockIUserRepository_AddPoint_Call) Return(_a0 error) *MockIUserRepository_AddPoint_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUserRepository_AddPoint_Call) RunAndReturn(run func(context.Context, database.ITransaction, uuid.UUID, int) error) *MockIUserRepository_AddPoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateUser provides a mock function with given fields: ctx, user
 func (_m *MockIUserRepository) CreateUser(ctx context.Context, user *entity.User) error {
 	ret := _m.Called(ctx, user)
@@ -119,8 +172,133 @@ func (_c *MockIUserRepository_DeleteUser_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// GetMentors provides a mock function with given fields: ctx, pageReq
+func (_m *MockIUserRepository) GetMentors(ctx context.Context, pageReq dto.PaginationRequest) ([]*entity.User, dto.PaginationResponse, error) {
+	ret := _m.Called(ctx, pageReq)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMentors")
+	}
+
+	var r0 []*entity.User
+	var r1 dto.PaginationResponse
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, dto.PaginationRequest) ([]*entity.User, dto.PaginationResponse, error)); ok {
+		return rf(ctx, pageReq)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dto.PaginationRequest) []*entity.User); ok {
+		r0 = rf(ctx, pageReq)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dto.PaginationRequest) dto.PaginationResponse); ok {
+		r1 = rf(ctx, pageReq)
+	} else {
+		r1 = ret.Get(1).(dto.PaginationResponse)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, dto.PaginationRequest) error); ok {
+		r2 = rf(ctx, pageReq)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockIUserRepository_GetMentors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMentors'
+type MockIUserRepository_GetMentors_Call struct {
+	*mock.Call
+}
+
+// GetMentors is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pageReq dto.PaginationRequest
+func (_e *MockIUserRepository_Expecter) GetMentors(ctx interface{}, pageReq interface{}) *MockIUserRepository_GetMentors_Call {
+	return &MockIUserRepository_GetMentors_Call{Call: _e.mock.On("GetMentors", ctx, pageReq)}
+}
+
+func (_c *MockIUserRepository_GetMentors_Call) Run(run func(ctx context.Context, pageReq dto.PaginationRequest)) *MockIUserRepository_GetMentors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dto.PaginationRequest))
+	})
+	return _c
+}
+
+func (_c *MockIUserRepository_GetMentors_Call) Return(_a0 []*entity.User, _a1 dto.PaginationResponse, _a2 error) *MockIUserRepository_GetMentors_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockIUserRepository_GetMentors_Call) RunAndReturn(run func(context.Context, dto.PaginationRequest) ([]*entity.User, dto.PaginationResponse, error)) *MockIUserRepository_GetMentors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTopPoints provides a mock function with given fields: ctx, limit
+func (_m *MockIUserRepository) GetTopPoints(ctx context.Context, limit int) ([]*entity.User, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTopPoints")
+	}
+
+	var r0 []*entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*entity.User, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*entity.User); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIUserRepository_GetTopPoints_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTopPoints'
+type MockIUserRepository_GetTopPoints_Call struct {
+	*mock.Call
+}
+
+// GetTopPoints is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *MockIUserRepository_Expecter) GetTopPoints(ctx interface{}, limit interface{}) *MockIUserRepository_GetTopPoints_Call {
+	return &MockIUserRepository_GetTopPoints_Call{Call: _e.mock.On("GetTopPoints", ctx, limit)}
+}
+
+func (_c *MockIUserRepository_GetTopPoints_Call) Run(run func(ctx context.Context, limit int)) *MockIUserRepository_GetTopPoints_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockIUserRepository_GetTopPoints_Call) Return(_a0 []*entity.User, _a1 error) *MockIUserRepository_GetTopPoints_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIUserRepository_GetTopPoints_Call) RunAndReturn(run func(context.Context, int) ([]*entity.User, error)) *MockIUserRepository_GetTopPoints_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUserByField provides a mock function with given fields: ctx, field, value
-func (_m *MockIUserRepository) GetUserByField(ctx context.Context, field string, value string) (*entity.User, error) {
+func (_m *MockIUserRepository) GetUserByField(ctx context.Context, field string, value interface{}) (*entity.User, error) {
 	ret := _m.Called(ctx, field, value)
 
 	if len(ret) == 0 {
@@ -129,10 +307,10 @@ func (_m *MockIUserRepository) GetUserByField(ctx context.Context, field string,
 
 	var r0 *entity.User
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.User, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) (*entity.User, error)); ok {
 		return rf(ctx, field, value)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.User); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) *entity.User); ok {
 		r0 = rf(ctx, field, value)
 	} else {
 		if ret.Get(0) != nil {
@@ -140,7 +318,7 @@ func (_m *MockIUserRepository) GetUserByField(ctx context.Context, field string,
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}) error); ok {
 		r1 = rf(ctx, field, value)
 	} else {
 		r1 = ret.Error(1)
@@ -157,14 +335,14 @@ type MockIUserRepository_GetUserByField_Call struct {
 // GetUserByField is a helper method to define mock.On call
 //   - ctx context.Context
 //   - field string
-//   - value string
+//   - value interface{}
 func (_e *MockIUserRepository_Expecter) GetUserByField(ctx interface{}, field interface{}, value interface{}) *MockIUserRepository_GetUserByField_Call {
 	return &MockIUserRepository_GetUserByField_Call{Call: _e.mock.On("GetUserByField", ctx, field, value)}
 }
 
-func (_c *MockIUserRepository_GetUserByField_Call) Run(run func(ctx context.Context, field string, value string)) *MockIUserRepository_GetUserByField_Call {
+func (_c *MockIUserRepository_GetUserByField_Call) Run(run func(ctx context.Context, field string, value interface{})) *MockIUserRepository_GetUserByField_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}))
 	})
 	return _c
 }
@@ -174,22 +352,22 @@ func (_c *MockIUserRepository_GetUserByField_Call) Return(_a0 *entity.User, _a1
 	return _c
 }
 
-func (_c *MockIUserRepository_GetUserByField_Call) RunAndReturn(run func(context.Context, string, string) (*entity.User, error)) *MockIUserRepository_GetUserByField_Call {
+func (_c *MockIUserRepository_GetUserByField_Call) RunAndReturn(run func(context.Context, string, interface{}) (*entity.User, error)) *MockIUserRepository_GetUserByField_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateUser provides a mock function with given fields: ctx, user
-func (_m *MockIUserRepository) UpdateUser(ctx context.Context, user *entity.User) error {
-	ret := _m.Called(ctx, user)
+// UpdateUser provides a mock function with given fields: ctx, req
+func (_m *MockIUserRepository) UpdateUser(ctx context.Context, req *dto.UserUpdate) error {
+	ret := _m.Called(ctx, req)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateUser")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.User) error); ok {
-		r0 = rf(ctx, user)
+	if rf, ok := ret.Get(0).(func(context.Context, *dto.UserUpdate) error); ok {
+		r0 = rf(ctx, req)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -204,14 +382,14 @@ type MockIUserRepository_UpdateUser_Call struct {
 
 // UpdateUser is a helper method to define mock.On call
 //   - ctx context.Context
-//   - user *entity.User
-func (_e *MockIUserRepository_Expecter) UpdateUser(ctx interface{}, user interface{}) *MockIUserRepository_UpdateUser_Call {
-	return &MockIUserRepository_UpdateUser_Call{Call: _e.mock.On("UpdateUser", ctx, user)}
+//   - req *dto.UserUpdate
+func (_e *MockIUserRepository_Expecter) UpdateUser(ctx interface{}, req interface{}) *MockIUserRepository_UpdateUser_Call {
+	return &MockIUserRepository_UpdateUser_Call{Call: _e.mock.On("UpdateUser", ctx, req)}
 }
 
-func (_c *MockIUserRepository_UpdateUser_Call) Run(run func(ctx context.Context, user *entity.User)) *MockIUserRepository_UpdateUser_Call {
+func (_c *MockIUserRepository_UpdateUser_Call) Run(run func(ctx context.Context, req *dto.UserUpdate)) *MockIUserRepository_UpdateUser_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*entity.User))
+		run(args[0].(context.Context), args[1].(*dto.UserUpdate))
 	})
 	return _c
 }
@@ -221,7 +399,7 @@ func (_c *MockIUserRepository_UpdateUser_Call) Return(_a0 error) *MockIUserRepos
 	return _c
 }
 
-func (_c *MockIUserRepository_UpdateUser_Call) RunAndReturn(run func(context.Context, *entity.User) error) *MockIUserRepository_UpdateUser_Call {
+func (_c *MockIUserRepository_UpdateUser_Call) RunAndReturn(run func(context.Context, *dto.UserUpdate) error) *MockIUserRepository_UpdateUser_Call {
 	_c.Call.Return(run)
 	return _c
 }
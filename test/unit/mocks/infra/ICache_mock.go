@@ -0,0 +1,460 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockICache is an autogenerated mock type for the ICache type
+type MockICache struct {
+	mock.Mock
+}
+
+type MockICache_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockICache) EXPECT() *MockICache_Expecter {
+	return &MockICache_Expecter{mock: &_m.Mock}
+}
+
+// Close provides a mock function with no fields
+func (_m *MockICache) Close() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockICache_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
+type MockICache_Close_Call struct {
+	*mock.Call
+}
+
+// Close is a helper method to define mock.On call
+func (_e *MockICache_Expecter) Close() *MockICache_Close_Call {
+	return &MockICache_Close_Call{Call: _e.mock.On("Close")}
+}
+
+func (_c *MockICache_Close_Call) Run(run func()) *MockICache_Close_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockICache_Close_Call) Return(_a0 error) *MockICache_Close_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockICache_Close_Call) RunAndReturn(run func() error) *MockICache_Close_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Del provides a mock function with given fields: ctx, key
+func (_m *MockICache) Del(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Del")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockICache_Del_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Del'
+type MockICache_Del_Call struct {
+	*mock.Call
+}
+
+// Del is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockICache_Expecter) Del(ctx interface{}, key interface{}) *MockICache_Del_Call {
+	return &MockICache_Del_Call{Call: _e.mock.On("Del", ctx, key)}
+}
+
+func (_c *MockICache_Del_Call) Run(run func(ctx context.Context, key string)) *MockICache_Del_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockICache_Del_Call) Return(_a0 error) *MockICache_Del_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockICache_Del_Call) RunAndReturn(run func(context.Context, string) error) *MockICache_Del_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, key, val
+func (_m *MockICache) Get(ctx context.Context, key string, val interface{}) error {
+	ret := _m.Called(ctx, key, val)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) error); ok {
+		r0 = rf(ctx, key, val)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockICache_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockICache_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - val interface{}
+func (_e *MockICache_Expecter) Get(ctx interface{}, key interface{}, val interface{}) *MockICache_Get_Call {
+	return &MockICache_Get_Call{Call: _e.mock.On("Get", ctx, key, val)}
+}
+
+func (_c *MockICache_Get_Call) Run(run func(ctx context.Context, key string, val interface{})) *MockICache_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}))
+	})
+	return _c
+}
+
+func (_c *MockICache_Get_Call) Return(_a0 error) *MockICache_Get_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockICache_Get_Call) RunAndReturn(run func(context.Context, string, interface{}) error) *MockICache_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IncrBy provides a mock function with given fields: ctx, key, value, expiration
+func (_m *MockICache) IncrBy(ctx context.Context, key string, value int64, expiration time.Duration) (int64, error) {
+	ret := _m.Called(ctx, key, value, expiration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IncrBy")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) (int64, error)); ok {
+		return rf(ctx, key, value, expiration)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, time.Duration) int64); ok {
+		r0 = rf(ctx, key, value, expiration)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, time.Duration) error); ok {
+		r1 = rf(ctx, key, value, expiration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockICache_IncrBy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrBy'
+type MockICache_IncrBy_Call struct {
+	*mock.Call
+}
+
+// IncrBy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value int64
+//   - expiration time.Duration
+func (_e *MockICache_Expecter) IncrBy(ctx interface{}, key interface{}, value interface{}, expiration interface{}) *MockICache_IncrBy_Call {
+	return &MockICache_IncrBy_Call{Call: _e.mock.On("IncrBy", ctx, key, value, expiration)}
+}
+
+func (_c *MockICache_IncrBy_Call) Run(run func(ctx context.Context, key string, value int64, expiration time.Duration)) *MockICache_IncrBy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockICache_IncrBy_Call) Return(_a0 int64, _a1 error) *MockICache_IncrBy_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockICache_IncrBy_Call) RunAndReturn(run func(context.Context, string, int64, time.Duration) (int64, error)) *MockICache_IncrBy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Publish provides a mock function with given fields: ctx, channel, message
+func (_m *MockICache) Publish(ctx context.Context, channel string, message interface{}) error {
+	ret := _m.Called(ctx, channel, message)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}) error); ok {
+		r0 = rf(ctx, channel, message)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockICache_Publish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Publish'
+type MockICache_Publish_Call struct {
+	*mock.Call
+}
+
+// Publish is a helper method to define mock.On call
+//   - ctx context.Context
+//   - channel string
+//   - message interface{}
+func (_e *MockICache_Expecter) Publish(ctx interface{}, channel interface{}, message interface{}) *MockICache_Publish_Call {
+	return &MockICache_Publish_Call{Call: _e.mock.On("Publish", ctx, channel, message)}
+}
+
+func (_c *MockICache_Publish_Call) Run(run func(ctx context.Context, channel string, message interface{})) *MockICache_Publish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}))
+	})
+	return _c
+}
+
+func (_c *MockICache_Publish_Call) Return(_a0 error) *MockICache_Publish_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockICache_Publish_Call) RunAndReturn(run func(context.Context, string, interface{}) error) *MockICache_Publish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Set provides a mock function with given fields: ctx, key, value, expiration
+func (_m *MockICache) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	ret := _m.Called(ctx, key, value, expiration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Set")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r0 = rf(ctx, key, value, expiration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockICache_Set_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Set'
+type MockICache_Set_Call struct {
+	*mock.Call
+}
+
+// Set is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - expiration time.Duration
+func (_e *MockICache_Expecter) Set(ctx interface{}, key interface{}, value interface{}, expiration interface{}) *MockICache_Set_Call {
+	return &MockICache_Set_Call{Call: _e.mock.On("Set", ctx, key, value, expiration)}
+}
+
+func (_c *MockICache_Set_Call) Run(run func(ctx context.Context, key string, value interface{}, expiration time.Duration)) *MockICache_Set_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockICache_Set_Call) Return(_a0 error) *MockICache_Set_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockICache_Set_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) error) *MockICache_Set_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetNX provides a mock function with given fields: ctx, key, value, expiration
+func (_m *MockICache) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	ret := _m.Called(ctx, key, value, expiration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetNX")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) (bool, error)); ok {
+		return rf(ctx, key, value, expiration)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) bool); ok {
+		r0 = rf(ctx, key, value, expiration)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r1 = rf(ctx, key, value, expiration)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockICache_SetNX_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetNX'
+type MockICache_SetNX_Call struct {
+	*mock.Call
+}
+
+// SetNX is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - value interface{}
+//   - expiration time.Duration
+func (_e *MockICache_Expecter) SetNX(ctx interface{}, key interface{}, value interface{}, expiration interface{}) *MockICache_SetNX_Call {
+	return &MockICache_SetNX_Call{Call: _e.mock.On("SetNX", ctx, key, value, expiration)}
+}
+
+func (_c *MockICache_SetNX_Call) Run(run func(ctx context.Context, key string, value interface{}, expiration time.Duration)) *MockICache_SetNX_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(interface{}), args[3].(time.Duration))
+	})
+	return _c
+}
+
+func (_c *MockICache_SetNX_Call) Return(_a0 bool, _a1 error) *MockICache_SetNX_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockICache_SetNX_Call) RunAndReturn(run func(context.Context, string, interface{}, time.Duration) (bool, error)) *MockICache_SetNX_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Subscribe provides a mock function with given fields: ctx, channel
+func (_m *MockICache) Subscribe(ctx context.Context, channel string) (<-chan string, func() error, error) {
+	ret := _m.Called(ctx, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 <-chan string
+	var r1 func() error
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (<-chan string, func() error, error)); ok {
+		return rf(ctx, channel)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan string); ok {
+		r0 = rf(ctx, channel)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) func() error); ok {
+		r1 = rf(ctx, channel)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(func() error)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = rf(ctx, channel)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockICache_Subscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Subscribe'
+type MockICache_Subscribe_Call struct {
+	*mock.Call
+}
+
+// Subscribe is a helper method to define mock.On call
+//   - ctx context.Context
+//   - channel string
+func (_e *MockICache_Expecter) Subscribe(ctx interface{}, channel interface{}) *MockICache_Subscribe_Call {
+	return &MockICache_Subscribe_Call{Call: _e.mock.On("Subscribe", ctx, channel)}
+}
+
+func (_c *MockICache_Subscribe_Call) Run(run func(ctx context.Context, channel string)) *MockICache_Subscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockICache_Subscribe_Call) Return(_a0 <-chan string, _a1 func() error, _a2 error) *MockICache_Subscribe_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockICache_Subscribe_Call) RunAndReturn(run func(context.Context, string) (<-chan string, func() error, error)) *MockICache_Subscribe_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockICache creates a new instance of MockICache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockICache(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockICache {
+	mock := &MockICache{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	database "github.com/nathakusuma/elevateu-backend/internal/infra/database"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockITransactionManager is an autogenerated mock type for the ITransactionManager type
+type MockITransactionManager struct {
+	mock.Mock
+}
+
+type MockITransactionManager_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockITransactionManager) EXPECT() *MockITransactionManager_Expecter {
+	return &MockITransactionManager_Expecter{mock: &_m.Mock}
+}
+
+// BeginTx provides a mock function with given fields: ctx
+func (_m *MockITransactionManager) BeginTx(ctx context.Context) (database.ITransaction, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BeginTx")
+	}
+
+	var r0 database.ITransaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (database.ITransaction, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) database.ITransaction); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(database.ITransaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockITransactionManager_BeginTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BeginTx'
+type MockITransactionManager_BeginTx_Call struct {
+	*mock.Call
+}
+
+// BeginTx is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockITransactionManager_Expecter) BeginTx(ctx interface{}) *MockITransactionManager_BeginTx_Call {
+	return &MockITransactionManager_BeginTx_Call{Call: _e.mock.On("BeginTx", ctx)}
+}
+
+func (_c *MockITransactionManager_BeginTx_Call) Run(run func(ctx context.Context)) *MockITransactionManager_BeginTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockITransactionManager_BeginTx_Call) Return(_a0 database.ITransaction, _a1 error) *MockITransactionManager_BeginTx_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockITransactionManager_BeginTx_Call) RunAndReturn(run func(context.Context) (database.ITransaction, error)) *MockITransactionManager_BeginTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockITransactionManager creates a new instance of MockITransactionManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockITransactionManager(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockITransactionManager {
+	mock := &MockITransactionManager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,172 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	sqlx "github.com/jmoiron/sqlx"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockITransaction is an autogenerated mock type for the ITransaction type
+type MockITransaction struct {
+	mock.Mock
+}
+
+type MockITransaction_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockITransaction) EXPECT() *MockITransaction_Expecter {
+	return &MockITransaction_Expecter{mock: &_m.Mock}
+}
+
+// Commit provides a mock function with no fields
+func (_m *MockITransaction) Commit() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Commit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockITransaction_Commit_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Commit'
+type MockITransaction_Commit_Call struct {
+	*mock.Call
+}
+
+// Commit is a helper method to define mock.On call
+func (_e *MockITransaction_Expecter) Commit() *MockITransaction_Commit_Call {
+	return &MockITransaction_Commit_Call{Call: _e.mock.On("Commit")}
+}
+
+func (_c *MockITransaction_Commit_Call) Run(run func()) *MockITransaction_Commit_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockITransaction_Commit_Call) Return(_a0 error) *MockITransaction_Commit_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockITransaction_Commit_Call) RunAndReturn(run func() error) *MockITransaction_Commit_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTx provides a mock function with no fields
+func (_m *MockITransaction) GetTx() *sqlx.Tx {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTx")
+	}
+
+	var r0 *sqlx.Tx
+	if rf, ok := ret.Get(0).(func() *sqlx.Tx); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sqlx.Tx)
+		}
+	}
+
+	return r0
+}
+
+// MockITransaction_GetTx_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTx'
+type MockITransaction_GetTx_Call struct {
+	*mock.Call
+}
+
+// GetTx is a helper method to define mock.On call
+func (_e *MockITransaction_Expecter) GetTx() *MockITransaction_GetTx_Call {
+	return &MockITransaction_GetTx_Call{Call: _e.mock.On("GetTx")}
+}
+
+func (_c *MockITransaction_GetTx_Call) Run(run func()) *MockITransaction_GetTx_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockITransaction_GetTx_Call) Return(_a0 *sqlx.Tx) *MockITransaction_GetTx_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockITransaction_GetTx_Call) RunAndReturn(run func() *sqlx.Tx) *MockITransaction_GetTx_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rollback provides a mock function with no fields
+func (_m *MockITransaction) Rollback() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rollback")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockITransaction_Rollback_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rollback'
+type MockITransaction_Rollback_Call struct {
+	*mock.Call
+}
+
+// Rollback is a helper method to define mock.On call
+func (_e *MockITransaction_Expecter) Rollback() *MockITransaction_Rollback_Call {
+	return &MockITransaction_Rollback_Call{Call: _e.mock.On("Rollback")}
+}
+
+func (_c *MockITransaction_Rollback_Call) Run(run func()) *MockITransaction_Rollback_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockITransaction_Rollback_Call) Return(_a0 error) *MockITransaction_Rollback_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockITransaction_Rollback_Call) RunAndReturn(run func() error) *MockITransaction_Rollback_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockITransaction creates a new instance of MockITransaction. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockITransaction(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockITransaction {
+	mock := &MockITransaction{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
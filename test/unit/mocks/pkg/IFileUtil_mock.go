@@ -3,9 +3,14 @@
 package mocks
 
 import (
-	multipart "mime/multipart"
+	context "context"
+	io "io"
+
+	fileutil "github.com/nathakusuma/elevateu-backend/pkg/fileutil"
 
 	mock "github.com/stretchr/testify/mock"
+
+	multipart "mime/multipart"
 )
 
 // MockIFileUtil is an autogenerated mock type for the IFileUtil type
@@ -85,6 +90,504 @@ func (_c *MockIFileUtil_CheckMIMEFileType_Call) RunAndReturn(run func(multipart.
 	return _c
 }
 
+// Delete provides a mock function with given fields: ctx, path
+func (_m *MockIFileUtil) Delete(ctx context.Context, path string) error {
+	ret := _m.Called(ctx, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, path)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIFileUtil_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockIFileUtil_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - path string
+func (_e *MockIFileUtil_Expecter) Delete(ctx interface{}, path interface{}) *MockIFileUtil_Delete_Call {
+	return &MockIFileUtil_Delete_Call{Call: _e.mock.On("Delete", ctx, path)}
+}
+
+func (_c *MockIFileUtil_Delete_Call) Run(run func(ctx context.Context, path string)) *MockIFileUtil_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIFileUtil_Delete_Call) Return(_a0 error) *MockIFileUtil_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIFileUtil_Delete_Call) RunAndReturn(run func(context.Context, string) error) *MockIFileUtil_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Exists provides a mock function with given fields: ctx, path
+func (_m *MockIFileUtil) Exists(ctx context.Context, path string) (bool, error) {
+	ret := _m.Called(ctx, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, path)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, path)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIFileUtil_Exists_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exists'
+type MockIFileUtil_Exists_Call struct {
+	*mock.Call
+}
+
+// Exists is a helper method to define mock.On call
+//   - ctx context.Context
+//   - path string
+func (_e *MockIFileUtil_Expecter) Exists(ctx interface{}, path interface{}) *MockIFileUtil_Exists_Call {
+	return &MockIFileUtil_Exists_Call{Call: _e.mock.On("Exists", ctx, path)}
+}
+
+func (_c *MockIFileUtil_Exists_Call) Run(run func(ctx context.Context, path string)) *MockIFileUtil_Exists_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIFileUtil_Exists_Call) Return(_a0 bool, _a1 error) *MockIFileUtil_Exists_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIFileUtil_Exists_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *MockIFileUtil_Exists_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAttributes provides a mock function with given fields: ctx, path
+func (_m *MockIFileUtil) GetAttributes(ctx context.Context, path string) (*fileutil.FileAttributes, error) {
+	ret := _m.Called(ctx, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAttributes")
+	}
+
+	var r0 *fileutil.FileAttributes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*fileutil.FileAttributes, error)); ok {
+		return rf(ctx, path)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *fileutil.FileAttributes); ok {
+		r0 = rf(ctx, path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*fileutil.FileAttributes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIFileUtil_GetAttributes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAttributes'
+type MockIFileUtil_GetAttributes_Call struct {
+	*mock.Call
+}
+
+// GetAttributes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - path string
+func (_e *MockIFileUtil_Expecter) GetAttributes(ctx interface{}, path interface{}) *MockIFileUtil_GetAttributes_Call {
+	return &MockIFileUtil_GetAttributes_Call{Call: _e.mock.On("GetAttributes", ctx, path)}
+}
+
+func (_c *MockIFileUtil_GetAttributes_Call) Run(run func(ctx context.Context, path string)) *MockIFileUtil_GetAttributes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIFileUtil_GetAttributes_Call) Return(_a0 *fileutil.FileAttributes, _a1 error) *MockIFileUtil_GetAttributes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIFileUtil_GetAttributes_Call) RunAndReturn(run func(context.Context, string) (*fileutil.FileAttributes, error)) *MockIFileUtil_GetAttributes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetFullURL provides a mock function with given fields: path
+func (_m *MockIFileUtil) GetFullURL(path string) string {
+	ret := _m.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFullURL")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(path)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockIFileUtil_GetFullURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFullURL'
+type MockIFileUtil_GetFullURL_Call struct {
+	*mock.Call
+}
+
+// GetFullURL is a helper method to define mock.On call
+//   - path string
+func (_e *MockIFileUtil_Expecter) GetFullURL(path interface{}) *MockIFileUtil_GetFullURL_Call {
+	return &MockIFileUtil_GetFullURL_Call{Call: _e.mock.On("GetFullURL", path)}
+}
+
+func (_c *MockIFileUtil_GetFullURL_Call) Run(run func(path string)) *MockIFileUtil_GetFullURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockIFileUtil_GetFullURL_Call) Return(_a0 string) *MockIFileUtil_GetFullURL_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIFileUtil_GetFullURL_Call) RunAndReturn(run func(string) string) *MockIFileUtil_GetFullURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSignedURL provides a mock function with given fields: path
+func (_m *MockIFileUtil) GetSignedURL(path string) (string, error) {
+	ret := _m.Called(path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSignedURL")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(path)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(path)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIFileUtil_GetSignedURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSignedURL'
+type MockIFileUtil_GetSignedURL_Call struct {
+	*mock.Call
+}
+
+// GetSignedURL is a helper method to define mock.On call
+//   - path string
+func (_e *MockIFileUtil_Expecter) GetSignedURL(path interface{}) *MockIFileUtil_GetSignedURL_Call {
+	return &MockIFileUtil_GetSignedURL_Call{Call: _e.mock.On("GetSignedURL", path)}
+}
+
+func (_c *MockIFileUtil_GetSignedURL_Call) Run(run func(path string)) *MockIFileUtil_GetSignedURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockIFileUtil_GetSignedURL_Call) Return(_a0 string, _a1 error) *MockIFileUtil_GetSignedURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIFileUtil_GetSignedURL_Call) RunAndReturn(run func(string) (string, error)) *MockIFileUtil_GetSignedURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSignedURLs provides a mock function with given fields: paths
+func (_m *MockIFileUtil) GetSignedURLs(paths []string) (map[string]string, error) {
+	ret := _m.Called(paths)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSignedURLs")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func([]string) (map[string]string, error)); ok {
+		return rf(paths)
+	}
+	if rf, ok := ret.Get(0).(func([]string) map[string]string); ok {
+		r0 = rf(paths)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) error); ok {
+		r1 = rf(paths)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIFileUtil_GetSignedURLs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSignedURLs'
+type MockIFileUtil_GetSignedURLs_Call struct {
+	*mock.Call
+}
+
+// GetSignedURLs is a helper method to define mock.On call
+//   - paths []string
+func (_e *MockIFileUtil_Expecter) GetSignedURLs(paths interface{}) *MockIFileUtil_GetSignedURLs_Call {
+	return &MockIFileUtil_GetSignedURLs_Call{Call: _e.mock.On("GetSignedURLs", paths)}
+}
+
+func (_c *MockIFileUtil_GetSignedURLs_Call) Run(run func(paths []string)) *MockIFileUtil_GetSignedURLs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].([]string))
+	})
+	return _c
+}
+
+func (_c *MockIFileUtil_GetSignedURLs_Call) Return(_a0 map[string]string, _a1 error) *MockIFileUtil_GetSignedURLs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIFileUtil_GetSignedURLs_Call) RunAndReturn(run func([]string) (map[string]string, error)) *MockIFileUtil_GetSignedURLs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUploadSignedURL provides a mock function with given fields: path, contentType
+func (_m *MockIFileUtil) GetUploadSignedURL(path string, contentType string) (string, error) {
+	ret := _m.Called(path, contentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUploadSignedURL")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
+		return rf(path, contentType)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(path, contentType)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(path, contentType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIFileUtil_GetUploadSignedURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUploadSignedURL'
+type MockIFileUtil_GetUploadSignedURL_Call struct {
+	*mock.Call
+}
+
+// GetUploadSignedURL is a helper method to define mock.On call
+//   - path string
+//   - contentType string
+func (_e *MockIFileUtil_Expecter) GetUploadSignedURL(path interface{}, contentType interface{}) *MockIFileUtil_GetUploadSignedURL_Call {
+	return &MockIFileUtil_GetUploadSignedURL_Call{Call: _e.mock.On("GetUploadSignedURL", path, contentType)}
+}
+
+func (_c *MockIFileUtil_GetUploadSignedURL_Call) Run(run func(path string, contentType string)) *MockIFileUtil_GetUploadSignedURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIFileUtil_GetUploadSignedURL_Call) Return(_a0 string, _a1 error) *MockIFileUtil_GetUploadSignedURL_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIFileUtil_GetUploadSignedURL_Call) RunAndReturn(run func(string, string) (string, error)) *MockIFileUtil_GetUploadSignedURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Upload provides a mock function with given fields: ctx, file, path, contentType
+func (_m *MockIFileUtil) Upload(ctx context.Context, file io.Reader, path string, contentType string) (string, error) {
+	ret := _m.Called(ctx, file, path, contentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Upload")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader, string, string) (string, error)); ok {
+		return rf(ctx, file, path, contentType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, io.Reader, string, string) string); ok {
+		r0 = rf(ctx, file, path, contentType)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, io.Reader, string, string) error); ok {
+		r1 = rf(ctx, file, path, contentType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIFileUtil_Upload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Upload'
+type MockIFileUtil_Upload_Call struct {
+	*mock.Call
+}
+
+// Upload is a helper method to define mock.On call
+//   - ctx context.Context
+//   - file io.Reader
+//   - path string
+//   - contentType string
+func (_e *MockIFileUtil_Expecter) Upload(ctx interface{}, file interface{}, path interface{}, contentType interface{}) *MockIFileUtil_Upload_Call {
+	return &MockIFileUtil_Upload_Call{Call: _e.mock.On("Upload", ctx, file, path, contentType)}
+}
+
+func (_c *MockIFileUtil_Upload_Call) Run(run func(ctx context.Context, file io.Reader, path string, contentType string)) *MockIFileUtil_Upload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(io.Reader), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockIFileUtil_Upload_Call) Return(_a0 string, _a1 error) *MockIFileUtil_Upload_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIFileUtil_Upload_Call) RunAndReturn(run func(context.Context, io.Reader, string, string) (string, error)) *MockIFileUtil_Upload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ValidateAndUploadFile provides a mock function with given fields: ctx, header, allowedTypes, path
+func (_m *MockIFileUtil) ValidateAndUploadFile(ctx context.Context, header *multipart.FileHeader, allowedTypes []string, path string) (string, error) {
+	ret := _m.Called(ctx, header, allowedTypes, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateAndUploadFile")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *multipart.FileHeader, []string, string) (string, error)); ok {
+		return rf(ctx, header, allowedTypes, path)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *multipart.FileHeader, []string, string) string); ok {
+		r0 = rf(ctx, header, allowedTypes, path)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *multipart.FileHeader, []string, string) error); ok {
+		r1 = rf(ctx, header, allowedTypes, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIFileUtil_ValidateAndUploadFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateAndUploadFile'
+type MockIFileUtil_ValidateAndUploadFile_Call struct {
+	*mock.Call
+}
+
+// ValidateAndUploadFile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - header *multipart.FileHeader
+//   - allowedTypes []string
+//   - path string
+func (_e *MockIFileUtil_Expecter) ValidateAndUploadFile(ctx interface{}, header interface{}, allowedTypes interface{}, path interface{}) *MockIFileUtil_ValidateAndUploadFile_Call {
+	return &MockIFileUtil_ValidateAndUploadFile_Call{Call: _e.mock.On("ValidateAndUploadFile", ctx, header, allowedTypes, path)}
+}
+
+func (_c *MockIFileUtil_ValidateAndUploadFile_Call) Run(run func(ctx context.Context, header *multipart.FileHeader, allowedTypes []string, path string)) *MockIFileUtil_ValidateAndUploadFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*multipart.FileHeader), args[2].([]string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockIFileUtil_ValidateAndUploadFile_Call) Return(_a0 string, _a1 error) *MockIFileUtil_ValidateAndUploadFile_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIFileUtil_ValidateAndUploadFile_Call) RunAndReturn(run func(context.Context, *multipart.FileHeader, []string, string) (string, error)) *MockIFileUtil_ValidateAndUploadFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockIFileUtil creates a new instance of MockIFileUtil. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockIFileUtil(t interface {
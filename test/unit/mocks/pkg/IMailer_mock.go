@@ -2,7 +2,10 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	mail "github.com/nathakusuma/elevateu-backend/pkg/mail"
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MockIMailer is an autogenerated mock type for the IMailer type
 type MockIMailer struct {
@@ -17,17 +20,24 @@ func (_m *MockIMailer) EXPECT() *MockIMailer_Expecter {
 	return &MockIMailer_Expecter{mock: &_m.Mock}
 }
 
-// Send provides a mock function with given fields: recipientEmail, subject, templateName, data
-func (_m *MockIMailer) Send(recipientEmail string, subject string, templateName string, data map[string]any) error {
-	ret := _m.Called(recipientEmail, subject, templateName, data)
+// Send provides a mock function with given fields: recipientEmail, subject, templateName, data, attachments
+func (_m *MockIMailer) Send(recipientEmail string, subject string, templateName string, data map[string]any, attachments ...mail.Attachment) error {
+	_va := make([]interface{}, len(attachments))
+	for _i := range attachments {
+		_va[_i] = attachments[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, recipientEmail, subject, templateName, data)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Send")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string, map[string]any) error); ok {
-		r0 = rf(recipientEmail, subject, templateName, data)
+	if rf, ok := ret.Get(0).(func(string, string, string, map[string]any, ...mail.Attachment) error); ok {
+		r0 = rf(recipientEmail, subject, templateName, data, attachments...)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -45,13 +55,21 @@ type MockIMailer_Send_Call struct {
 //   - subject string
 //   - templateName string
 //   - data map[string]any
-func (_e *MockIMailer_Expecter) Send(recipientEmail interface{}, subject interface{}, templateName interface{}, data interface{}) *MockIMailer_Send_Call {
-	return &MockIMailer_Send_Call{Call: _e.mock.On("Send", recipientEmail, subject, templateName, data)}
+//   - attachments ...mail.Attachment
+func (_e *MockIMailer_Expecter) Send(recipientEmail interface{}, subject interface{}, templateName interface{}, data interface{}, attachments ...interface{}) *MockIMailer_Send_Call {
+	return &MockIMailer_Send_Call{Call: _e.mock.On("Send",
+		append([]interface{}{recipientEmail, subject, templateName, data}, attachments...)...)}
 }
 
-func (_c *MockIMailer_Send_Call) Run(run func(recipientEmail string, subject string, templateName string, data map[string]any)) *MockIMailer_Send_Call {
+func (_c *MockIMailer_Send_Call) Run(run func(recipientEmail string, subject string, templateName string, data map[string]any, attachments ...mail.Attachment)) *MockIMailer_Send_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string), args[3].(map[string]any))
+		variadicArgs := make([]mail.Attachment, len(args)-4)
+		for i, a := range args[4:] {
+			if a != nil {
+				variadicArgs[i] = a.(mail.Attachment)
+			}
+		}
+		run(args[0].(string), args[1].(string), args[2].(string), args[3].(map[string]any), variadicArgs...)
 	})
 	return _c
 }
@@ -61,7 +79,7 @@ func (_c *MockIMailer_Send_Call) Return(_a0 error) *MockIMailer_Send_Call {
 	return _c
 }
 
-func (_c *MockIMailer_Send_Call) RunAndReturn(run func(string, string, string, map[string]any) error) *MockIMailer_Send_Call {
+func (_c *MockIMailer_Send_Call) RunAndReturn(run func(string, string, string, map[string]any, ...mail.Attachment) error) *MockIMailer_Send_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,151 @@
+// Code generated by mockery v2.52.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	pdf "github.com/nathakusuma/elevateu-backend/pkg/pdf"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockIPDF is an autogenerated mock type for the IPDF type
+type MockIPDF struct {
+	mock.Mock
+}
+
+type MockIPDF_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIPDF) EXPECT() *MockIPDF_Expecter {
+	return &MockIPDF_Expecter{mock: &_m.Mock}
+}
+
+// GenerateReceipt provides a mock function with given fields: receipt
+func (_m *MockIPDF) GenerateReceipt(receipt pdf.Receipt) ([]byte, error) {
+	ret := _m.Called(receipt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateReceipt")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(pdf.Receipt) ([]byte, error)); ok {
+		return rf(receipt)
+	}
+	if rf, ok := ret.Get(0).(func(pdf.Receipt) []byte); ok {
+		r0 = rf(receipt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(pdf.Receipt) error); ok {
+		r1 = rf(receipt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIPDF_GenerateReceipt_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateReceipt'
+type MockIPDF_GenerateReceipt_Call struct {
+	*mock.Call
+}
+
+// GenerateReceipt is a helper method to define mock.On call
+//   - receipt pdf.Receipt
+func (_e *MockIPDF_Expecter) GenerateReceipt(receipt interface{}) *MockIPDF_GenerateReceipt_Call {
+	return &MockIPDF_GenerateReceipt_Call{Call: _e.mock.On("GenerateReceipt", receipt)}
+}
+
+func (_c *MockIPDF_GenerateReceipt_Call) Run(run func(receipt pdf.Receipt)) *MockIPDF_GenerateReceipt_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(pdf.Receipt))
+	})
+	return _c
+}
+
+func (_c *MockIPDF_GenerateReceipt_Call) Return(_a0 []byte, _a1 error) *MockIPDF_GenerateReceipt_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIPDF_GenerateReceipt_Call) RunAndReturn(run func(pdf.Receipt) ([]byte, error)) *MockIPDF_GenerateReceipt_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GenerateTranscript provides a mock function with given fields: transcript
+func (_m *MockIPDF) GenerateTranscript(transcript pdf.Transcript) ([]byte, error) {
+	ret := _m.Called(transcript)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateTranscript")
+	}
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(pdf.Transcript) ([]byte, error)); ok {
+		return rf(transcript)
+	}
+	if rf, ok := ret.Get(0).(func(pdf.Transcript) []byte); ok {
+		r0 = rf(transcript)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(pdf.Transcript) error); ok {
+		r1 = rf(transcript)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIPDF_GenerateTranscript_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GenerateTranscript'
+type MockIPDF_GenerateTranscript_Call struct {
+	*mock.Call
+}
+
+// GenerateTranscript is a helper method to define mock.On call
+//   - transcript pdf.Transcript
+func (_e *MockIPDF_Expecter) GenerateTranscript(transcript interface{}) *MockIPDF_GenerateTranscript_Call {
+	return &MockIPDF_GenerateTranscript_Call{Call: _e.mock.On("GenerateTranscript", transcript)}
+}
+
+func (_c *MockIPDF_GenerateTranscript_Call) Run(run func(transcript pdf.Transcript)) *MockIPDF_GenerateTranscript_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(pdf.Transcript))
+	})
+	return _c
+}
+
+func (_c *MockIPDF_GenerateTranscript_Call) Return(_a0 []byte, _a1 error) *MockIPDF_GenerateTranscript_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIPDF_GenerateTranscript_Call) RunAndReturn(run func(pdf.Transcript) ([]byte, error)) *MockIPDF_GenerateTranscript_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIPDF creates a new instance of MockIPDF. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIPDF(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIPDF {
+	mock := &MockIPDF{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
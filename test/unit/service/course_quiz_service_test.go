@@ -0,0 +1,572 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nathakusuma/elevateu-backend/domain/contract"
+	"github.com/nathakusuma/elevateu-backend/domain/ctxkey"
+	"github.com/nathakusuma/elevateu-backend/domain/dto"
+	"github.com/nathakusuma/elevateu-backend/domain/entity"
+	"github.com/nathakusuma/elevateu-backend/domain/enum"
+	"github.com/nathakusuma/elevateu-backend/domain/errorpkg"
+	"github.com/nathakusuma/elevateu-backend/internal/app/course/service"
+	"github.com/nathakusuma/elevateu-backend/internal/infra/database"
+	appmocks "github.com/nathakusuma/elevateu-backend/test/unit/mocks/app"
+	inframocks "github.com/nathakusuma/elevateu-backend/test/unit/mocks/infra"
+	pkgmocks "github.com/nathakusuma/elevateu-backend/test/unit/mocks/pkg"
+	_ "github.com/nathakusuma/elevateu-backend/test/unit/setup" // Initialize test environment
+)
+
+type courseQuizServiceMocks struct {
+	quizRepo     *appmocks.MockICourseQuizRepository
+	courseRepo   *appmocks.MockICourseRepository
+	progressRepo *appmocks.MockICourseProgressRepository
+	userRepo     *appmocks.MockIUserRepository
+	txManager    *inframocks.MockITransactionManager
+	tx           *inframocks.MockITransaction
+	uuid         *pkgmocks.MockIUUID
+}
+
+func setupCourseQuizServiceTest(t *testing.T) (contract.ICourseQuizService, *courseQuizServiceMocks) {
+	mocks := &courseQuizServiceMocks{
+		quizRepo:     appmocks.NewMockICourseQuizRepository(t),
+		courseRepo:   appmocks.NewMockICourseRepository(t),
+		progressRepo: appmocks.NewMockICourseProgressRepository(t),
+		userRepo:     appmocks.NewMockIUserRepository(t),
+		txManager:    inframocks.NewMockITransactionManager(t),
+		tx:           inframocks.NewMockITransaction(t),
+		uuid:         pkgmocks.NewMockIUUID(t),
+	}
+
+	// SubmitAttempt does not touch course contents
+	svc := service.NewCourseQuizService(mocks.quizRepo, nil, mocks.courseRepo, mocks.progressRepo,
+		mocks.userRepo, mocks.txManager, mocks.uuid)
+
+	return svc, mocks
+}
+
+// quizFixture is a quiz worth 10 points:
+// a multiple choice question worth 2 points where option B is correct,
+// a multi select question worth 3 points where options A and C are correct,
+// and a short answer question worth 5 points
+type quizFixture struct {
+	quiz                      *entity.CourseQuiz
+	choiceID, selectID        uuid.UUID
+	shortID                   uuid.UUID
+	choiceA, choiceB, choiceC uuid.UUID
+	selectA, selectB, selectC uuid.UUID
+}
+
+func newQuizFixture(passingScore, maxAttempts int) *quizFixture {
+	f := &quizFixture{
+		choiceID: uuid.New(),
+		selectID: uuid.New(),
+		shortID:  uuid.New(),
+		choiceA:  uuid.New(),
+		choiceB:  uuid.New(),
+		choiceC:  uuid.New(),
+		selectA:  uuid.New(),
+		selectB:  uuid.New(),
+		selectC:  uuid.New(),
+	}
+
+	f.quiz = &entity.CourseQuiz{
+		ID:           uuid.New(),
+		CourseID:     uuid.New(),
+		PassingScore: passingScore,
+		MaxAttempts:  maxAttempts,
+		Questions: []*entity.CourseQuizQuestion{
+			{
+				ID:     f.choiceID,
+				Type:   enum.QuizQuestionTypeMultipleChoice,
+				Points: 2,
+				Options: []*entity.CourseQuizOption{
+					{ID: f.choiceA},
+					{ID: f.choiceB, IsCorrect: true},
+					{ID: f.choiceC},
+				},
+			},
+			{
+				ID:     f.selectID,
+				Type:   enum.QuizQuestionTypeMultiSelect,
+				Points: 3,
+				Options: []*entity.CourseQuizOption{
+					{ID: f.selectA, IsCorrect: true},
+					{ID: f.selectB},
+					{ID: f.selectC, IsCorrect: true},
+				},
+			},
+			{
+				ID:     f.shortID,
+				Type:   enum.QuizQuestionTypeShortAnswer,
+				Points: 5,
+				Options: []*entity.CourseQuizOption{
+					{Text: "Go Routine", IsCorrect: true},
+					{Text: "goroutines", IsCorrect: true},
+				},
+			},
+		},
+	}
+
+	return f
+}
+
+// allCorrect answers every question of the fixture correctly
+func (f *quizFixture) allCorrect() []dto.CourseQuizAnswerRequest {
+	return []dto.CourseQuizAnswerRequest{
+		{QuestionID: f.choiceID, OptionIDs: []uuid.UUID{f.choiceB}},
+		{QuestionID: f.selectID, OptionIDs: []uuid.UUID{f.selectA, f.selectC}},
+		{QuestionID: f.shortID, Text: "goroutines"},
+	}
+}
+
+func studentContext(studentID uuid.UUID) context.Context {
+	ctx := context.WithValue(context.Background(), ctxkey.UserID, studentID)
+	ctx = context.WithValue(ctx, ctxkey.UserRole, enum.UserRoleStudent)
+	return context.WithValue(ctx, ctxkey.IsSubscribedBoost, true)
+}
+
+func assertResponseError(t *testing.T, want *errorpkg.ResponseError, err error) {
+	t.Helper()
+
+	var respErr *errorpkg.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	assert.Equal(t, want.Type, respErr.Type)
+}
+
+// expectAttemptStart expects the quiz to be loaded and the student to be enrolled
+func expectAttemptStart(mocks *courseQuizServiceMocks, quiz *entity.CourseQuiz, studentID, attemptID uuid.UUID) {
+	mocks.quizRepo.EXPECT().
+		GetQuizByID(mock.Anything, quiz.ID).
+		Return(quiz, nil).Once()
+
+	mocks.courseRepo.EXPECT().
+		GetEnrollment(mock.Anything, quiz.CourseID, studentID).
+		Return(&entity.CourseEnrollment{CourseID: quiz.CourseID, StudentID: studentID}, nil).Once()
+
+	mocks.uuid.EXPECT().
+		NewV7().
+		Return(attemptID, nil).Once()
+}
+
+// expectAttemptSaved expects the attempt to be counted and saved in a transaction, and returns the saved attempt
+func expectAttemptSaved(mocks *courseQuizServiceMocks, quiz *entity.CourseQuiz, studentID uuid.UUID,
+	attemptCount int) *entity.CourseQuizAttempt {
+	mocks.txManager.EXPECT().
+		BeginTx(mock.Anything).
+		Return(mocks.tx, nil).Once()
+
+	mocks.tx.EXPECT().
+		Rollback().
+		Return(nil).Maybe()
+
+	mocks.quizRepo.EXPECT().
+		CountAttempts(mock.Anything, mocks.tx, quiz.ID, studentID).
+		Return(attemptCount, nil).Once()
+
+	saved := &entity.CourseQuizAttempt{}
+	mocks.quizRepo.EXPECT().
+		CreateAttempt(mock.Anything, mocks.tx, mock.Anything).
+		Run(func(_ context.Context, _ database.ITransaction, attempt *entity.CourseQuizAttempt) {
+			*saved = *attempt
+		}).
+		Return(nil).Once()
+
+	mocks.tx.EXPECT().
+		Commit().
+		Return(nil).Once()
+
+	return saved
+}
+
+func Test_CourseQuizService_SubmitAttempt_Grading(t *testing.T) {
+	f := newQuizFixture(0, 0)
+
+	tests := []struct {
+		name         string
+		passingScore int
+		answers      []dto.CourseQuizAnswerRequest
+		wantScore    int
+		wantPassed   bool
+		wantCorrect  []bool
+		wantErr      bool
+	}{
+		{
+			name:         "all correct",
+			passingScore: 100,
+			answers: []dto.CourseQuizAnswerRequest{
+				{QuestionID: f.choiceID, OptionIDs: []uuid.UUID{f.choiceB}},
+				{QuestionID: f.selectID, OptionIDs: []uuid.UUID{f.selectC, f.selectA}},
+				{QuestionID: f.shortID, Text: "  go   ROUTINE "},
+			},
+			wantScore:   10,
+			wantPassed:  true,
+			wantCorrect: []bool{true, true, true},
+		},
+		{
+			name:         "wrong multiple choice option",
+			passingScore: 50,
+			answers: []dto.CourseQuizAnswerRequest{
+				{QuestionID: f.choiceID, OptionIDs: []uuid.UUID{f.choiceA}},
+			},
+			wantScore:   0,
+			wantPassed:  false,
+			wantCorrect: []bool{false, false, false},
+		},
+		{
+			name:         "multiple choice with the correct and a wrong option",
+			passingScore: 50,
+			answers: []dto.CourseQuizAnswerRequest{
+				{QuestionID: f.choiceID, OptionIDs: []uuid.UUID{f.choiceA, f.choiceB}},
+			},
+			wantScore:   0,
+			wantCorrect: []bool{false, false, false},
+		},
+		{
+			name:         "partial multi select earns no points",
+			passingScore: 50,
+			answers: []dto.CourseQuizAnswerRequest{
+				{QuestionID: f.selectID, OptionIDs: []uuid.UUID{f.selectA}},
+				{QuestionID: f.shortID, Text: "goroutines"},
+			},
+			wantScore:   5,
+			wantPassed:  true,
+			wantCorrect: []bool{false, false, true},
+		},
+		{
+			name:         "multi select with an extra wrong option",
+			passingScore: 50,
+			answers: []dto.CourseQuizAnswerRequest{
+				{QuestionID: f.selectID, OptionIDs: []uuid.UUID{f.selectA, f.selectB, f.selectC}},
+			},
+			wantScore:   0,
+			wantCorrect: []bool{false, false, false},
+		},
+		{
+			name:         "empty selection is incorrect",
+			passingScore: 50,
+			answers: []dto.CourseQuizAnswerRequest{
+				{QuestionID: f.selectID},
+			},
+			wantScore:   0,
+			wantCorrect: []bool{false, false, false},
+		},
+		{
+			name:         "score at the passing percentage",
+			passingScore: 80,
+			answers: []dto.CourseQuizAnswerRequest{
+				{QuestionID: f.selectID, OptionIDs: []uuid.UUID{f.selectA, f.selectC}},
+				{QuestionID: f.shortID, Text: "goroutines"},
+			},
+			wantScore:   8,
+			wantPassed:  true,
+			wantCorrect: []bool{false, true, true},
+		},
+		{
+			name:         "score below the passing percentage",
+			passingScore: 81,
+			answers: []dto.CourseQuizAnswerRequest{
+				{QuestionID: f.selectID, OptionIDs: []uuid.UUID{f.selectA, f.selectC}},
+				{QuestionID: f.shortID, Text: "goroutines"},
+			},
+			wantScore:   8,
+			wantPassed:  false,
+			wantCorrect: []bool{false, true, true},
+		},
+		{
+			name:         "no answers",
+			passingScore: 0,
+			wantScore:    0,
+			wantPassed:   true,
+			wantCorrect:  []bool{false, false, false},
+		},
+		{
+			name:         "question answered twice",
+			passingScore: 50,
+			answers: []dto.CourseQuizAnswerRequest{
+				{QuestionID: f.choiceID, OptionIDs: []uuid.UUID{f.choiceB}},
+				{QuestionID: f.choiceID, OptionIDs: []uuid.UUID{f.choiceA}},
+			},
+			wantErr: true,
+		},
+		{
+			name:         "question not in the quiz",
+			passingScore: 50,
+			answers: []dto.CourseQuizAnswerRequest{
+				{QuestionID: uuid.New(), Text: "answer"},
+			},
+			wantErr: true,
+		},
+		{
+			name:         "option of another question",
+			passingScore: 50,
+			answers: []dto.CourseQuizAnswerRequest{
+				{QuestionID: f.choiceID, OptionIDs: []uuid.UUID{f.selectA}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, mocks := setupCourseQuizServiceTest(t)
+
+			studentID := uuid.New()
+			attemptID := uuid.New()
+			quiz := *f.quiz
+			quiz.PassingScore = tt.passingScore
+
+			expectAttemptStart(mocks, &quiz, studentID, attemptID)
+
+			if tt.wantErr {
+				resp, err := svc.SubmitAttempt(studentContext(studentID), studentID, quiz.ID,
+					dto.SubmitCourseQuizRequest{Answers: tt.answers})
+
+				assertResponseError(t, errorpkg.ErrValidation(), err)
+				assert.Nil(t, resp)
+				return
+			}
+
+			saved := expectAttemptSaved(mocks, &quiz, studentID, 0)
+			if tt.wantPassed {
+				// the quiz was passed before, so the course progress stays the same
+				mocks.progressRepo.EXPECT().
+					UpdateQuizProgress(mock.Anything, mocks.tx, entity.CourseQuizProgress{
+						StudentID: studentID,
+						QuizID:    quiz.ID,
+					}).
+					Return(false, nil).Once()
+			}
+
+			resp, err := svc.SubmitAttempt(studentContext(studentID), studentID, quiz.ID,
+				dto.SubmitCourseQuizRequest{Answers: tt.answers})
+
+			require.NoError(t, err)
+			assert.Equal(t, attemptID, resp.ID)
+			assert.Equal(t, quiz.ID, resp.QuizID)
+			assert.Equal(t, tt.wantScore, resp.Score)
+			assert.Equal(t, 10, resp.MaxScore)
+			assert.Equal(t, tt.wantPassed, resp.IsPassed)
+
+			require.Len(t, resp.Results, len(quiz.Questions))
+			for i, result := range resp.Results {
+				assert.Equal(t, quiz.Questions[i].ID, result.QuestionID)
+				assert.Equal(t, tt.wantCorrect[i], result.IsCorrect)
+			}
+
+			assert.Equal(t, attemptID, saved.ID)
+			assert.Equal(t, studentID, saved.StudentID)
+			assert.Equal(t, tt.wantScore, saved.Score)
+			for _, answer := range saved.Answers {
+				assert.Equal(t, attemptID, answer.AttemptID)
+			}
+		})
+	}
+}
+
+func Test_CourseQuizService_SubmitAttempt(t *testing.T) {
+	t.Run("success - first completion of the quiz advances the course", func(t *testing.T) {
+		svc, mocks := setupCourseQuizServiceTest(t)
+
+		f := newQuizFixture(80, 3)
+		studentID := uuid.New()
+		attemptID := uuid.New()
+
+		expectAttemptStart(mocks, f.quiz, studentID, attemptID)
+		expectAttemptSaved(mocks, f.quiz, studentID, 2)
+
+		mocks.progressRepo.EXPECT().
+			UpdateQuizProgress(mock.Anything, mocks.tx, entity.CourseQuizProgress{
+				StudentID: studentID,
+				QuizID:    f.quiz.ID,
+			}).
+			Return(true, nil).Once()
+
+		mocks.progressRepo.EXPECT().
+			IncrementCourseProgress(mock.Anything, mocks.tx, f.quiz.CourseID, studentID).
+			Return(false, nil).Once()
+
+		resp, err := svc.SubmitAttempt(studentContext(studentID), studentID, f.quiz.ID,
+			dto.SubmitCourseQuizRequest{Answers: f.allCorrect()})
+
+		require.NoError(t, err)
+		assert.True(t, resp.IsPassed)
+		assert.Equal(t, 100, resp.Percentage)
+	})
+
+	t.Run("success - completing the course awards points", func(t *testing.T) {
+		svc, mocks := setupCourseQuizServiceTest(t)
+
+		f := newQuizFixture(80, 0)
+		studentID := uuid.New()
+		attemptID := uuid.New()
+
+		expectAttemptStart(mocks, f.quiz, studentID, attemptID)
+		expectAttemptSaved(mocks, f.quiz, studentID, 5)
+
+		mocks.progressRepo.EXPECT().
+			UpdateQuizProgress(mock.Anything, mocks.tx, mock.Anything).
+			Return(true, nil).Once()
+
+		mocks.progressRepo.EXPECT().
+			IncrementCourseProgress(mock.Anything, mocks.tx, f.quiz.CourseID, studentID).
+			Return(true, nil).Once()
+
+		mocks.userRepo.EXPECT().
+			AddPoint(mock.Anything, mocks.tx, studentID, 50).
+			Return(nil).Once()
+
+		resp, err := svc.SubmitAttempt(studentContext(studentID), studentID, f.quiz.ID,
+			dto.SubmitCourseQuizRequest{Answers: f.allCorrect()})
+
+		require.NoError(t, err)
+		assert.True(t, resp.IsPassed)
+	})
+
+	t.Run("success - failed attempt leaves progress untouched", func(t *testing.T) {
+		svc, mocks := setupCourseQuizServiceTest(t)
+
+		f := newQuizFixture(80, 3)
+		studentID := uuid.New()
+		attemptID := uuid.New()
+
+		expectAttemptStart(mocks, f.quiz, studentID, attemptID)
+		saved := expectAttemptSaved(mocks, f.quiz, studentID, 0)
+
+		resp, err := svc.SubmitAttempt(studentContext(studentID), studentID, f.quiz.ID,
+			dto.SubmitCourseQuizRequest{Answers: []dto.CourseQuizAnswerRequest{
+				{QuestionID: f.shortID, Text: "goroutines"},
+			}})
+
+		require.NoError(t, err)
+		assert.False(t, resp.IsPassed)
+		assert.False(t, saved.IsPassed)
+		mocks.progressRepo.AssertNotCalled(t, "UpdateQuizProgress", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("success - quiz without points never passes", func(t *testing.T) {
+		svc, mocks := setupCourseQuizServiceTest(t)
+
+		quiz := &entity.CourseQuiz{ID: uuid.New(), CourseID: uuid.New()}
+		studentID := uuid.New()
+		attemptID := uuid.New()
+
+		expectAttemptStart(mocks, quiz, studentID, attemptID)
+		expectAttemptSaved(mocks, quiz, studentID, 0)
+
+		resp, err := svc.SubmitAttempt(studentContext(studentID), studentID, quiz.ID,
+			dto.SubmitCourseQuizRequest{})
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, resp.MaxScore)
+		assert.False(t, resp.IsPassed)
+	})
+
+	t.Run("error - attempt limit reached", func(t *testing.T) {
+		svc, mocks := setupCourseQuizServiceTest(t)
+
+		f := newQuizFixture(80, 3)
+		studentID := uuid.New()
+		attemptID := uuid.New()
+
+		expectAttemptStart(mocks, f.quiz, studentID, attemptID)
+
+		mocks.txManager.EXPECT().
+			BeginTx(mock.Anything).
+			Return(mocks.tx, nil).Once()
+
+		mocks.tx.EXPECT().
+			Rollback().
+			Return(nil).Once()
+
+		mocks.quizRepo.EXPECT().
+			CountAttempts(mock.Anything, mocks.tx, f.quiz.ID, studentID).
+			Return(3, nil).Once()
+
+		resp, err := svc.SubmitAttempt(studentContext(studentID), studentID, f.quiz.ID,
+			dto.SubmitCourseQuizRequest{Answers: f.allCorrect()})
+
+		assertResponseError(t, errorpkg.ErrQuizAttemptLimitReached(), err)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("error - not enrolled", func(t *testing.T) {
+		svc, mocks := setupCourseQuizServiceTest(t)
+
+		f := newQuizFixture(80, 3)
+		studentID := uuid.New()
+
+		mocks.quizRepo.EXPECT().
+			GetQuizByID(mock.Anything, f.quiz.ID).
+			Return(f.quiz, nil).Once()
+
+		mocks.courseRepo.EXPECT().
+			GetEnrollment(mock.Anything, f.quiz.CourseID, studentID).
+			Return(nil, errors.New("enrollment not found")).Once()
+
+		// admins can see every course, so only the enrollment decides
+		ctx := context.WithValue(studentContext(studentID), ctxkey.UserRole, enum.UserRoleAdmin)
+
+		resp, err := svc.SubmitAttempt(ctx, studentID, f.quiz.ID,
+			dto.SubmitCourseQuizRequest{Answers: f.allCorrect()})
+
+		assertResponseError(t, errorpkg.ErrNotEnrolled(), err)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("error - quiz not found", func(t *testing.T) {
+		svc, mocks := setupCourseQuizServiceTest(t)
+
+		quizID := uuid.New()
+		studentID := uuid.New()
+
+		mocks.quizRepo.EXPECT().
+			GetQuizByID(mock.Anything, quizID).
+			Return(nil, errors.New("quiz not found")).Once()
+
+		resp, err := svc.SubmitAttempt(studentContext(studentID), studentID, quizID,
+			dto.SubmitCourseQuizRequest{})
+
+		assertResponseError(t, errorpkg.ErrNotFound(), err)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("error - saving the attempt fails", func(t *testing.T) {
+		svc, mocks := setupCourseQuizServiceTest(t)
+
+		f := newQuizFixture(80, 0)
+		studentID := uuid.New()
+		attemptID := uuid.New()
+
+		expectAttemptStart(mocks, f.quiz, studentID, attemptID)
+
+		mocks.txManager.EXPECT().
+			BeginTx(mock.Anything).
+			Return(mocks.tx, nil).Once()
+
+		mocks.tx.EXPECT().
+			Rollback().
+			Return(nil).Once()
+
+		mocks.quizRepo.EXPECT().
+			CountAttempts(mock.Anything, mocks.tx, f.quiz.ID, studentID).
+			Return(0, nil).Once()
+
+		mocks.quizRepo.EXPECT().
+			CreateAttempt(mock.Anything, mocks.tx, mock.Anything).
+			Return(errors.New("db error")).Once()
+
+		resp, err := svc.SubmitAttempt(studentContext(studentID), studentID, f.quiz.ID,
+			dto.SubmitCourseQuizRequest{Answers: f.allCorrect()})
+
+		assertResponseError(t, errorpkg.ErrInternalServer(), err)
+		assert.Nil(t, resp)
+	})
+}